@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"lazyargo/internal/argocd"
+	"lazyargo/internal/config"
+	"lazyargo/internal/ui"
+)
+
+// runDiff implements `lazyargo diff [--exit-code] <app>`. Like git diff, it
+// exits 0 regardless of drift unless --exit-code is set, in which case a
+// drifted app exits 1. Errors exit 2.
+func runDiff(args []string) int {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	var o options
+	o.register(fs)
+	exitCode := fs.Bool("exit-code", false, "exit 1 if the app has drifted, 0 if it is in sync")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: lazyargo diff [flags] <app>")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	name := fs.Arg(0)
+
+	cfg, err := o.loadConfig()
+	if err != nil {
+		slog.Error("config error", "err", err)
+		return 2
+	}
+	tracer, err := o.openTrace()
+	if err != nil {
+		slog.Error("open trace file", "path", o.trace, "err", err)
+		return 2
+	}
+	defer tracer.Close()
+	return diffApp(context.Background(), o.newClient(cfg, tracer), os.Stdout, name, *exitCode, cfg.UI.Theme)
+}
+
+// diffApp writes name's server-side diff to w and returns the exit code
+// runDiff documents.
+func diffApp(ctx context.Context, c argocd.Client, w io.Writer, name string, exitCode bool, theme config.Theme) int {
+	diffs, err := c.ServerSideDiff(ctx, name)
+	if err != nil {
+		slog.Error("diff failed", "app", name, "err", err)
+		return 2
+	}
+
+	drifted := false
+	for _, d := range diffs {
+		if d.Modified {
+			drifted = true
+		}
+	}
+	if !drifted {
+		fmt.Fprintf(w, "%s: in sync\n", name)
+		return 0
+	}
+	fmt.Fprint(w, ui.RenderDiffs(diffs, theme))
+	if exitCode {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"lazyargo/internal/argocd"
+	"lazyargo/internal/config"
+)
+
+func TestDiffApp_exitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   argocd.Client
+		app      string
+		exitCode bool
+		want     int
+		wantOut  string
+	}{
+		{name: "in sync", client: argocd.NewMockClient(), app: "payments-api", exitCode: true, want: 0, wantOut: "payments-api: in sync\n"},
+		{name: "drifted", client: argocd.NewMockClient(), app: "web-frontend", exitCode: true, want: 1, wantOut: "+ replicas: 2"},
+		{name: "drifted without --exit-code", client: argocd.NewMockClient(), app: "web-frontend", want: 0, wantOut: "+ replicas: 2"},
+		{name: "client error", client: argocd.NewMockClient(argocd.WithErrorRate(1)), app: "payments-api", exitCode: true, want: 2},
+		{name: "unknown app", client: argocd.NewMockClient(), app: "missing", exitCode: true, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			got := diffApp(context.Background(), tt.client, &b, tt.app, tt.exitCode, config.Default().UI.Theme)
+			if got != tt.want {
+				t.Fatalf("exit code = %d, want %d (output %q)", got, tt.want, b.String())
+			}
+			if !strings.Contains(b.String(), tt.wantOut) {
+				t.Fatalf("output %q does not contain %q", b.String(), tt.wantOut)
+			}
+		})
+	}
+}
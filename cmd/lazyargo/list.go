@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"lazyargo/internal/argocd"
+)
+
+// appJSON is the stable `list --output json` schema. Add fields, don't rename them.
+type appJSON struct {
+	Name       string `json:"name"`
+	Project    string `json:"project"`
+	Health     string `json:"health"`
+	Sync       string `json:"sync"`
+	SyncPolicy string `json:"syncPolicy"`
+	RepoURL    string `json:"repoURL"`
+	Path       string `json:"path"`
+	Revision   string `json:"targetRevision"`
+	Cluster    string `json:"cluster"`
+	Namespace  string `json:"namespace"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func toAppJSON(a argocd.Application) appJSON {
+	return appJSON{
+		Name:       a.Name,
+		Project:    a.Project,
+		Health:     a.Health,
+		Sync:       a.Sync,
+		SyncPolicy: a.SyncPolicy,
+		RepoURL:    a.RepoURL,
+		Path:       a.Path,
+		Revision:   a.Revision,
+		Cluster:    a.Cluster,
+		Namespace:  a.Namespace,
+		Labels:     a.Labels,
+	}
+}
+
+// writeApps prints apps as a JSON array, CSV or an aligned table.
+func writeApps(w io.Writer, apps []argocd.Application, output string) error {
+	switch output {
+	case "json":
+		out := make([]appJSON, 0, len(apps))
+		for _, a := range apps {
+			out = append(out, toAppJSON(a))
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	case "csv":
+		return argocd.WriteApplicationsCSV(w, apps)
+	case "table", "":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tPROJECT\tHEALTH\tSYNC\tNAMESPACE\tREVISION")
+		for _, a := range apps {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", a.Name, dash(a.Project), dash(a.Health), dash(a.Sync), dash(a.Namespace), dash(a.Revision))
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown output format %q (want json, csv or table)", output)
+	}
+}
+
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// runList implements `lazyargo list [--output json|csv|table]`.
+func runList(args []string) int {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	var o options
+	o.register(fs)
+	output := fs.String("output", "table", "output format: table, json or csv")
+	_ = fs.Parse(args)
+
+	cfg, err := o.loadConfig()
+	if err != nil {
+		slog.Error("config error", "err", err)
+		return 1
+	}
+	tracer, err := o.openTrace()
+	if err != nil {
+		slog.Error("open trace file", "path", o.trace, "err", err)
+		return 1
+	}
+	defer tracer.Close()
+	if err := listApps(context.Background(), o.newClient(cfg, tracer), os.Stdout, *output); err != nil {
+		slog.Error("list applications", "err", err)
+		return 1
+	}
+	return 0
+}
+
+// listApps lists the applications c sees and writes them to w.
+func listApps(ctx context.Context, c argocd.Client, w io.Writer, output string) error {
+	apps, err := c.ListApplications(ctx)
+	if err != nil {
+		return err
+	}
+	return writeApps(w, apps, output)
+}
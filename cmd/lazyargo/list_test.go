@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"lazyargo/internal/argocd"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestListApps(t *testing.T) {
+	for _, output := range []string{"table", "json", "csv"} {
+		t.Run(output, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := listApps(context.Background(), argocd.NewMockClient(), &b, output); err != nil {
+				t.Fatal(err)
+			}
+			golden := filepath.Join("testdata", "list."+output+".golden")
+			if *update {
+				if err := os.WriteFile(golden, b.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != string(want) {
+				t.Fatalf("list --output %s =\n%s\nwant\n%s", output, b.String(), want)
+			}
+		})
+	}
+}
+
+func TestListApps_unknownFormat(t *testing.T) {
+	var b bytes.Buffer
+	err := listApps(context.Background(), argocd.NewMockClient(), &b, "yaml")
+	if err == nil || err.Error() != `unknown output format "yaml" (want json, csv or table)` {
+		t.Fatalf("expected an unknown format error, got %v", err)
+	}
+	if b.Len() != 0 {
+		t.Fatalf("expected no output, got %q", b.String())
+	}
+}
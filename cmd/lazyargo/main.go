@@ -2,10 +2,14 @@ package main
 
 import (
 	"flag"
+	"io"
 	"log/slog"
 	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 
 	"lazyargo/internal/argocd"
 	"lazyargo/internal/config"
@@ -36,75 +40,295 @@ func parseLogLevel(s string) slog.Level {
 	}
 }
 
-func main() {
-	// Set a reasonable default logger early so startup/config errors are structured.
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo})))
+// tuiLogHandler returns the handler to use while the TUI owns the terminal:
+// the log file when one is configured, otherwise a handler that drops
+// everything. The returned close func must run after the program exits.
+func tuiLogHandler(cfg config.Config) (slog.Handler, func(), error) {
+	opts := &slog.HandlerOptions{Level: parseLogLevel(cfg.LogLevel)}
+	if cfg.LogFile == "" {
+		return slog.NewTextHandler(io.Discard, opts), func() {}, nil
+	}
+	f, err := os.OpenFile(cfg.LogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, nil, err
+	}
+	return slog.NewTextHandler(f, opts), func() { f.Close() }, nil
+}
 
-	var (
-		configPath string
-		useMock    bool
-		server     string
-		username   string
-		password   string
-		token      string
-		insecure   bool
-		logLevel   string
-	)
-
-	flag.StringVar(&configPath, "config", "", "path to config file (optional)")
-	flag.BoolVar(&useMock, "mock", false, "use mock Argo CD client")
-	flag.StringVar(&server, "server", "", "Argo CD server URL (overrides config + ARGOCD_SERVER)")
-	flag.StringVar(&username, "username", "", "Argo CD username (or ARGOCD_USERNAME; optional)")
-	flag.StringVar(&password, "password", "", "Argo CD password (or ARGOCD_PASSWORD; optional)")
-	flag.StringVar(&token, "token", "", "Argo CD auth token (overrides config + ARGOCD_AUTH_TOKEN)")
-	flag.BoolVar(&insecure, "insecure", false, "skip TLS verification (or set ARGOCD_INSECURE=true)")
-	flag.StringVar(&logLevel, "log-level", "", "log level (debug, info, warn, error)")
-	flag.Parse()
+// options holds the flags shared by the TUI and every subcommand.
+type options struct {
+	configPath string
+	useMock    bool
+	server     string
+	username   string
+	password   string
+	token      string
+	tokenFile  string
+	insecure   bool
+	anonymous  bool
+	clientCert string
+	clientKey  string
+	caCert     string
+	proxy      string
+	grpcWeb    bool
+	argocdCfg  bool
+	logLevel   string
+	logFile    string
+	noColor    bool
+	refresh    time.Duration
+	debug      bool
+	trace      string
 
-	cfg, err := config.Load(configPath)
+	mockLatency time.Duration
+	mockFail    float64
+}
+
+func (o *options) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.configPath, "config", "", "path to config file (optional)")
+	fs.BoolVar(&o.useMock, "mock", false, "use mock Argo CD client")
+	fs.StringVar(&o.server, "server", "", "Argo CD server URL (overrides config + ARGOCD_SERVER)")
+	fs.StringVar(&o.username, "username", "", "Argo CD username (or ARGOCD_USERNAME; optional)")
+	fs.StringVar(&o.password, "password", "", "Argo CD password (or ARGOCD_PASSWORD; optional)")
+	fs.StringVar(&o.token, "token", "", "Argo CD auth token (overrides config + ARGOCD_AUTH_TOKEN)")
+	fs.StringVar(&o.tokenFile, "token-file", "", "read the Argo CD auth token from this file (overrides config + ARGOCD_AUTH_TOKEN; --token wins)")
+	fs.BoolVar(&o.insecure, "insecure", false, "skip TLS verification (or set ARGOCD_INSECURE=true)")
+	fs.BoolVar(&o.anonymous, "anonymous", false, "connect without credentials (server must allow anonymous access)")
+	fs.StringVar(&o.clientCert, "client-cert", "", "client certificate (PEM) for mutual TLS")
+	fs.StringVar(&o.clientKey, "client-key", "", "client certificate key (PEM) for mutual TLS")
+	fs.StringVar(&o.proxy, "proxy", "", "proxy URL for all API calls, e.g. http://proxy:3128 (overrides HTTP(S)_PROXY)")
+	fs.BoolVar(&o.grpcWeb, "grpc-web", false, "talk to the server over gRPC-web (list, get and sync only)")
+	fs.BoolVar(&o.argocdCfg, "use-argocd-config", false, "take the server and token from the argocd CLI's current context (~/.config/argocd/config)")
+	fs.StringVar(&o.caCert, "ca-cert", "", "PEM bundle of CAs to trust for the server certificate (preferred over --insecure)")
+	fs.StringVar(&o.logLevel, "log-level", "", "log level (debug, info, warn, error)")
+	fs.StringVar(&o.logFile, "log-file", "", "write logs to this file while the TUI runs (or LAZYARGO_LOG_FILE)")
+	fs.BoolVar(&o.noColor, "no-color", false, "disable colors (or set NO_COLOR)")
+	fs.DurationVar(&o.refresh, "refresh", 0, "auto-refresh the app list at this interval, e.g. 30s (overrides config)")
+	fs.DurationVar(&o.mockLatency, "mock-latency", 0, "with --mock: delay every call by this long, e.g. 800ms")
+	fs.Float64Var(&o.mockFail, "mock-fail", 0, "with --mock: fail this fraction of calls (0-1)")
+	fs.BoolVar(&o.debug, "debug", false, "record recent API requests for the debug overlay (ctrl+g)")
+	fs.StringVar(&o.trace, "trace", "", "write every API request and response (redacted) to this file as JSON lines, for bug reports")
+}
+
+// loadConfig loads the config file and environment, then applies CLI
+// overrides and configures the stderr logger.
+func (o options) loadConfig() (config.Config, error) {
+	cfg, err := config.Load(o.configPath)
 	if err != nil {
-		slog.Error("config error", "err", err)
-		os.Exit(1)
+		return config.Config{}, err
+	}
+
+	if o.argocdCfg {
+		if err := useArgoCDContext(&cfg); err != nil {
+			return config.Config{}, err
+		}
 	}
 
 	// CLI overrides.
-	if server != "" {
-		cfg.ArgoCD.Server = server
+	if o.server != "" {
+		cfg.ArgoCD.Server = o.server
+	}
+	if cfg.ArgoCD.Server != "" {
+		s, err := argocd.NormalizeServerURL(cfg.ArgoCD.Server)
+		if err != nil {
+			return config.Config{}, err
+		}
+		cfg.ArgoCD.Server = s
 	}
-	if token != "" {
-		cfg.ArgoCD.Token = token
+	if o.tokenFile != "" {
+		cfg.ArgoCD.TokenFile = o.tokenFile
+	}
+	tok, err := config.ResolveToken(o.token, cfg)
+	if err != nil {
+		return config.Config{}, err
 	}
-	if insecure {
+	cfg.ArgoCD.Token = tok
+	if o.insecure {
 		cfg.ArgoCD.InsecureSkipVerify = true
 	}
-	if logLevel != "" {
-		cfg.LogLevel = logLevel
+	if o.anonymous {
+		cfg.ArgoCD.Anonymous = true
+	}
+	if o.clientCert != "" {
+		cfg.ArgoCD.ClientCert = o.clientCert
+	}
+	if o.clientKey != "" {
+		cfg.ArgoCD.ClientKey = o.clientKey
+	}
+	if o.caCert != "" {
+		cfg.ArgoCD.CACert = o.caCert
+	}
+	if o.proxy != "" {
+		cfg.ArgoCD.Proxy = o.proxy
+	}
+	if cfg.ArgoCD.Proxy != "" {
+		if _, err := argocd.ParseProxyURL(cfg.ArgoCD.Proxy); err != nil {
+			return config.Config{}, err
+		}
+	}
+	if o.grpcWeb {
+		cfg.ArgoCD.Transport = "grpc-web"
+	}
+	if o.logLevel != "" {
+		cfg.LogLevel = o.logLevel
+	}
+	if o.logFile != "" {
+		cfg.LogFile = o.logFile
+	}
+	if o.noColor {
+		cfg.UI.NoColor = true
+	}
+	if o.refresh > 0 {
+		cfg.UI.RefreshInterval = o.refresh
+	}
+	if o.debug {
+		cfg.Debug = true
+	}
+	if cfg.UI.NoColor {
+		lipgloss.SetColorProfile(termenv.Ascii)
 	}
 
-	// Configure the logger after config+flags are applied.
+	// Configure the logger after config+flags are applied. This stderr logger
+	// only covers startup; see tuiLogHandler for the session itself.
 	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: parseLogLevel(cfg.LogLevel)})))
+	return cfg, nil
+}
 
+// useArgoCDContext replaces the config file's server settings with the
+// argocd CLI's current context. ARGOCD_SERVER and ARGOCD_AUTH_TOKEN still
+// win, as they do for the CLI itself, and flags are applied afterwards.
+func useArgoCDContext(cfg *config.Config) error {
+	path, err := config.DefaultArgoCDConfigPath()
+	if err != nil {
+		return err
+	}
+	ctx, err := config.LoadArgoCDContext(path)
+	if err != nil {
+		return err
+	}
+	if os.Getenv("ARGOCD_SERVER") == "" {
+		cfg.ArgoCD.Server = ctx.Server
+		cfg.ArgoCD.InsecureSkipVerify = cfg.ArgoCD.InsecureSkipVerify || ctx.Insecure
+		if ctx.GRPCWeb {
+			cfg.ArgoCD.Transport = "grpc-web"
+		}
+	}
+	if os.Getenv("ARGOCD_AUTH_TOKEN") == "" && ctx.Token != "" {
+		cfg.ArgoCD.Token = ctx.Token
+	}
+	slog.Debug("using argocd CLI context", "context", ctx.Name, "path", path)
+	return nil
+}
+
+// openTrace opens the --trace file, or returns nil when tracing is off.
+func (o options) openTrace() (*argocd.Tracer, error) {
+	if o.trace == "" {
+		return nil, nil
+	}
+	return argocd.OpenTraceFile(o.trace)
+}
+
+// newClient builds the Argo CD client: the mock when requested or when no
+// server is configured, otherwise the HTTP client (wrapped for gRPC-web when
+// that transport is selected). tracer, when set, records its requests.
+func (o options) newClient(cfg config.Config, tracer *argocd.Tracer) argocd.Client {
 	// Username/password are only for future/optional flows.
-	usr := firstNonEmpty(username, os.Getenv("ARGOCD_USERNAME"))
-	pwd := firstNonEmpty(password, os.Getenv("ARGOCD_PASSWORD"))
+	usr := firstNonEmpty(o.username, os.Getenv("ARGOCD_USERNAME"))
+	pwd := firstNonEmpty(o.password, os.Getenv("ARGOCD_PASSWORD"))
 
-	var client argocd.Client
-	if useMock || cfg.ArgoCD.Server == "" {
-		client = argocd.NewMockClient()
+	if o.useMock || cfg.ArgoCD.Server == "" {
 		slog.Info("using mock argocd client")
-	} else {
-		h := argocd.NewHTTPClient(cfg.ArgoCD.Server)
-		h.AuthToken = cfg.ArgoCD.Token
-		h.Username = usr
-		h.Password = pwd
-		h.Insecure = cfg.ArgoCD.InsecureSkipVerify
-		client = h
+		return argocd.NewMockClient(argocd.WithLatency(o.mockLatency), argocd.WithErrorRate(o.mockFail))
+	}
+	h := argocd.NewHTTPClient(cfg.ArgoCD.Server)
+	h.AuthToken = cfg.ArgoCD.Token
+	if o.token == "" {
+		// Follow rotations of the file the token came from.
+		h.TokenFile = cfg.ArgoCD.TokenFile
+	}
+	h.Username = usr
+	h.Password = pwd
+	h.Insecure = cfg.ArgoCD.InsecureSkipVerify
+	h.Anonymous = cfg.ArgoCD.Anonymous
+	h.ClientCert = cfg.ArgoCD.ClientCert
+	h.ClientKey = cfg.ArgoCD.ClientKey
+	h.CACert = cfg.ArgoCD.CACert
+	h.Proxy = cfg.ArgoCD.Proxy
+	h.ListResources = cfg.UI.SidebarDriftCounts
+	if cfg.ArgoCD.MaxIdleConnsPerHost > 0 {
+		h.MaxIdleConnsPerHost = cfg.ArgoCD.MaxIdleConnsPerHost
+	}
+	h.IdleConnTimeout = cfg.ArgoCD.IdleConnTimeout
+	h.KeepAlive = cfg.ArgoCD.KeepAlive
+	if cfg.ArgoCD.RateLimitRetries != 0 {
+		h.RateLimitRetries = max(0, cfg.ArgoCD.RateLimitRetries)
+	}
+	if cfg.Debug {
+		h.Requests = argocd.NewRequestLog(debugRequestLogSize)
+	}
+	h.Trace = tracer
+	if cfg.ArgoCD.Transport == "grpc-web" {
+		return argocd.NewGRPCWebClient(h)
+	}
+	return h
+}
+
+// debugRequestLogSize is how many API calls --debug keeps for the overlay.
+const debugRequestLogSize = 200
+
+// subcommands run without the TUI. Each gets the shared flags plus its own,
+// and returns the process exit code.
+var subcommands = map[string]func(args []string) int{
+	"diff": runDiff,
+	"list": runList,
+	"sync": runSync,
+}
+
+func main() {
+	// Set a reasonable default logger early so startup/config errors are structured.
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			os.Exit(run(os.Args[2:]))
+		}
+	}
+
+	var o options
+	o.register(flag.CommandLine)
+	flag.Parse()
+
+	cfg, err := o.loadConfig()
+	if err != nil {
+		slog.Error("config error", "err", err)
+		os.Exit(1)
+	}
+	if err := ui.ValidateCustomActionKeys(cfg.CustomActions); err != nil {
+		slog.Error("config error", "err", err)
+		os.Exit(1)
+	}
+	tracer, err := o.openTrace()
+	if err != nil {
+		slog.Error("open trace file", "path", o.trace, "err", err)
+		os.Exit(1)
 	}
+	client := o.newClient(cfg, tracer)
 
 	m := ui.NewModel(cfg, client)
 
+	h, closeLog, err := tuiLogHandler(cfg)
+	if err != nil {
+		slog.Error("open log file", "path", cfg.LogFile, "err", err)
+		os.Exit(1)
+	}
+	stderrLog := slog.Default()
+	slog.SetDefault(slog.New(h))
+
 	p := tea.NewProgram(m, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	_, err = p.Run()
+	closeLog()
+	tracer.Close()
+	slog.SetDefault(stderrLog)
+	if err != nil {
 		slog.Error("tui exited with error", "err", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lazyargo/internal/config"
+)
+
+func TestTUILogHandler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lazyargo.log")
+	h, closeLog, err := tuiLogHandler(config.Config{LogLevel: "warn", LogFile: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := slog.New(h)
+	log.Info("dropped below level")
+	log.Warn("sync failed", "app", "payments-api")
+	closeLog()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(b)
+	if !strings.Contains(got, `msg="sync failed" app=payments-api`) {
+		t.Fatalf("expected the warning in the log file, got %q", got)
+	}
+	if strings.Contains(got, "dropped below level") {
+		t.Fatalf("expected info to be filtered at warn, got %q", got)
+	}
+
+	// Reopening appends rather than truncating.
+	h, closeLog, err = tuiLogHandler(config.Config{LogLevel: "warn", LogFile: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slog.New(h).Error("second session")
+	closeLog()
+	b, _ = os.ReadFile(path)
+	if got := string(b); !strings.Contains(got, "sync failed") || !strings.Contains(got, "second session") {
+		t.Fatalf("expected both sessions in the log file, got %q", got)
+	}
+}
+
+func TestTUILogHandler_noFile(t *testing.T) {
+	h, closeLog, err := tuiLogHandler(config.Config{LogLevel: "debug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeLog()
+	if !h.Enabled(context.Background(), slog.LevelDebug) {
+		t.Fatal("expected the discarding handler to keep the configured level")
+	}
+	// Must not write anywhere, least of all the terminal the TUI owns.
+	slog.New(h).Error("nowhere")
+}
+
+func TestTUILogHandler_openError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "lazyargo.log")
+	if _, _, err := tuiLogHandler(config.Config{LogFile: path}); err == nil {
+		t.Fatal("expected an error for a log file in a missing directory")
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"lazyargo/internal/argocd"
+)
+
+// syncPollInterval is how often `sync --wait` polls the operation state.
+const syncPollInterval = 2 * time.Second
+
+// runSync implements `lazyargo sync <app> [--dry-run] [--prune] [--wait]`.
+func runSync(args []string) int {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	var o options
+	o.register(fs)
+	dryRun := fs.Bool("dry-run", false, "validate and simulate the sync without applying it")
+	prune := fs.Bool("prune", false, "delete resources no longer defined in git")
+	wait := fs.Bool("wait", false, "wait for the operation to finish and exit non-zero if it fails")
+	timeout := fs.Duration("timeout", 5*time.Minute, "how long --wait waits before giving up")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: lazyargo sync [flags] <app>")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	name := fs.Arg(0)
+
+	cfg, err := o.loadConfig()
+	if err != nil {
+		slog.Error("config error", "err", err)
+		return 1
+	}
+	tracer, err := o.openTrace()
+	if err != nil {
+		slog.Error("open trace file", "path", o.trace, "err", err)
+		return 1
+	}
+	defer tracer.Close()
+	client := o.newClient(cfg, tracer)
+
+	started := time.Now()
+	if err := client.SyncApplication(context.Background(), name, argocd.SyncOptions{DryRun: *dryRun, Prune: *prune}); err != nil {
+		slog.Error("sync failed", "app", name, "err", err)
+		return 1
+	}
+	if !*wait {
+		fmt.Printf("%s: sync started\n", name)
+		return 0
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	app, err := waitForOperation(ctx, client, name, started)
+	if err != nil {
+		slog.Error("wait for sync", "app", name, "err", err)
+		return 1
+	}
+
+	phase := "Succeeded"
+	msg := ""
+	if app.OperationState != nil {
+		phase = app.OperationState.Phase
+		msg = app.OperationState.Message
+	}
+	fmt.Printf("%s: %s (sync %s, health %s)\n", name, phase, dash(app.Sync), dash(app.Health))
+	if msg != "" {
+		fmt.Println(msg)
+	}
+	if phase != "Succeeded" {
+		return 1
+	}
+	return 0
+}
+
+// waitForOperation polls the app until the sync started at (or after)
+// started has finished. An app with no operation state counts as finished.
+func waitForOperation(ctx context.Context, client argocd.Client, name string, started time.Time) (argocd.Application, error) {
+	seenRunning := false
+	for {
+		app, err := client.GetApplication(ctx, name)
+		if err != nil {
+			return argocd.Application{}, err
+		}
+		op := app.OperationState
+		if op.Finished(started, seenRunning) {
+			return app, nil
+		}
+		if op.Phase == "Running" {
+			seenRunning = true
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return argocd.Application{}, fmt.Errorf("timed out; last phase %q", op.Phase)
+			}
+			return argocd.Application{}, ctx.Err()
+		case <-time.After(syncPollInterval):
+		}
+	}
+}
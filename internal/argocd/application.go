@@ -0,0 +1,236 @@
+package argocd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// QualifiedAppName names an app as "namespace/name", the form the argocd CLI
+// takes with apps-in-any-namespace; without a namespace it is just the name.
+func QualifiedAppName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+// SplitAppName is the inverse of QualifiedAppName. Kubernetes names cannot
+// contain a slash, so a plain name comes back with an empty namespace.
+func SplitAppName(app string) (namespace, name string) {
+	if ns, n, ok := strings.Cut(app, "/"); ok {
+		return ns, n
+	}
+	return "", app
+}
+
+// qualifySharedNames renames apps whose name is used in more than one
+// namespace to their qualified name, so each app in the list has a name of
+// its own.
+// Finished reports whether op is the finished result of an operation started
+// at started, rather than one left over from before it: a terminal phase
+// that began after started (give or take clock skew), or any terminal phase
+// once the caller has seen the operation running. A nil op, an app with no
+// operation, counts as finished.
+func (op *OperationState) Finished(started time.Time, seenRunning bool) bool {
+	if op == nil {
+		return true
+	}
+	switch op.Phase {
+	case "Succeeded", "Failed", "Error":
+	default:
+		return false
+	}
+	if seenRunning {
+		return true
+	}
+	at, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(op.StartedAt))
+	// Allow a little clock skew between us and the server.
+	return err != nil || !at.Before(started.Add(-5*time.Second))
+}
+
+func qualifySharedNames(apps []Application) {
+	namespaces := map[string]map[string]bool{}
+	for _, a := range apps {
+		if namespaces[a.Name] == nil {
+			namespaces[a.Name] = map[string]bool{}
+		}
+		namespaces[a.Name][a.AppObjectNamespace] = true
+	}
+	for i, a := range apps {
+		if len(namespaces[a.Name]) > 1 {
+			apps[i].Name = QualifiedAppName(a.AppObjectNamespace, a.Name)
+		}
+	}
+}
+
+// appNamePattern is a Kubernetes object name (DNS subdomain).
+var appNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`)
+
+// checkNewApplication reports the fields of a new application that the
+// server would reject without looking further: missing or malformed ones.
+func checkNewApplication(app Application) []error {
+	var errs []error
+	switch {
+	case app.Name == "":
+		errs = append(errs, errors.New("name is required"))
+	case len(app.Name) > 253 || !appNamePattern.MatchString(app.Name):
+		errs = append(errs, fmt.Errorf("name %q is not a valid Kubernetes name (lowercase letters, digits, '-' and '.')", app.Name))
+	}
+	if app.Project == "" {
+		errs = append(errs, errors.New("project is required"))
+	}
+	if app.RepoURL == "" {
+		errs = append(errs, errors.New("repository is required"))
+	}
+	if app.Cluster == "" {
+		errs = append(errs, errors.New("destination cluster is required"))
+	}
+	return errs
+}
+
+// apiApplication is the REST (JSON) shape of an Argo CD Application, as
+// returned by both GET /api/v1/applications/{name} and the items of the list
+// endpoint. Decode into it and map with toApplication so a field added here
+// shows up wherever applications are read.
+type apiApplication struct {
+	Metadata struct {
+		Name      string            `json:"name"`
+		Namespace string            `json:"namespace"`
+		Labels    map[string]string `json:"labels"`
+	} `json:"metadata"`
+	Spec struct {
+		Project     string `json:"project"`
+		Destination struct {
+			Namespace string `json:"namespace"`
+			Server    string `json:"server"`
+		} `json:"destination"`
+		Source struct {
+			RepoURL        string `json:"repoURL"`
+			TargetRevision string `json:"targetRevision"`
+			Path           string `json:"path"`
+		} `json:"source"`
+		SyncPolicy *struct {
+			Automated *struct{} `json:"automated"`
+		} `json:"syncPolicy"`
+	} `json:"spec"`
+	Status struct {
+		Health struct {
+			Status string `json:"status"`
+		} `json:"health"`
+		Sync struct {
+			Status string `json:"status"`
+		} `json:"sync"`
+		OperationState *struct {
+			Phase     string `json:"phase"`
+			Message   string `json:"message"`
+			StartedAt string `json:"startedAt"`
+		} `json:"operationState"`
+		ReconciledAt string `json:"reconciledAt"`
+		History      []struct {
+			Revision        string `json:"revision"`
+			DeployedAt      string `json:"deployedAt"`
+			DeployStartedAt string `json:"deployStartedAt"`
+			Source          struct {
+				RepoURL string `json:"repoURL"`
+				Path    string `json:"path"`
+			} `json:"source"`
+			InitiatedBy struct {
+				Username  string `json:"username"`
+				Automated bool   `json:"automated"`
+			} `json:"initiatedBy"`
+		} `json:"history"`
+		Conditions []struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"conditions"`
+		Resources []struct {
+			Group     string `json:"group"`
+			Kind      string `json:"kind"`
+			Version   string `json:"version"`
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+			Status    string `json:"status"`
+			Health    struct {
+				Status string `json:"status"`
+			} `json:"health"`
+			Hook     bool `json:"hook"`
+			SyncWave int  `json:"syncWave"`
+		} `json:"resources"`
+	} `json:"status"`
+}
+
+// toApplication maps the decoded API object to an Application. Resources
+// come from status.resources and history entries carry no commit message;
+// RefreshApplication fills in both from further requests.
+func (a apiApplication) toApplication() Application {
+	var resources []Resource
+	for _, r := range a.Status.Resources {
+		resources = append(resources, Resource{
+			Group:     r.Group,
+			Kind:      r.Kind,
+			Version:   r.Version,
+			Name:      r.Name,
+			Namespace: r.Namespace,
+			Status:    r.Status,
+			Health:    r.Health.Status,
+			Hook:      r.Hook,
+			SyncWave:  r.SyncWave,
+		})
+	}
+
+	var op *OperationState
+	if o := a.Status.OperationState; o != nil {
+		op = &OperationState{Phase: o.Phase, Message: o.Message, StartedAt: o.StartedAt}
+	}
+
+	// Argo CD only appends to status.history once a sync has succeeded, so
+	// every entry is a successful deploy.
+	var history []SyncHistoryEntry
+	for _, h := range a.Status.History {
+		deployedAt := h.DeployedAt
+		if deployedAt == "" {
+			deployedAt = h.DeployStartedAt
+		}
+		src := h.InitiatedBy.Username
+		if src == "" && h.InitiatedBy.Automated {
+			src = "automated sync"
+		}
+		if src == "" && h.Source.RepoURL != "" {
+			src = strings.TrimSuffix(h.Source.RepoURL+"/"+h.Source.Path, "/")
+		}
+		history = append(history, SyncHistoryEntry{Revision: h.Revision, DeployedAt: deployedAt, Status: "Succeeded", Source: src})
+	}
+
+	var conds []AppCondition
+	for _, cnd := range a.Status.Conditions {
+		conds = append(conds, AppCondition{Type: cnd.Type, Message: cnd.Message})
+	}
+
+	syncPolicy := "manual"
+	if a.Spec.SyncPolicy != nil && a.Spec.SyncPolicy.Automated != nil {
+		syncPolicy = "auto"
+	}
+
+	return Application{
+		Name:               a.Metadata.Name,
+		AppObjectNamespace: a.Metadata.Namespace,
+		Labels:             a.Metadata.Labels,
+		Namespace:          a.Spec.Destination.Namespace,
+		Project:            a.Spec.Project,
+		Health:             a.Status.Health.Status,
+		Sync:               a.Status.Sync.Status,
+		RepoURL:            a.Spec.Source.RepoURL,
+		Revision:           a.Spec.Source.TargetRevision,
+		Path:               a.Spec.Source.Path,
+		Cluster:            a.Spec.Destination.Server,
+		SyncPolicy:         syncPolicy,
+		Resources:          resources,
+		OperationState:     op,
+		ReconciledAt:       a.Status.ReconciledAt,
+		History:            history,
+		Conditions:         conds,
+	}
+}
@@ -0,0 +1,102 @@
+package argocd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAPIApplication_toApplication(t *testing.T) {
+	const body = `{
+		"metadata": {"name": "guestbook", "labels": {"team": "web"}},
+		"spec": {
+			"project": "default",
+			"destination": {"namespace": "web", "server": "https://kubernetes.default.svc"},
+			"source": {"repoURL": "https://github.com/example/apps", "targetRevision": "main", "path": "guestbook"},
+			"syncPolicy": {"automated": {"prune": true}}
+		},
+		"status": {
+			"health": {"status": "Degraded"},
+			"sync": {"status": "OutOfSync"},
+			"reconciledAt": "2026-03-01T10:00:00Z",
+			"operationState": {"phase": "Running", "message": "waiting for healthy state", "startedAt": "2026-03-01T09:59:00Z"},
+			"history": [
+				{"revision": "abc", "deployStartedAt": "2026-02-01T12:00:00Z", "initiatedBy": {"automated": true}},
+				{"revision": "def", "deployedAt": "2026-02-02T12:00:00Z", "initiatedBy": {"username": "alice"}},
+				{"revision": "123", "deployedAt": "2026-02-03T12:00:00Z", "source": {"repoURL": "https://github.com/example/apps", "path": "guestbook"}}
+			],
+			"conditions": [{"type": "SyncError", "message": "one or more objects failed to apply"}],
+			"resources": [
+				{"group": "apps", "version": "v1", "kind": "Deployment", "namespace": "web", "name": "web", "status": "OutOfSync", "health": {"status": "Degraded"}, "syncWave": 1},
+				{"kind": "Job", "namespace": "web", "name": "migrate", "status": "Synced", "hook": true}
+			]
+		}
+	}`
+	var a apiApplication
+	if err := json.Unmarshal([]byte(body), &a); err != nil {
+		t.Fatal(err)
+	}
+	want := Application{
+		Name:       "guestbook",
+		Namespace:  "web",
+		Project:    "default",
+		Health:     "Degraded",
+		Sync:       "OutOfSync",
+		SyncPolicy: "auto",
+		Labels:     map[string]string{"team": "web"},
+		RepoURL:    "https://github.com/example/apps",
+		Revision:   "main",
+		Path:       "guestbook",
+		Cluster:    "https://kubernetes.default.svc",
+		Resources: []Resource{
+			{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "web", Name: "web", Status: "OutOfSync", Health: "Degraded", SyncWave: 1},
+			{Kind: "Job", Namespace: "web", Name: "migrate", Status: "Synced", Hook: true},
+		},
+		OperationState: &OperationState{Phase: "Running", Message: "waiting for healthy state", StartedAt: "2026-03-01T09:59:00Z"},
+		ReconciledAt:   "2026-03-01T10:00:00Z",
+		History: []SyncHistoryEntry{
+			{Revision: "abc", DeployedAt: "2026-02-01T12:00:00Z", Status: "Succeeded", Source: "automated sync"},
+			{Revision: "def", DeployedAt: "2026-02-02T12:00:00Z", Status: "Succeeded", Source: "alice"},
+			{Revision: "123", DeployedAt: "2026-02-03T12:00:00Z", Status: "Succeeded", Source: "https://github.com/example/apps/guestbook"},
+		},
+		Conditions: []AppCondition{{Type: "SyncError", Message: "one or more objects failed to apply"}},
+	}
+	if got := a.toApplication(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got  %+v\nwant %+v", got, want)
+	}
+}
+
+func TestAPIApplication_toApplicationSparse(t *testing.T) {
+	// A list item trimmed by ?fields= has no status beyond health and sync.
+	var a apiApplication
+	if err := json.Unmarshal([]byte(`{"metadata": {"name": "a"}, "spec": {"project": "p"}, "status": {"health": {"status": "Healthy"}, "sync": {"status": "Synced"}}}`), &a); err != nil {
+		t.Fatal(err)
+	}
+	want := Application{Name: "a", Project: "p", Health: "Healthy", Sync: "Synced", SyncPolicy: "manual"}
+	if got := a.toApplication(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got  %+v\nwant %+v", got, want)
+	}
+}
+
+func TestOperationState_Finished(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	if !(*OperationState)(nil).Finished(start, false) {
+		t.Fatalf("an app without an operation has nothing to wait for")
+	}
+	if (&OperationState{Phase: "Running"}).Finished(start, false) {
+		t.Fatalf("running operation is not done")
+	}
+	stale := &OperationState{Phase: "Succeeded", StartedAt: start.Add(-time.Hour).Format(time.RFC3339)}
+	if stale.Finished(start, false) {
+		t.Fatalf("an earlier finished operation must not end the wait")
+	}
+	fresh := &OperationState{Phase: "Failed", StartedAt: start.Add(time.Second).Format(time.RFC3339)}
+	if !fresh.Finished(start, false) {
+		t.Fatalf("expected the new failed operation to end the wait")
+	}
+	if !stale.Finished(start, true) {
+		t.Fatalf("after seeing Running, any terminal phase ends the wait")
+	}
+}
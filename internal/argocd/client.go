@@ -14,10 +14,21 @@ type Application struct {
 	Health    string // e.g. Healthy, Degraded
 	Sync      string // e.g. Synced, OutOfSync
 
+	// AppObjectNamespace is the namespace of the Application object itself
+	// (apps-in-any-namespace), not its destination. When two apps share a
+	// name, ListApplications qualifies Name as "namespace/name".
+	AppObjectNamespace string
+
 	OperationState *OperationState
+	// ReconciledAt is the RFC3339 time the controller last compared the app
+	// with git, when known.
+	ReconciledAt string
 
 	SyncPolicy string // e.g. auto/manual
 
+	// Labels are the Application's metadata.labels (team, env, ...).
+	Labels map[string]string
+
 	// Optional fields (may be empty depending on API permissions / list endpoint)
 	RepoURL  string
 	Revision string
@@ -36,8 +47,47 @@ type Application struct {
 type OperationState struct {
 	Phase   string
 	Message string
+	// StartedAt is the RFC3339 start time of the operation, when known.
+	StartedAt string
+}
+
+// Repository is a repository registered with Argo CD.
+type Repository struct {
+	Repo string
+	// Name is the optional display name given when the repo was added.
+	Name string
+	// Type is "git" or "helm".
+	Type string
+	// ConnectionStatus is the result of Argo CD's last connection check:
+	// "Successful", "Failed" or "Unknown"; ConnectionMessage says why a
+	// check failed.
+	ConnectionStatus  string
+	ConnectionMessage string
 }
 
+// Connected reports whether Argo CD last reached the repository.
+func (r Repository) Connected() bool { return r.ConnectionStatus == "Successful" }
+
+// ConnectionFailed reports whether Argo CD's last connection check failed.
+func (r Repository) ConnectionFailed() bool { return r.ConnectionStatus == "Failed" }
+
+// Cluster is a destination cluster registered with Argo CD. Server is what
+// an Application's destination names; Name is the friendly one (e.g.
+// "in-cluster").
+type Cluster struct {
+	Server string
+	Name   string
+	// ConnectionStatus and ConnectionMessage are as for Repository.
+	ConnectionStatus  string
+	ConnectionMessage string
+}
+
+// Connected reports whether Argo CD last reached the cluster.
+func (c Cluster) Connected() bool { return c.ConnectionStatus == "Successful" }
+
+// ConnectionFailed reports whether Argo CD's last connection check failed.
+func (c Cluster) ConnectionFailed() bool { return c.ConnectionStatus == "Failed" }
+
 type Revision struct {
 	ID       int64
 	Revision string
@@ -55,6 +105,25 @@ type Resource struct {
 	Status    string
 	Health    string
 	Hook      bool
+	// Images are the container images running in the resource: a Pod's own,
+	// or those of the Pods it owns (e.g. a Deployment's). CreatedAt is its
+	// creationTimestamp (RFC3339). Both come from the resource tree and are
+	// empty when the server doesn't report them.
+	Images    []string
+	CreatedAt string
+	// SyncWave is the argocd.argoproj.io/sync-wave annotation as the server
+	// read it from the desired manifest; 0, the default wave, when unset.
+	SyncWave int
+}
+
+// SyncOptions tune a sync operation.
+type SyncOptions struct {
+	// DryRun validates and simulates the operation without mutating state.
+	DryRun bool
+	// Prune deletes resources that are no longer defined in git.
+	Prune bool
+	// Revision syncs to this revision instead of the app's target revision.
+	Revision string
 }
 
 // Client is the interface the UI depends on.
@@ -73,22 +142,48 @@ type Client interface {
 	TerminateOperation(ctx context.Context, name string) error
 	DeleteApplication(ctx context.Context, name string, cascade bool) error
 	CreateApplication(ctx context.Context, app Application) error
+	// CreateApplicationRaw creates an application from a full Application
+	// manifest, for fields the wizard doesn't cover.
+	CreateApplicationRaw(ctx context.Context, yaml string) error
+	// ValidateApplication checks a new application without creating it:
+	// required fields, that the name is free and, where the server can
+	// tell, that the source repository and path resolve. Each problem found
+	// is one error of the errors.Join result.
+	ValidateApplication(ctx context.Context, app Application) error
 	ListProjects(ctx context.Context) ([]string, error)
-	ListClusters(ctx context.Context) ([]string, error)
-	ListRepositories(ctx context.Context) ([]string, error)
+	ListClusters(ctx context.Context) ([]Cluster, error)
+	ListRepositories(ctx context.Context) ([]Repository, error)
 	UpdateApplication(ctx context.Context, app Application) error
+	// GetApplicationRaw returns an application's manifest as YAML, with
+	// every spec field the server has, for editing; UpdateApplicationRaw
+	// replaces the application with an edited copy of it as is.
+	GetApplicationRaw(ctx context.Context, name string) (string, error)
+	UpdateApplicationRaw(ctx context.Context, name, manifest string) error
 
 	// SyncApplication triggers an Argo CD sync operation.
-	// When dryRun is true, the server should validate and simulate the operation without mutating state.
-	SyncApplication(ctx context.Context, name string, dryRun bool) error
+	SyncApplication(ctx context.Context, name string, opts SyncOptions) error
 
 	// Phase 2 additions.
 	GetResource(ctx context.Context, appName string, resource ResourceRef) (string, error)
 	GetManifests(ctx context.Context, appName string) ([]string, error)
 	ListEvents(ctx context.Context, appName string) ([]Event, error)
+	// ListApplicationEvents returns the events of every application, tagged with Event.App.
+	ListApplicationEvents(ctx context.Context) ([]Event, error)
 	PodLogs(ctx context.Context, appName, podName, container string, follow bool) (io.ReadCloser, error)
 	ServerSideDiff(ctx context.Context, appName string) ([]DiffResult, error)
 	RevisionMetadata(ctx context.Context, appName, revision string) (RevisionMeta, error)
 	ChartDetails(ctx context.Context, appName, revision string) (ChartMeta, error)
+	// RevisionsDiff compares two source revisions of an application: their
+	// commit metadata and, when the server can render them, their manifests.
+	RevisionsDiff(ctx context.Context, appName, from, to string) (RevisionComparison, error)
 	GetSyncWindows(ctx context.Context, appName string) ([]SyncWindow, error)
+
+	// DeleteResource deletes a single managed resource from the cluster.
+	// When force is true, the server skips graceful deletion.
+	DeleteResource(ctx context.Context, appName string, ref ResourceRef, force bool) error
+
+	// ListResourceActions returns the resource actions (restart, pause, resume, ...)
+	// Argo CD offers for a resource; RunResourceAction executes one of them.
+	ListResourceActions(ctx context.Context, appName string, ref ResourceRef) ([]string, error)
+	RunResourceAction(ctx context.Context, appName string, ref ResourceRef, action string) error
 }
@@ -0,0 +1,93 @@
+package argocd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// ErrNotAPI marks a successful response that isn't from the Argo CD API: an
+// HTML page, typically the web UI or a proxy in front of it.
+var ErrNotAPI = errors.New("response is HTML, not Argo CD API JSON")
+
+// APIError is a non-2xx response from the Argo CD API. Callers that care
+// about the kind of failure (e.g. 403 vs 404) can errors.As to it.
+type APIError struct {
+	Method string
+	// Path is the request path, without the query string.
+	Path   string
+	Status int
+	// Body is the raw response body.
+	Body string
+	// ContentType is the response's Content-Type header.
+	ContentType string
+}
+
+func (e *APIError) Error() string {
+	msg := strings.TrimSpace(e.Body)
+	if len(msg) > 500 {
+		msg = msg[:500] + "…"
+	}
+	return fmt.Sprintf("argocd api %s %s failed: %d %s: %s", e.Method, e.Path, e.Status, http.StatusText(e.Status), msg)
+}
+
+// Reason is the server's short explanation: the "message" field of Argo
+// CD's JSON error body, or else the first line of the body.
+func (e *APIError) Reason() string {
+	var body struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	if json.Unmarshal([]byte(e.Body), &body) == nil {
+		if m := strings.TrimSpace(body.Message); m != "" {
+			return m
+		}
+		if m := strings.TrimSpace(body.Error); m != "" {
+			return m
+		}
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(e.Body), "\n")
+	if len(line) > 200 {
+		line = line[:200] + "…"
+	}
+	return line
+}
+
+// Summary is a one-line "403 Forbidden: permission denied" form for the UI.
+func (e *APIError) Summary() string {
+	s := fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status))
+	if r := e.Reason(); r != "" {
+		s += ": " + r
+	}
+	return s
+}
+
+// LooksLikeWebUI reports whether err suggests the server is not the API: an
+// HTML response, or a 404 for the application list, which the API always
+// serves. It is for hinting at a wrong port or path, not for control flow.
+func LooksLikeWebUI(err error) bool {
+	if errors.Is(err, ErrNotAPI) {
+		return true
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if isHTML(apiErr.ContentType, []byte(apiErr.Body)) {
+		return true
+	}
+	return apiErr.Status == http.StatusNotFound && apiErr.Path == "/api/v1/applications"
+}
+
+// isHTML reports whether a response is an HTML page, by its content type or,
+// failing that, its first bytes.
+func isHTML(contentType string, body []byte) bool {
+	if mt, _, err := mime.ParseMediaType(contentType); err == nil && mt == "text/html" {
+		return true
+	}
+	head := strings.ToLower(strings.TrimSpace(string(body[:min(len(body), 64)])))
+	return strings.HasPrefix(head, "<!doctype html") || strings.HasPrefix(head, "<html")
+}
@@ -0,0 +1,26 @@
+package argocd
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// ApplicationCSVHeader is the stable column set WriteApplicationsCSV
+// writes. Add columns at the end; don't rename or reorder them.
+var ApplicationCSVHeader = []string{"name", "project", "namespace", "cluster", "health", "sync", "repo", "path", "revision"}
+
+// WriteApplicationsCSV writes apps as CSV with ApplicationCSVHeader as the
+// first row. Fields are quoted as needed.
+func WriteApplicationsCSV(w io.Writer, apps []Application) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(ApplicationCSVHeader); err != nil {
+		return err
+	}
+	for _, a := range apps {
+		if err := cw.Write([]string{a.Name, a.Project, a.Namespace, a.Cluster, a.Health, a.Sync, a.RepoURL, a.Path, a.Revision}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,23 @@
+package argocd
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteApplicationsCSV(t *testing.T) {
+	var b bytes.Buffer
+	apps := []Application{
+		{Name: "guestbook", Project: "default", Namespace: "web", Cluster: "in-cluster", Health: "Healthy", Sync: "Synced", RepoURL: "https://git.example/repo", Path: "apps/guestbook", Revision: "main"},
+		{Name: "odd", Path: `charts/"quoted",path`},
+	}
+	if err := WriteApplicationsCSV(&b, apps); err != nil {
+		t.Fatal(err)
+	}
+	want := "name,project,namespace,cluster,health,sync,repo,path,revision\n" +
+		"guestbook,default,web,in-cluster,Healthy,Synced,https://git.example/repo,apps/guestbook,main\n" +
+		"odd,,,,,,,\"charts/\"\"quoted\"\",path\",\n"
+	if b.String() != want {
+		t.Fatalf("csv =\n%s\nwant\n%s", b.String(), want)
+	}
+}
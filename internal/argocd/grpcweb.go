@@ -0,0 +1,406 @@
+package argocd
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrNotImplemented is returned by GRPCWebClient for calls it doesn't
+// support yet.
+var ErrNotImplemented = errors.New("not implemented over gRPC-web")
+
+// GRPCWebClient talks to Argo CD's gRPC-web gateway, for deployments that
+// expose gRPC-web but not the REST paths HTTPClient uses. It covers
+// listing, getting and syncing applications; every other call fails with
+// ErrNotImplemented.
+//
+// Connection settings (server, auth, TLS, proxy) come from the wrapped
+// HTTPClient, and calls go through its request log, trace and 429 retries.
+type GRPCWebClient struct {
+	conn *HTTPClient
+}
+
+func NewGRPCWebClient(conn *HTTPClient) *GRPCWebClient {
+	return &GRPCWebClient{conn: conn}
+}
+
+// RecentRequests returns the recorded calls, newest first (nil unless the
+// wrapped client's Requests is set).
+func (c *GRPCWebClient) RecentRequests() []RequestRecord {
+	return c.conn.RecentRequests()
+}
+
+func (c *GRPCWebClient) login(ctx context.Context) error {
+	h := c.conn
+	if h.AuthToken != "" || h.loginToken != "" {
+		return nil
+	}
+	if h.Username == "" || h.Password == "" {
+		if h.Anonymous {
+			return nil
+		}
+		return fmt.Errorf("missing Argo CD auth: set ARGOCD_AUTH_TOKEN, provide username/password, or use --anonymous")
+	}
+	var req protoEncoder
+	req.string(1, h.Username)
+	req.string(2, h.Password)
+	resp, err := c.call(ctx, "session.SessionService/Create", req)
+	if err != nil {
+		return err
+	}
+	tok := resp.string(1)
+	if tok == "" {
+		return fmt.Errorf("argocd login returned empty token")
+	}
+	h.loginToken = tok
+	return nil
+}
+
+// invoke logs in if needed and makes a unary call.
+func (c *GRPCWebClient) invoke(ctx context.Context, method string, req protoEncoder) (protoMessage, error) {
+	if err := c.login(ctx); err != nil {
+		return nil, err
+	}
+	return c.call(ctx, method, req)
+}
+
+// call makes one unary gRPC-web call: a single length-prefixed message in,
+// a data frame and a trailer frame out. gRPC failures are returned as an
+// *APIError with the closest HTTP status, so the UI shows them like REST
+// errors.
+func (c *GRPCWebClient) call(ctx context.Context, method string, req protoEncoder) (protoMessage, error) {
+	h := c.conn
+	path := "/" + method
+	frame := make([]byte, 5, 5+len(req))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(req)))
+	frame = append(frame, req...)
+
+	res, body, err := h.send(ctx, path, frame, false, func(r io.Reader) (*http.Request, error) {
+		hreq, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(h.Server, "/")+path, r)
+		if err != nil {
+			return nil, err
+		}
+		hreq.Header.Set("Content-Type", "application/grpc-web+proto")
+		hreq.Header.Set("Accept", "application/grpc-web+proto")
+		hreq.Header.Set("X-Grpc-Web", "1")
+		return hreq, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, &APIError{Method: http.MethodPost, Path: path, Status: res.StatusCode, Body: string(body)}
+	}
+
+	data, trailer, err := splitGRPCWebFrames(body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+	// Trailers-only responses carry the status in the HTTP headers.
+	status, msg := res.Header.Get("Grpc-Status"), res.Header.Get("Grpc-Message")
+	if trailer != nil {
+		status, msg = trailer.Get("Grpc-Status"), trailer.Get("Grpc-Message")
+	}
+	if status != "" && status != "0" {
+		code, _ := strconv.Atoi(status)
+		if m, err := url.PathUnescape(msg); err == nil {
+			msg = m
+		}
+		b, _ := json.Marshal(map[string]any{"code": code, "message": msg})
+		return nil, &APIError{Method: http.MethodPost, Path: path, Status: grpcHTTPStatus(code), Body: string(b)}
+	}
+	return decodeProto(data)
+}
+
+// splitGRPCWebFrames returns the message of the first data frame and the
+// parsed trailer frame, if any.
+func splitGRPCWebFrames(b []byte) (data []byte, trailer textproto.MIMEHeader, err error) {
+	for len(b) > 0 {
+		if len(b) < 5 {
+			return nil, nil, errors.New("grpc-web: truncated frame")
+		}
+		flag, n := b[0], binary.BigEndian.Uint32(b[1:5])
+		if uint64(len(b)-5) < uint64(n) {
+			return nil, nil, errors.New("grpc-web: truncated frame")
+		}
+		payload := b[5 : 5+n]
+		b = b[5+n:]
+		if flag&0x80 != 0 {
+			trailer = textproto.MIMEHeader{}
+			for _, line := range strings.Split(string(payload), "\r\n") {
+				if k, v, ok := strings.Cut(line, ":"); ok {
+					trailer.Add(textproto.CanonicalMIMEHeaderKey(strings.TrimSpace(k)), strings.TrimSpace(v))
+				}
+			}
+			continue
+		}
+		if data == nil {
+			data = payload
+		}
+	}
+	return data, trailer, nil
+}
+
+// grpcHTTPStatus maps a gRPC status code to the HTTP status with the same
+// meaning.
+func grpcHTTPStatus(code int) int {
+	switch code {
+	case 3: // InvalidArgument
+		return http.StatusBadRequest
+	case 5: // NotFound
+		return http.StatusNotFound
+	case 6: // AlreadyExists
+		return http.StatusConflict
+	case 7: // PermissionDenied
+		return http.StatusForbidden
+	case 9: // FailedPrecondition
+		return http.StatusPreconditionFailed
+	case 12: // Unimplemented
+		return http.StatusNotImplemented
+	case 14: // Unavailable
+		return http.StatusServiceUnavailable
+	case 16: // Unauthenticated
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
+
+// decodeApplication maps a v1alpha1.Application message.
+func decodeApplication(m protoMessage) Application {
+	meta, spec, status := m.message(1), m.message(2), m.message(3)
+	src, dst := spec.message(1), spec.message(2)
+
+	var labels map[string]string
+	for _, e := range meta.repeated(11) {
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[e.string(1)] = e.string(2)
+	}
+	syncPolicy := "manual"
+	if spec.message(4).has(1) {
+		syncPolicy = "auto"
+	}
+
+	var resources []Resource
+	for _, r := range status.repeated(1) {
+		resources = append(resources, Resource{
+			Group:     r.string(1),
+			Version:   r.string(2),
+			Kind:      r.string(3),
+			Namespace: r.string(4),
+			Name:      r.string(5),
+			Status:    r.string(6),
+			Health:    r.message(7).string(1),
+			Hook:      r.bool(8),
+			SyncWave:  int(int64(r.uint(10))),
+		})
+	}
+	var history []SyncHistoryEntry
+	for _, h := range status.repeated(4) {
+		deployedAt := h.time(4)
+		if deployedAt == "" {
+			deployedAt = h.time(7)
+		}
+		by := h.message(10)
+		src := by.string(1)
+		if src == "" && by.bool(2) {
+			src = "automated sync"
+		}
+		history = append(history, SyncHistoryEntry{Revision: h.string(2), DeployedAt: deployedAt, Status: "Succeeded", Source: src})
+	}
+	var conds []AppCondition
+	for _, cnd := range status.repeated(5) {
+		conds = append(conds, AppCondition{Type: cnd.string(1), Message: cnd.string(2)})
+	}
+	var op *OperationState
+	if status.has(7) {
+		o := status.message(7)
+		op = &OperationState{Phase: o.string(2), Message: o.string(3), StartedAt: o.time(6)}
+	}
+
+	return Application{
+		Name:               meta.string(1),
+		AppObjectNamespace: meta.string(3),
+		Labels:             labels,
+		Namespace:          dst.string(2),
+		Project:            spec.string(3),
+		Health:             status.message(3).string(1),
+		Sync:               status.message(2).string(1),
+		RepoURL:            src.string(1),
+		Path:               src.string(2),
+		Revision:           src.string(4),
+		Cluster:            dst.string(1),
+		SyncPolicy:         syncPolicy,
+		Resources:          resources,
+		OperationState:     op,
+		ReconciledAt:       status.time(6),
+		History:            history,
+		Conditions:         conds,
+	}
+}
+
+func (c *GRPCWebClient) ListApplications(ctx context.Context) ([]Application, error) {
+	resp, err := c.invoke(ctx, "application.ApplicationService/List", nil)
+	if err != nil {
+		return nil, err
+	}
+	items := resp.repeated(2)
+	apps := make([]Application, 0, len(items))
+	for _, it := range items {
+		apps = append(apps, decodeApplication(it))
+	}
+	qualifySharedNames(apps)
+	c.conn.rememberAppNamespaces(apps)
+	return apps, nil
+}
+
+func (c *GRPCWebClient) GetApplication(ctx context.Context, name string) (Application, error) {
+	return c.RefreshApplication(ctx, name, false)
+}
+
+func (c *GRPCWebClient) RefreshApplication(ctx context.Context, name string, hard bool) (Application, error) {
+	ns, plain := c.conn.appName(name)
+	var q protoEncoder
+	q.string(1, plain)
+	if hard {
+		q.string(2, "hard")
+	}
+	q.string(7, ns)
+	resp, err := c.invoke(ctx, "application.ApplicationService/Get", q)
+	if err != nil {
+		return Application{}, err
+	}
+	app := decodeApplication(resp)
+	app.Name = name
+	return app, nil
+}
+
+func (c *GRPCWebClient) SyncApplication(ctx context.Context, name string, opts SyncOptions) error {
+	ns, plain := c.conn.appName(name)
+	var req protoEncoder
+	req.string(1, plain)
+	req.string(2, opts.Revision)
+	req.bool(3, opts.DryRun)
+	req.bool(4, opts.Prune)
+	req.string(12, ns)
+	_, err := c.invoke(ctx, "application.ApplicationService/Sync", req)
+	return err
+}
+
+func notImplemented(op string) error {
+	return fmt.Errorf("%s: %w", op, ErrNotImplemented)
+}
+
+func (c *GRPCWebClient) ListRevisions(ctx context.Context, name string) ([]Revision, error) {
+	return nil, notImplemented("list revisions")
+}
+
+func (c *GRPCWebClient) RollbackApplication(ctx context.Context, name string, revisionID int64) error {
+	return notImplemented("rollback")
+}
+
+func (c *GRPCWebClient) TerminateOperation(ctx context.Context, name string) error {
+	return notImplemented("terminate operation")
+}
+
+func (c *GRPCWebClient) DeleteApplication(ctx context.Context, name string, cascade bool) error {
+	return notImplemented("delete application")
+}
+
+func (c *GRPCWebClient) CreateApplication(ctx context.Context, app Application) error {
+	return notImplemented("create application")
+}
+
+func (c *GRPCWebClient) CreateApplicationRaw(ctx context.Context, yaml string) error {
+	return notImplemented("create application")
+}
+
+func (c *GRPCWebClient) ValidateApplication(ctx context.Context, app Application) error {
+	return notImplemented("validate application")
+}
+
+func (c *GRPCWebClient) ListProjects(ctx context.Context) ([]string, error) {
+	return nil, notImplemented("list projects")
+}
+
+func (c *GRPCWebClient) ListClusters(ctx context.Context) ([]Cluster, error) {
+	return nil, notImplemented("list clusters")
+}
+
+func (c *GRPCWebClient) ListRepositories(ctx context.Context) ([]Repository, error) {
+	return nil, notImplemented("list repositories")
+}
+
+func (c *GRPCWebClient) UpdateApplication(ctx context.Context, app Application) error {
+	return notImplemented("update application")
+}
+
+func (c *GRPCWebClient) GetApplicationRaw(ctx context.Context, name string) (string, error) {
+	return "", notImplemented("get application manifest")
+}
+
+func (c *GRPCWebClient) UpdateApplicationRaw(ctx context.Context, name, manifest string) error {
+	return notImplemented("update application")
+}
+
+func (c *GRPCWebClient) GetResource(ctx context.Context, appName string, resource ResourceRef) (string, error) {
+	return "", notImplemented("get resource")
+}
+
+func (c *GRPCWebClient) GetManifests(ctx context.Context, appName string) ([]string, error) {
+	return nil, notImplemented("get manifests")
+}
+
+func (c *GRPCWebClient) ListEvents(ctx context.Context, appName string) ([]Event, error) {
+	return nil, notImplemented("list events")
+}
+
+func (c *GRPCWebClient) ListApplicationEvents(ctx context.Context) ([]Event, error) {
+	return nil, notImplemented("list events")
+}
+
+func (c *GRPCWebClient) PodLogs(ctx context.Context, appName, podName, container string, follow bool) (io.ReadCloser, error) {
+	return nil, notImplemented("pod logs")
+}
+
+func (c *GRPCWebClient) ServerSideDiff(ctx context.Context, appName string) ([]DiffResult, error) {
+	return nil, notImplemented("diff")
+}
+
+func (c *GRPCWebClient) RevisionMetadata(ctx context.Context, appName, revision string) (RevisionMeta, error) {
+	return RevisionMeta{}, notImplemented("revision metadata")
+}
+
+func (c *GRPCWebClient) ChartDetails(ctx context.Context, appName, revision string) (ChartMeta, error) {
+	return ChartMeta{}, notImplemented("chart details")
+}
+
+func (c *GRPCWebClient) RevisionsDiff(ctx context.Context, appName, from, to string) (RevisionComparison, error) {
+	return RevisionComparison{}, notImplemented("revision diff")
+}
+
+func (c *GRPCWebClient) GetSyncWindows(ctx context.Context, appName string) ([]SyncWindow, error) {
+	return nil, notImplemented("sync windows")
+}
+
+func (c *GRPCWebClient) DeleteResource(ctx context.Context, appName string, ref ResourceRef, force bool) error {
+	return notImplemented("delete resource")
+}
+
+func (c *GRPCWebClient) ListResourceActions(ctx context.Context, appName string, ref ResourceRef) ([]string, error) {
+	return nil, notImplemented("resource actions")
+}
+
+func (c *GRPCWebClient) RunResourceAction(ctx context.Context, appName string, ref ResourceRef, action string) error {
+	return notImplemented("resource actions")
+}
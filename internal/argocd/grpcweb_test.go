@@ -0,0 +1,204 @@
+package argocd
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// grpcWebFrame wraps a message (flag 0) or trailer block (flag 0x80).
+func grpcWebFrame(flag byte, payload []byte) []byte {
+	b := make([]byte, 5, 5+len(payload))
+	b[0] = flag
+	binary.BigEndian.PutUint32(b[1:], uint32(len(payload)))
+	return append(b, payload...)
+}
+
+func testProtoApplication(name string) protoEncoder {
+	var meta, labels, spec, src, dst, policy, status, health, sync, ts, hist protoEncoder
+	meta.string(1, name)
+	labels.string(1, "team")
+	labels.string(2, "payments")
+	meta.message(11, labels)
+	src.string(1, "https://git.example/repo")
+	src.string(2, "apps/"+name)
+	src.string(4, "main")
+	dst.string(1, "https://kubernetes.default.svc")
+	dst.string(2, "prod")
+	policy.message(1, nil)
+	spec.message(1, src)
+	spec.message(2, dst)
+	spec.string(3, "default")
+	spec.message(4, policy)
+	health.string(1, "Healthy")
+	sync.string(1, "Synced")
+	ts.tag(1, wireVarint)
+	ts = binary.AppendUvarint(ts, 1700000000)
+	hist.string(2, "abc123")
+	hist.message(4, ts)
+	status.message(2, sync)
+	status.message(3, health)
+	status.message(4, hist)
+
+	var app protoEncoder
+	app.message(1, meta)
+	app.message(2, spec)
+	app.message(3, status)
+	return app
+}
+
+func TestGRPCWebClient(t *testing.T) {
+	var synced protoMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Content-Type"); got != "application/grpc-web+proto" {
+			t.Errorf("%s: Content-Type = %q", r.URL.Path, got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer tok" {
+			t.Errorf("%s: Authorization = %q", r.URL.Path, got)
+		}
+		body, _ := io.ReadAll(r.Body)
+		req, err := decodeProto(body[5:])
+		if err != nil {
+			t.Fatalf("%s: decode request: %v", r.URL.Path, err)
+		}
+		w.Header().Set("Content-Type", "application/grpc-web+proto")
+		var resp protoEncoder
+		switch r.URL.Path {
+		case "/application.ApplicationService/List":
+			resp.message(2, testProtoApplication("guestbook"))
+			resp.message(2, testProtoApplication("billing"))
+		case "/application.ApplicationService/Get":
+			if req.string(1) == "missing" {
+				w.Header().Set("Grpc-Status", "5")
+				w.Header().Set("Grpc-Message", "application%20not%20found")
+				return
+			}
+			resp = testProtoApplication(req.string(1))
+		case "/application.ApplicationService/Sync":
+			synced = req
+			resp = testProtoApplication(req.string(1))
+		default:
+			t.Errorf("unexpected call %s", r.URL.Path)
+		}
+		w.Write(grpcWebFrame(0, resp))
+		w.Write(grpcWebFrame(0x80, []byte("grpc-status: 0\r\ngrpc-message: \r\n")))
+	}))
+	defer srv.Close()
+
+	h := NewHTTPClient(srv.URL)
+	h.AuthToken = "tok"
+	c := NewGRPCWebClient(h)
+	ctx := context.Background()
+
+	apps, err := c.ListApplications(ctx)
+	if err != nil {
+		t.Fatalf("ListApplications: %v", err)
+	}
+	if len(apps) != 2 || apps[0].Name != "guestbook" || apps[1].Name != "billing" {
+		t.Fatalf("apps = %+v", apps)
+	}
+
+	app, err := c.GetApplication(ctx, "guestbook")
+	if err != nil {
+		t.Fatalf("GetApplication: %v", err)
+	}
+	if app.Project != "default" || app.Namespace != "prod" || app.Path != "apps/guestbook" || app.Revision != "main" {
+		t.Errorf("spec not decoded: %+v", app)
+	}
+	if app.Health != "Healthy" || app.Sync != "Synced" || app.SyncPolicy != "auto" || app.Labels["team"] != "payments" {
+		t.Errorf("status not decoded: %+v", app)
+	}
+	if len(app.History) != 1 || app.History[0].Revision != "abc123" || app.History[0].DeployedAt != "2023-11-14T22:13:20Z" {
+		t.Errorf("history = %+v", app.History)
+	}
+
+	if err := c.SyncApplication(ctx, "guestbook", SyncOptions{Revision: "v2", Prune: true}); err != nil {
+		t.Fatalf("SyncApplication: %v", err)
+	}
+	if synced.string(1) != "guestbook" || synced.string(2) != "v2" || !synced.bool(4) || synced.bool(3) {
+		t.Errorf("sync request = %+v", synced)
+	}
+
+	_, err = c.GetApplication(ctx, "missing")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotFound || apiErr.Summary() != "404 Not Found: application not found" {
+		t.Errorf("missing app error = %v", err)
+	}
+
+	if _, err := c.ListProjects(ctx); !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("ListProjects error = %v, want ErrNotImplemented", err)
+	}
+}
+
+func TestGRPCWebClient_instrumented(t *testing.T) {
+	listCalls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/grpc-web+proto")
+		var resp protoEncoder
+		switch r.URL.Path {
+		case "/session.SessionService/Create":
+			resp.string(1, "s3cr3t-session")
+		case "/application.ApplicationService/List":
+			if listCalls++; listCalls == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			resp.message(2, testProtoApplication("guestbook"))
+		}
+		w.Write(grpcWebFrame(0, resp))
+		w.Write(grpcWebFrame(0x80, []byte("grpc-status: 0\r\n")))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	h := NewHTTPClient(srv.URL)
+	h.Username = "admin"
+	h.Password = "hunter2"
+	h.RateLimitRetries = 1
+	h.Requests = NewRequestLog(10)
+	h.Trace = NewTracer(&buf)
+	c := NewGRPCWebClient(h)
+
+	apps, err := c.ListApplications(context.Background())
+	if err != nil {
+		t.Fatalf("expected the 429 to be retried, got %v", err)
+	}
+	if len(apps) != 1 || listCalls != 2 {
+		t.Fatalf("apps = %+v after %d list calls", apps, listCalls)
+	}
+
+	var statuses []int
+	for _, r := range c.RecentRequests() {
+		statuses = append(statuses, r.Status)
+	}
+	if want := []int{http.StatusOK, http.StatusTooManyRequests, http.StatusOK}; !reflect.DeepEqual(statuses, want) {
+		t.Fatalf("request log statuses = %v, want %v", statuses, want)
+	}
+
+	out := buf.String()
+	for _, secret := range []string{"hunter2", "s3cr3t-session"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("trace leaks %q:\n%s", secret, out)
+		}
+	}
+	var urls []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var e TraceEntry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("bad trace line %q: %v", line, err)
+		}
+		urls = append(urls, strings.TrimPrefix(e.URL, srv.URL))
+	}
+	if want := []string{"/session.SessionService/Create", "/application.ApplicationService/List", "/application.ApplicationService/List"}; !reflect.DeepEqual(urls, want) {
+		t.Fatalf("traced %v, want %v", urls, want)
+	}
+}
@@ -4,15 +4,24 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"sigs.k8s.io/yaml"
 )
 
 // HTTPClient is a minimal Argo CD API client over HTTP.
@@ -33,52 +42,257 @@ type HTTPClient struct {
 	HTTP      *http.Client
 	UserAgent string
 	Insecure  bool // placeholder; only relevant when using HTTPS + custom TLS config
+	// Anonymous skips login when no token is set, for servers with
+	// anonymous access enabled. Writes then fail with the server's 403.
+	Anonymous bool
 	Logger    *slog.Logger
+	// ClientCert and ClientKey are PEM files presented to servers (or
+	// gateways) that require mutual TLS. Both or neither must be set.
+	ClientCert string
+	ClientKey  string
+	// CACert is a PEM bundle of extra CAs to trust, e.g. a corporate CA;
+	// prefer it over Insecure, which turns verification off.
+	CACert string
+	// Proxy routes every request, log streams included, through this
+	// proxy URL instead of the HTTP(S)_PROXY environment.
+	Proxy string
+	// ListResources makes ListApplications include each app's resource
+	// statuses, at the cost of a much larger response.
+	ListResources bool
+	// Requests, when set, records every API call for the debug overlay.
+	Requests *RequestLog
+	// Trace, when set, writes every API call with its bodies (--trace).
+	Trace *Tracer
+	// MaxIdleConnsPerHost caps the idle connections kept for reuse;
+	// IdleConnTimeout is how long one may sit idle, and KeepAlive the TCP
+	// keep-alive period. Zero keeps the net/http default.
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	KeepAlive           time.Duration
+	// TokenFile, when set, is re-read whenever it changes so rotated tokens
+	// are picked up mid-session; it wins over AuthToken while readable.
+	TokenFile string
+	// RateLimitRetries is how many times a 429 response is retried, after
+	// the server's Retry-After or an exponential backoff; 0 fails at once.
+	RateLimitRetries int
 
 	loginToken string
+
+	// fileToken caches TokenFile's content as of fileTokenMod/fileTokenSize.
+	fileTokenMu   sync.Mutex
+	fileToken     string
+	fileTokenMod  time.Time
+	fileTokenSize int64
+
+	// appNamespaces maps app names to the namespace of their Application
+	// object, as of the last ListApplications, for apps-in-any-namespace.
+	appNamespacesMu sync.Mutex
+	appNamespaces   map[string]string
+
+	// buildOnce fills in HTTP from the settings above on first use; the
+	// settings are fixed from then on.
+	buildOnce sync.Once
+	buildErr  error
 }
 
 func NewHTTPClient(server string) *HTTPClient {
+	if s, err := NormalizeServerURL(server); err == nil {
+		server = s
+	}
 	return &HTTPClient{
 		Server:    strings.TrimRight(server, "/"),
 		Timeout:   10 * time.Second,
 		UserAgent: "lazyargo/0.0.1",
 		Logger:    slog.Default(),
+		// net/http keeps only 2 idle connections per host, too few for
+		// prefetch and refresh-all, which then redial for every burst.
+		MaxIdleConnsPerHost: 16,
+		RateLimitRetries:    3,
 	}
 }
 
-func (c *HTTPClient) client() *http.Client {
-	if c.HTTP != nil {
-		return c.HTTP
-	}
+// RecentRequests returns the recorded API calls, newest first (nil unless
+// Requests is set).
+func (c *HTTPClient) RecentRequests() []RequestRecord {
+	return c.Requests.Recent()
+}
 
+// client returns HTTP, building it on the first call unless the caller set
+// one. HTTP is only read after the Once so concurrent first calls can't
+// race on it.
+func (c *HTTPClient) client() (*http.Client, error) {
+	c.buildOnce.Do(func() {
+		if c.HTTP == nil {
+			c.HTTP, c.buildErr = c.newHTTPClient()
+		}
+	})
+	return c.HTTP, c.buildErr
+}
+
+// newHTTPClient builds the client for client(); every call shares it, and
+// with it the transport's connection pool.
+func (c *HTTPClient) newHTTPClient() (*http.Client, error) {
+	tlsCfg, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
 	transport := http.DefaultTransport.(*http.Transport).Clone()
-	if c.Insecure {
-		if transport.TLSClientConfig == nil {
-			transport.TLSClientConfig = &tls.Config{}
+	transport.TLSClientConfig = tlsCfg
+	if c.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = c.MaxIdleConnsPerHost
+	}
+	if c.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = c.IdleConnTimeout
+	}
+	if c.KeepAlive != 0 {
+		transport.DialContext = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: c.KeepAlive}).DialContext
+	}
+	if c.Proxy != "" {
+		u, err := ParseProxyURL(c.Proxy)
+		if err != nil {
+			return nil, err
 		}
-		transport.TLSClientConfig.InsecureSkipVerify = true //nolint:gosec // explicit user flag
+		transport.Proxy = http.ProxyURL(u)
 	}
+	return &http.Client{Timeout: c.Timeout, Transport: transport}, nil
+}
 
-	return &http.Client{Timeout: c.Timeout, Transport: transport}
+// ParseProxyURL validates a proxy URL such as http://proxy.corp:3128.
+func ParseProxyURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("proxy: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("proxy %q: scheme must be http, https or socks5", s)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy %q: missing host", s)
+	}
+	return u, nil
+}
+
+// NormalizeServerURL turns a configured server into the base URL requests
+// are built on: https:// is assumed when no scheme is given (so
+// "localhost:8080" works) and trailing slashes are dropped. A path prefix
+// (Argo CD served under /argocd) is kept.
+func NormalizeServerURL(s string) (string, error) {
+	raw := strings.TrimSpace(s)
+	if raw == "" {
+		return "", fmt.Errorf("server: empty URL")
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("server %q: %w", s, err)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	switch u.Scheme {
+	case "http", "https":
+	default:
+		return "", fmt.Errorf("server %q: scheme must be http or https", s)
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("server %q: missing host", s)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("server %q: unexpected query or fragment", s)
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+// tlsConfig builds the transport's TLS settings: verification (or not, with
+// Insecure) against the system roots plus CACert, and the client
+// certificate for mutual TLS.
+func (c *HTTPClient) tlsConfig() (*tls.Config, error) {
+	cfg := &tls.Config{
+		InsecureSkipVerify: c.Insecure, //nolint:gosec // explicit user flag
+	}
+	if c.CACert != "" {
+		b, err := os.ReadFile(c.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("CA bundle %s: no PEM certificates found", c.CACert)
+		}
+		cfg.RootCAs = pool
+	}
+	if c.ClientCert == "" && c.ClientKey == "" {
+		return cfg, nil
+	}
+	if c.ClientCert == "" || c.ClientKey == "" {
+		return nil, fmt.Errorf("client certificate: both a certificate and a key file are required")
+	}
+	cert, err := tls.LoadX509KeyPair(c.ClientCert, c.ClientKey)
+	if err != nil {
+		return nil, fmt.Errorf("load client certificate: %w", err)
+	}
+	cfg.Certificates = []tls.Certificate{cert}
+	return cfg, nil
 }
 
 func (c *HTTPClient) token() string {
+	if c.TokenFile != "" {
+		if tok := c.tokenFromFile(); tok != "" {
+			return tok
+		}
+	}
 	if c.AuthToken != "" {
 		return c.AuthToken
 	}
 	return c.loginToken
 }
 
+// tokenFromFile returns TokenFile's trimmed content, re-reading it only when
+// its modification time or size changed. On a failed read (e.g. mid-rotate)
+// it keeps the last good token.
+func (c *HTTPClient) tokenFromFile() string {
+	c.fileTokenMu.Lock()
+	defer c.fileTokenMu.Unlock()
+	fi, err := os.Stat(c.TokenFile)
+	if err != nil {
+		return c.fileToken
+	}
+	if c.fileToken != "" && fi.ModTime().Equal(c.fileTokenMod) && fi.Size() == c.fileTokenSize {
+		return c.fileToken
+	}
+	b, err := os.ReadFile(c.TokenFile)
+	if err != nil {
+		return c.fileToken
+	}
+	if tok := strings.TrimSpace(string(b)); tok != "" {
+		if c.fileToken != "" && tok != c.fileToken && c.Logger != nil {
+			c.Logger.Info("auth token file changed; using the new token", "path", c.TokenFile)
+		}
+		c.fileToken = tok
+		c.fileTokenMod, c.fileTokenSize = fi.ModTime(), fi.Size()
+	}
+	return c.fileToken
+}
+
 func (c *HTTPClient) ensureLogin(ctx context.Context) error {
-	if c.AuthToken != "" {
+	if c.AuthToken != "" || c.TokenFile != "" {
 		return nil
 	}
 	if c.loginToken != "" {
 		return nil
 	}
 	if c.Username == "" || c.Password == "" {
-		return fmt.Errorf("missing Argo CD auth: set ARGOCD_AUTH_TOKEN or provide username/password")
+		if c.Anonymous {
+			return nil
+		}
+		return fmt.Errorf("missing Argo CD auth: set ARGOCD_AUTH_TOKEN, provide username/password, or use --anonymous")
 	}
 
 	payload := map[string]string{"username": c.Username, "password": c.Password}
@@ -95,60 +309,83 @@ func (c *HTTPClient) ensureLogin(ctx context.Context) error {
 	return nil
 }
 
+// listApplicationFields is the field selection for ListApplications.
+const listApplicationFields = "items.metadata.name,items.metadata.namespace,items.metadata.labels,items.spec,items.status.sync.status,items.status.health.status"
+
 func (c *HTTPClient) ListApplications(ctx context.Context) ([]Application, error) {
 	if err := c.ensureLogin(ctx); err != nil {
 		return nil, err
 	}
 	var resp struct {
-		Items []struct {
-			Metadata struct {
-				Name string `json:"name"`
-			} `json:"metadata"`
-			Spec struct {
-				Project     string `json:"project"`
-				Destination struct {
-					Namespace string `json:"namespace"`
-					Server    string `json:"server"`
-				} `json:"destination"`
-				Source struct {
-					RepoURL        string `json:"repoURL"`
-					TargetRevision string `json:"targetRevision"`
-					Path           string `json:"path"`
-				} `json:"source"`
-			} `json:"spec"`
-			Status struct {
-				Health struct {
-					Status string `json:"status"`
-				} `json:"health"`
-				Sync struct {
-					Status string `json:"status"`
-				} `json:"sync"`
-			} `json:"status"`
-		} `json:"items"`
+		Items []apiApplication `json:"items"`
 	}
 
 	// NOTE: Argo CD returns {metadata:{}, items:[...]}. items can be null.
-	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/applications", nil, &resp); err != nil {
+	// The list endpoint can't be paged, so ask only for the fields the
+	// sidebar needs; status.resources and status.history dominate the payload on
+	// large instances. Older servers ignore the parameter.
+	fields := listApplicationFields
+	if c.ListResources {
+		fields += ",items.status.resources"
+	}
+	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/applications?fields="+url.QueryEscape(fields), nil, &resp); err != nil {
 		return nil, err
 	}
 
 	apps := make([]Application, 0, len(resp.Items))
 	for _, it := range resp.Items {
-		apps = append(apps, Application{
-			Name:      it.Metadata.Name,
-			Project:   it.Spec.Project,
-			Health:    it.Status.Health.Status,
-			Sync:      it.Status.Sync.Status,
-			RepoURL:   it.Spec.Source.RepoURL,
-			Revision:  it.Spec.Source.TargetRevision,
-			Path:      it.Spec.Source.Path,
-			Namespace: it.Spec.Destination.Namespace,
-			Cluster:   it.Spec.Destination.Server,
-		})
+		apps = append(apps, it.toApplication())
 	}
+	qualifySharedNames(apps)
+	c.rememberAppNamespaces(apps)
 	return apps, nil
 }
 
+// rememberAppNamespaces records where each listed app's Application object
+// lives, for the requests that name it later.
+func (c *HTTPClient) rememberAppNamespaces(apps []Application) {
+	namespaces := make(map[string]string, len(apps))
+	for _, a := range apps {
+		if a.AppObjectNamespace != "" {
+			namespaces[a.Name] = a.AppObjectNamespace
+		}
+	}
+	c.appNamespacesMu.Lock()
+	c.appNamespaces = namespaces
+	c.appNamespacesMu.Unlock()
+}
+
+// appName splits app into the namespace of its Application object and its
+// name. app is either qualified ("ns/name", see QualifiedAppName) or a name
+// whose namespace the last ListApplications recorded; the namespace is empty
+// when unknown, which the server takes as its own namespace.
+func (c *HTTPClient) appName(app string) (namespace, name string) {
+	namespace, name = SplitAppName(app)
+	if namespace != "" {
+		return namespace, name
+	}
+	c.appNamespacesMu.Lock()
+	defer c.appNamespacesMu.Unlock()
+	return c.appNamespaces[name], name
+}
+
+// appPath is the API path of app's endpoint sub (e.g. "/events") with query
+// q, which gains appNamespace when the app's namespace is known.
+func (c *HTTPClient) appPath(app, sub string, q url.Values) string {
+	ns, name := c.appName(app)
+	if ns != "" {
+		if q == nil {
+			q = url.Values{}
+		}
+		q.Set("appNamespace", ns)
+	}
+	path := "/api/v1/applications/" + url.PathEscape(name) + sub
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	return path
+}
+
 func (c *HTTPClient) GetApplication(ctx context.Context, name string) (Application, error) {
 	return c.RefreshApplication(ctx, name, false)
 }
@@ -158,77 +395,26 @@ func (c *HTTPClient) RefreshApplication(ctx context.Context, name string, hard b
 		return Application{}, err
 	}
 
-	path := "/api/v1/applications/" + url.PathEscape(name)
+	q := url.Values{}
 	if hard {
-		path += "?refresh=hard"
+		q.Set("refresh", "hard")
 	}
+	path := c.appPath(name, "", q)
 
-	var resp struct {
-		Metadata struct {
-			Name string `json:"name"`
-		} `json:"metadata"`
-		Spec struct {
-			Project     string `json:"project"`
-			Destination struct {
-				Namespace string `json:"namespace"`
-				Server    string `json:"server"`
-			} `json:"destination"`
-			Source struct {
-				RepoURL        string `json:"repoURL"`
-				TargetRevision string `json:"targetRevision"`
-				Path           string `json:"path"`
-			} `json:"source"`
-		} `json:"spec"`
-		Status struct {
-			Health struct {
-				Status string `json:"status"`
-			} `json:"health"`
-			Sync struct {
-				Status string `json:"status"`
-			} `json:"sync"`
-			OperationState *struct {
-				Phase   string `json:"phase"`
-				Message string `json:"message"`
-			} `json:"operationState"`
-			History []struct {
-				Revision        string `json:"revision"`
-				DeployedAt      string `json:"deployedAt"`
-				DeployStartedAt string `json:"deployStartedAt"`
-				Source          any    `json:"source"`
-			} `json:"history"`
-			Conditions []struct {
-				Type    string `json:"type"`
-				Message string `json:"message"`
-			} `json:"conditions"`
-			Resources []struct {
-				Group     string `json:"group"`
-				Kind      string `json:"kind"`
-				Version   string `json:"version"`
-				Name      string `json:"name"`
-				Namespace string `json:"namespace"`
-				Status    string `json:"status"`
-				Health    struct {
-					Status string `json:"status"`
-				} `json:"health"`
-				Hook bool `json:"hook"`
-			} `json:"resources"`
-		} `json:"status"`
-	}
+	var resp apiApplication
 	if err := c.doJSON(ctx, http.MethodGet, path, nil, &resp); err != nil {
 		return Application{}, err
 	}
-	resources := make([]Resource, 0, len(resp.Status.Resources))
-	for _, r := range resp.Status.Resources {
-		resources = append(resources, Resource{
-			Group:     r.Group,
-			Kind:      r.Kind,
-			Version:   r.Version,
-			Name:      r.Name,
-			Namespace: r.Namespace,
-			Status:    r.Status,
-			Health:    r.Health.Status,
-			Hook:      r.Hook,
-		})
+	app := resp.toApplication()
+	if ns, _ := SplitAppName(name); ns != "" {
+		// Keep the qualified name the app is listed under.
+		app.Name = name
+	}
+	waves := map[ResourceRef]int{}
+	for _, r := range app.Resources {
+		if r.SyncWave != 0 {
+			waves[ResourceRef{Group: r.Group, Kind: r.Kind, Namespace: r.Namespace, Name: r.Name}] = r.SyncWave
+		}
 	}
 
 	// Prefer the resource tree endpoint for a fuller managed-resource view when available.
@@ -244,12 +430,51 @@ func (c *HTTPClient) RefreshApplication(ctx context.Context, name string, hard b
 			Health     struct {
 				Status string `json:"status"`
 			} `json:"health"`
-			Hook bool `json:"hook"`
+			Hook       bool     `json:"hook"`
+			UID        string   `json:"uid"`
+			CreatedAt  string   `json:"createdAt"`
+			Images     []string `json:"images"`
+			ParentRefs []struct {
+				UID string `json:"uid"`
+			} `json:"parentRefs"`
 		} `json:"nodes"`
 	}
-	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/applications/"+url.PathEscape(name)+"/resource-tree", nil, &tree); err == nil && len(tree.Nodes) > 0 {
-		resources = resources[:0]
-		for _, n := range tree.Nodes {
+	if err := c.doJSON(ctx, http.MethodGet, c.appPath(name, "/resource-tree", nil), nil, &tree); err == nil && len(tree.Nodes) > 0 {
+		// Only Pods carry images; credit them to every owner up the chain
+		// (ReplicaSet, Deployment, ...) too.
+		byUID := make(map[string]int, len(tree.Nodes))
+		for i, n := range tree.Nodes {
+			if n.UID != "" {
+				byUID[n.UID] = i
+			}
+		}
+		images := make([][]string, len(tree.Nodes))
+		for i, n := range tree.Nodes {
+			if len(n.Images) == 0 {
+				continue
+			}
+			seen := map[int]bool{}
+			for queue := []int{i}; len(queue) > 0; queue = queue[1:] {
+				j := queue[0]
+				if seen[j] {
+					continue
+				}
+				seen[j] = true
+				for _, img := range n.Images {
+					if !slices.Contains(images[j], img) {
+						images[j] = append(images[j], img)
+					}
+				}
+				for _, p := range tree.Nodes[j].ParentRefs {
+					if k, ok := byUID[p.UID]; ok {
+						queue = append(queue, k)
+					}
+				}
+			}
+		}
+
+		resources := make([]Resource, 0, len(tree.Nodes))
+		for i, n := range tree.Nodes {
 			status := n.Status
 			if status == "" {
 				status = n.SyncStatus
@@ -263,50 +488,17 @@ func (c *HTTPClient) RefreshApplication(ctx context.Context, name string, hard b
 				Status:    status,
 				Health:    n.Health.Status,
 				Hook:      n.Hook,
+				Images:    images[i],
+				CreatedAt: n.CreatedAt,
+				// The tree doesn't carry waves; managed resources take
+				// theirs from status.resources.
+				SyncWave: waves[ResourceRef{Group: n.Group, Kind: n.Kind, Namespace: n.Namespace, Name: n.Name}],
 			})
 		}
+		app.Resources = resources
 	}
 
-	var op *OperationState
-	if resp.Status.OperationState != nil {
-		op = &OperationState{Phase: resp.Status.OperationState.Phase, Message: resp.Status.OperationState.Message}
-	}
-
-	history := make([]SyncHistoryEntry, 0, len(resp.Status.History))
-	for _, h := range resp.Status.History {
-		deployedAt := h.DeployedAt
-		if deployedAt == "" {
-			deployedAt = h.DeployStartedAt
-		}
-		src := ""
-		if h.Source != nil {
-			if b, err := json.Marshal(h.Source); err == nil {
-				src = string(b)
-			}
-		}
-		history = append(history, SyncHistoryEntry{Revision: h.Revision, DeployedAt: deployedAt, Status: "", Message: "", Source: src})
-	}
-
-	conds := make([]AppCondition, 0, len(resp.Status.Conditions))
-	for _, cnd := range resp.Status.Conditions {
-		conds = append(conds, AppCondition{Type: cnd.Type, Message: cnd.Message})
-	}
-
-	return Application{
-		Name:           resp.Metadata.Name,
-		Namespace:      resp.Spec.Destination.Namespace,
-		Project:        resp.Spec.Project,
-		Health:         resp.Status.Health.Status,
-		Sync:           resp.Status.Sync.Status,
-		RepoURL:        resp.Spec.Source.RepoURL,
-		Revision:       resp.Spec.Source.TargetRevision,
-		Path:           resp.Spec.Source.Path,
-		Cluster:        resp.Spec.Destination.Server,
-		Resources:      resources,
-		OperationState: op,
-		History:        history,
-		Conditions:     conds,
-	}, nil
+	return app, nil
 }
 
 func (c *HTTPClient) ListRevisions(ctx context.Context, name string) ([]Revision, error) {
@@ -323,7 +515,7 @@ func (c *HTTPClient) ListRevisions(ctx context.Context, name string) ([]Revision
 			} `json:"history"`
 		} `json:"status"`
 	}
-	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/applications/"+url.PathEscape(name), nil, &app); err != nil {
+	if err := c.doJSON(ctx, http.MethodGet, c.appPath(name, "", nil), nil, &app); err != nil {
 		return nil, err
 	}
 
@@ -336,7 +528,7 @@ func (c *HTTPClient) ListRevisions(ctx context.Context, name string) ([]Revision
 				Date    string `json:"date"`
 				Message string `json:"message"`
 			}
-			_ = c.doJSON(ctx, http.MethodGet, "/api/v1/applications/"+url.PathEscape(name)+"/revisions/"+url.PathEscape(h.Revision)+"/metadata", nil, &meta)
+			_ = c.doJSON(ctx, http.MethodGet, c.appPath(name, "/revisions/"+url.PathEscape(h.Revision)+"/metadata", nil), nil, &meta)
 			r.Author = meta.Author
 			r.Date = meta.Date
 			r.Message = meta.Message
@@ -356,9 +548,11 @@ func (c *HTTPClient) RollbackApplication(ctx context.Context, name string, revis
 	if err := c.ensureLogin(ctx); err != nil {
 		return err
 	}
+	ns, name := c.appName(name)
 	payload := struct {
-		ID int64 `json:"id"`
-	}{ID: revisionID}
+		ID           int64  `json:"id"`
+		AppNamespace string `json:"appNamespace,omitempty"`
+	}{ID: revisionID, AppNamespace: ns}
 	return c.doJSON(ctx, http.MethodPost, "/api/v1/applications/"+url.PathEscape(name)+"/rollback", payload, nil)
 }
 
@@ -366,7 +560,7 @@ func (c *HTTPClient) TerminateOperation(ctx context.Context, name string) error
 	if err := c.ensureLogin(ctx); err != nil {
 		return err
 	}
-	return c.doJSON(ctx, http.MethodDelete, "/api/v1/applications/"+url.PathEscape(name)+"/operation", nil, nil)
+	return c.doJSON(ctx, http.MethodDelete, c.appPath(name, "/operation", nil), nil, nil)
 }
 
 func (c *HTTPClient) CreateApplication(ctx context.Context, app Application) error {
@@ -374,10 +568,12 @@ func (c *HTTPClient) CreateApplication(ctx context.Context, app Application) err
 		return err
 	}
 
+	meta := map[string]any{"name": app.Name}
+	if app.AppObjectNamespace != "" {
+		meta["namespace"] = app.AppObjectNamespace
+	}
 	spec := map[string]any{
-		"metadata": map[string]any{
-			"name": app.Name,
-		},
+		"metadata": meta,
 		"spec": map[string]any{
 			"project": app.Project,
 			"source": map[string]any{
@@ -402,6 +598,61 @@ func (c *HTTPClient) CreateApplication(ctx context.Context, app Application) err
 	return c.doJSON(ctx, http.MethodPost, "/api/v1/applications", spec, nil)
 }
 
+func (c *HTTPClient) CreateApplicationRaw(ctx context.Context, manifest string) error {
+	if _, err := ParseApplicationYAML(manifest); err != nil {
+		return err
+	}
+	body, err := yaml.YAMLToJSON([]byte(manifest))
+	if err != nil {
+		return fmt.Errorf("invalid YAML: %w", err)
+	}
+	if err := c.ensureLogin(ctx); err != nil {
+		return err
+	}
+	return c.doJSON(ctx, http.MethodPost, "/api/v1/applications", json.RawMessage(body), nil)
+}
+
+// ValidateApplication checks the fields locally, then asks the server
+// whether the name is taken and whether the source resolves: the repository
+// service's appdetails call fails for an unreachable repo, an unknown
+// revision or a missing path, as the create itself would.
+func (c *HTTPClient) ValidateApplication(ctx context.Context, app Application) error {
+	errs := checkNewApplication(app)
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if err := c.ensureLogin(ctx); err != nil {
+		return err
+	}
+
+	// Only a successful read proves the name is taken; a 404 (or a 403,
+	// which Argo CD also returns for apps the user cannot see) does not.
+	var existing struct{}
+	if err := c.doJSON(ctx, http.MethodGet, c.appPath(QualifiedAppName(app.AppObjectNamespace, app.Name), "", nil), nil, &existing); err == nil {
+		errs = append(errs, fmt.Errorf("application %s already exists", app.Name))
+	} else if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	query := map[string]any{
+		"source": map[string]any{
+			"repoURL":        app.RepoURL,
+			"path":           app.Path,
+			"targetRevision": app.Revision,
+		},
+		"appName":    app.Name,
+		"appProject": app.Project,
+	}
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/repositories/"+url.PathEscape(app.RepoURL)+"/appdetails", query, nil); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			err = errors.New(apiErr.Reason())
+		}
+		errs = append(errs, fmt.Errorf("source %s path %q at %s: %w", app.RepoURL, app.Path, app.Revision, err))
+	}
+	return errors.Join(errs...)
+}
+
 func (c *HTTPClient) ListProjects(ctx context.Context) ([]string, error) {
 	if err := c.ensureLogin(ctx); err != nil {
 		return nil, err
@@ -426,46 +677,69 @@ func (c *HTTPClient) ListProjects(ctx context.Context) ([]string, error) {
 	return out, nil
 }
 
-func (c *HTTPClient) ListClusters(ctx context.Context) ([]string, error) {
+// ListClusters lists the registered clusters. Newer Argo CD versions report
+// the connection state under info, older ones at the top level.
+func (c *HTTPClient) ListClusters(ctx context.Context) ([]Cluster, error) {
 	if err := c.ensureLogin(ctx); err != nil {
 		return nil, err
 	}
-	var resp []struct {
-		Server string `json:"server"`
-		Name   string `json:"name"`
+	type connectionState struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+	var resp struct {
+		Items []struct {
+			Server          string          `json:"server"`
+			Name            string          `json:"name"`
+			ConnectionState connectionState `json:"connectionState"`
+			Info            struct {
+				ConnectionState connectionState `json:"connectionState"`
+			} `json:"info"`
+		} `json:"items"`
 	}
 	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/clusters", nil, &resp); err != nil {
 		return nil, err
 	}
-	out := make([]string, 0, len(resp))
-	for _, c := range resp {
-		if c.Server != "" {
-			out = append(out, c.Server)
-		} else if c.Name != "" {
-			out = append(out, c.Name)
+	out := make([]Cluster, 0, len(resp.Items))
+	for _, it := range resp.Items {
+		if it.Server == "" && it.Name == "" {
+			continue
 		}
+		cs := it.Info.ConnectionState
+		if cs.Status == "" {
+			cs = it.ConnectionState
+		}
+		out = append(out, Cluster{Server: it.Server, Name: it.Name, ConnectionStatus: cs.Status, ConnectionMessage: cs.Message})
 	}
-	sort.Strings(out)
+	sort.Slice(out, func(i, j int) bool { return out[i].Server < out[j].Server })
 	return out, nil
 }
 
-func (c *HTTPClient) ListRepositories(ctx context.Context) ([]string, error) {
+func (c *HTTPClient) ListRepositories(ctx context.Context) ([]Repository, error) {
 	if err := c.ensureLogin(ctx); err != nil {
 		return nil, err
 	}
-	var resp []struct {
-		Repo string `json:"repo"`
+	var resp struct {
+		Items []struct {
+			Repo            string `json:"repo"`
+			Name            string `json:"name"`
+			Type            string `json:"type"`
+			ConnectionState struct {
+				Status  string `json:"status"`
+				Message string `json:"message"`
+			} `json:"connectionState"`
+		} `json:"items"`
 	}
 	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/repositories", nil, &resp); err != nil {
 		return nil, err
 	}
-	out := make([]string, 0, len(resp))
-	for _, r := range resp {
+	out := make([]Repository, 0, len(resp.Items))
+	for _, r := range resp.Items {
 		if r.Repo != "" {
-			out = append(out, r.Repo)
+			out = append(out, Repository{Repo: r.Repo, Name: r.Name, Type: r.Type, ConnectionStatus: r.ConnectionState.Status, ConnectionMessage: r.ConnectionState.Message})
 		}
 	}
-	sort.Strings(out)
+	sort.Slice(out, func(i, j int) bool { return out[i].Repo < out[j].Repo })
 	return out, nil
 }
 
@@ -477,10 +751,16 @@ func (c *HTTPClient) UpdateApplication(ctx context.Context, app Application) err
 		return fmt.Errorf("missing application name")
 	}
 
+	ns, name := c.appName(app.Name)
+	if ns == "" {
+		ns = app.AppObjectNamespace
+	}
+	meta := map[string]any{"name": name}
+	if ns != "" {
+		meta["namespace"] = ns
+	}
 	payload := map[string]any{
-		"metadata": map[string]any{
-			"name": app.Name,
-		},
+		"metadata": meta,
 		"spec": map[string]any{
 			"project": app.Project,
 			"source": map[string]any{
@@ -498,28 +778,76 @@ func (c *HTTPClient) UpdateApplication(ctx context.Context, app Application) err
 		payload["spec"].(map[string]any)["syncPolicy"] = map[string]any{"automated": map[string]any{}}
 	}
 
-	return c.doJSON(ctx, http.MethodPut, "/api/v1/applications/"+url.PathEscape(app.Name), payload, nil)
+	return c.doJSON(ctx, http.MethodPut, "/api/v1/applications/"+url.PathEscape(name), payload, nil)
+}
+
+// GetApplicationRaw fetches the application object and drops what an update
+// must not send back: its status and managed fields. The rest, including
+// metadata.resourceVersion, goes through untouched so an edit can't lose
+// fields lazyargo doesn't model and a concurrent change is rejected.
+func (c *HTTPClient) GetApplicationRaw(ctx context.Context, name string) (string, error) {
+	if err := c.ensureLogin(ctx); err != nil {
+		return "", err
+	}
+	var obj map[string]any
+	if err := c.doJSON(ctx, http.MethodGet, c.appPath(name, "", nil), nil, &obj); err != nil {
+		return "", err
+	}
+	delete(obj, "status")
+	delete(obj, "operation")
+	if meta, ok := obj["metadata"].(map[string]any); ok {
+		delete(meta, "managedFields")
+	}
+	// The API leaves out the type; the manifest reads better with it.
+	obj["apiVersion"] = "argoproj.io/v1alpha1"
+	obj["kind"] = "Application"
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// UpdateApplicationRaw sends an edited manifest from GetApplicationRaw as
+// the new application.
+func (c *HTTPClient) UpdateApplicationRaw(ctx context.Context, name, manifest string) error {
+	if _, err := ParseApplicationYAML(manifest); err != nil {
+		return err
+	}
+	body, err := yaml.YAMLToJSON([]byte(manifest))
+	if err != nil {
+		return fmt.Errorf("invalid YAML: %w", err)
+	}
+	if err := c.ensureLogin(ctx); err != nil {
+		return err
+	}
+	_, name = c.appName(name)
+	return c.doJSON(ctx, http.MethodPut, "/api/v1/applications/"+url.PathEscape(name), json.RawMessage(body), nil)
 }
 
 func (c *HTTPClient) DeleteApplication(ctx context.Context, name string, cascade bool) error {
 	if err := c.ensureLogin(ctx); err != nil {
 		return err
 	}
-	path := "/api/v1/applications/" + url.PathEscape(name)
+	q := url.Values{}
 	if cascade {
-		path += "?cascade=true"
+		q.Set("cascade", "true")
 	}
-	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
+	return c.doJSON(ctx, http.MethodDelete, c.appPath(name, "", q), nil, nil)
 }
 
-func (c *HTTPClient) SyncApplication(ctx context.Context, name string, dryRun bool) error {
+func (c *HTTPClient) SyncApplication(ctx context.Context, name string, opts SyncOptions) error {
 	if err := c.ensureLogin(ctx); err != nil {
 		return err
 	}
 
+	ns, name := c.appName(name)
 	payload := struct {
-		DryRun bool `json:"dryRun"`
-	}{DryRun: dryRun}
+		DryRun       bool   `json:"dryRun"`
+		Prune        bool   `json:"prune"`
+		Revision     string `json:"revision,omitempty"`
+		AppNamespace string `json:"appNamespace,omitempty"`
+	}{DryRun: opts.DryRun, Prune: opts.Prune, Revision: opts.Revision, AppNamespace: ns}
 
 	// The Argo CD API returns an Operation object. For now we only care that the request succeeds.
 	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/applications/"+url.PathEscape(name)+"/sync", payload, nil); err != nil {
@@ -533,14 +861,7 @@ func (c *HTTPClient) GetResource(ctx context.Context, appName string, resource R
 		return "", err
 	}
 
-	q := url.Values{}
-	q.Set("namespace", resource.Namespace)
-	q.Set("resourceName", resource.Name)
-	q.Set("version", resource.Version)
-	q.Set("kind", resource.Kind)
-	q.Set("group", resource.Group)
-
-	path := "/api/v1/applications/" + url.PathEscape(appName) + "/resource?" + q.Encode()
+	path := c.appPath(appName, "/resource", resourceQuery(resource))
 	var resp struct {
 		Manifest string `json:"manifest"`
 	}
@@ -550,14 +871,85 @@ func (c *HTTPClient) GetResource(ctx context.Context, appName string, resource R
 	return resp.Manifest, nil
 }
 
+func resourceQuery(ref ResourceRef) url.Values {
+	q := url.Values{}
+	q.Set("namespace", ref.Namespace)
+	q.Set("resourceName", ref.Name)
+	q.Set("version", ref.Version)
+	q.Set("kind", ref.Kind)
+	q.Set("group", ref.Group)
+	return q
+}
+
+func (c *HTTPClient) ListResourceActions(ctx context.Context, appName string, ref ResourceRef) ([]string, error) {
+	if err := c.ensureLogin(ctx); err != nil {
+		return nil, err
+	}
+
+	path := c.appPath(appName, "/resource/actions", resourceQuery(ref))
+	var resp struct {
+		Actions []struct {
+			Name     string `json:"name"`
+			Disabled bool   `json:"disabled"`
+		} `json:"actions"`
+	}
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	out := make([]string, 0, len(resp.Actions))
+	for _, a := range resp.Actions {
+		// Disabled actions can't be run in the resource's current state; hide them.
+		if a.Disabled || a.Name == "" {
+			continue
+		}
+		out = append(out, a.Name)
+	}
+	return out, nil
+}
+
+func (c *HTTPClient) RunResourceAction(ctx context.Context, appName string, ref ResourceRef, action string) error {
+	if err := c.ensureLogin(ctx); err != nil {
+		return err
+	}
+
+	// The action name is the whole request body (a JSON string).
+	path := c.appPath(appName, "/resource/actions", resourceQuery(ref))
+	return c.doJSON(ctx, http.MethodPost, path, action, nil)
+}
+
+func (c *HTTPClient) DeleteResource(ctx context.Context, appName string, ref ResourceRef, force bool) error {
+	if err := c.ensureLogin(ctx); err != nil {
+		return err
+	}
+
+	q := resourceQuery(ref)
+	if force {
+		q.Set("force", "true")
+	}
+
+	path := c.appPath(appName, "/resource", q)
+	return c.doJSON(ctx, http.MethodDelete, path, nil, nil)
+}
+
 func (c *HTTPClient) GetManifests(ctx context.Context, appName string) ([]string, error) {
 	if err := c.ensureLogin(ctx); err != nil {
 		return nil, err
 	}
+	return c.manifestsAt(ctx, appName, "")
+}
+
+// manifestsAt renders the desired manifests at revision (the target
+// revision when empty).
+func (c *HTTPClient) manifestsAt(ctx context.Context, appName, revision string) ([]string, error) {
+	q := url.Values{}
+	if revision != "" {
+		q.Set("revision", revision)
+	}
+	path := c.appPath(appName, "/manifests", q)
 	var resp struct {
 		Manifests []string `json:"manifests"`
 	}
-	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/applications/"+url.PathEscape(appName)+"/manifests", nil, &resp); err != nil {
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &resp); err != nil {
 		return nil, err
 	}
 	return resp.Manifests, nil
@@ -584,7 +976,7 @@ func (c *HTTPClient) ListEvents(ctx context.Context, appName string) ([]Event, e
 			} `json:"involvedObject"`
 		} `json:"items"`
 	}
-	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/applications/"+url.PathEscape(appName)+"/events", nil, &resp); err != nil {
+	if err := c.doJSON(ctx, http.MethodGet, c.appPath(appName, "/events", nil), nil, &resp); err != nil {
 		return nil, err
 	}
 	out := make([]Event, 0, len(resp.Items))
@@ -611,6 +1003,67 @@ func (c *HTTPClient) ListEvents(ctx context.Context, appName string) ([]Event, e
 	return out, nil
 }
 
+// eventsConcurrency bounds the per-app event requests ListApplicationEvents
+// has in flight at once.
+const eventsConcurrency = 4
+
+func (c *HTTPClient) ListApplicationEvents(ctx context.Context) ([]Event, error) {
+	apps, err := c.ListApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		out      []Event
+		firstErr error
+		failed   int
+	)
+	sem := make(chan struct{}, eventsConcurrency)
+	for _, a := range apps {
+		name := a.Name
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var (
+				evs []Event
+				err error
+			)
+			// Wait for a slot here rather than in the loop, so a cancelled
+			// load doesn't block on apps it will never fetch.
+			select {
+			case sem <- struct{}{}:
+				evs, err = c.ListEvents(ctx, name)
+				<-sem
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				slog.Debug("list events failed", "app", name, "err", err)
+				failed++
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			for i := range evs {
+				evs[i].App = name
+			}
+			out = append(out, evs...)
+		}()
+	}
+	wg.Wait()
+
+	// Partial results are more useful than nothing; only fail if every app did.
+	if len(apps) > 0 && failed == len(apps) {
+		return nil, firstErr
+	}
+	return out, nil
+}
+
 func (c *HTTPClient) PodLogs(ctx context.Context, appName, podName, container string, follow bool) (io.ReadCloser, error) {
 	if err := c.ensureLogin(ctx); err != nil {
 		return nil, err
@@ -620,8 +1073,12 @@ func (c *HTTPClient) PodLogs(ctx context.Context, appName, podName, container st
 	if err != nil {
 		return nil, fmt.Errorf("invalid server url: %w", err)
 	}
-	u.Path = strings.TrimRight(u.Path, "/") + "/api/v1/applications/" + url.PathEscape(appName) + "/pods/" + url.PathEscape(podName) + "/logs"
+	ns, name := c.appName(appName)
+	u.Path = strings.TrimRight(u.Path, "/") + "/api/v1/applications/" + url.PathEscape(name) + "/pods/" + url.PathEscape(podName) + "/logs"
 	q := u.Query()
+	if ns != "" {
+		q.Set("appNamespace", ns)
+	}
 	if container != "" {
 		q.Set("container", container)
 	}
@@ -630,25 +1087,14 @@ func (c *HTTPClient) PodLogs(ctx context.Context, appName, podName, container st
 	}
 	u.RawQuery = q.Encode()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return nil, err
-	}
-	if tok := c.token(); tok != "" {
-		req.Header.Set("Authorization", "Bearer "+tok)
-	}
-	if c.UserAgent != "" {
-		req.Header.Set("User-Agent", c.UserAgent)
-	}
-
-	res, err := c.client().Do(req)
+	res, b, err := c.sendStream(ctx, u.Path, func(io.Reader) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	})
 	if err != nil {
 		return nil, err
 	}
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		b, _ := io.ReadAll(res.Body)
-		_ = res.Body.Close()
-		return nil, fmt.Errorf("argocd api GET logs failed: %s: %s", res.Status, strings.TrimSpace(string(b)))
+		return nil, &APIError{Method: http.MethodGet, Path: u.Path, Status: res.StatusCode, Body: string(b), ContentType: res.Header.Get("Content-Type")}
 	}
 	// Caller must close.
 	return res.Body, nil
@@ -676,7 +1122,7 @@ func (c *HTTPClient) ServerSideDiff(ctx context.Context, appName string) ([]Diff
 		Items []diffItem `json:"items"`
 		Diffs []diffItem `json:"diffs"`
 	}
-	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/applications/"+url.PathEscape(appName)+"/server-side-diff", nil, &resp); err != nil {
+	if err := c.doJSON(ctx, http.MethodGet, c.appPath(appName, "/server-side-diff", nil), nil, &resp); err != nil {
 		return nil, err
 	}
 
@@ -706,13 +1152,42 @@ func (c *HTTPClient) RevisionMetadata(ctx context.Context, appName, revision str
 		Tags    []string `json:"tags"`
 		Message string   `json:"message"`
 	}
-	path := "/api/v1/applications/" + url.PathEscape(appName) + "/revisions/" + url.PathEscape(revision) + "/metadata"
+	path := c.appPath(appName, "/revisions/"+url.PathEscape(revision)+"/metadata", nil)
 	if err := c.doJSON(ctx, http.MethodGet, path, nil, &resp); err != nil {
 		return RevisionMeta{}, err
 	}
 	return RevisionMeta{Author: resp.Author, Date: resp.Date, Tags: resp.Tags, Message: resp.Message}, nil
 }
 
+// RevisionsDiff fetches the metadata and rendered manifests of both
+// revisions. Each part is best effort: metadata is missing for Helm chart
+// versions, and older servers ignore the revision when rendering manifests.
+// It only fails when nothing could be fetched.
+func (c *HTTPClient) RevisionsDiff(ctx context.Context, appName, from, to string) (RevisionComparison, error) {
+	if err := c.ensureLogin(ctx); err != nil {
+		return RevisionComparison{}, err
+	}
+	var out RevisionComparison
+	var fromErr, toErr error
+	out.From, fromErr = c.RevisionMetadata(ctx, appName, from)
+	out.To, toErr = c.RevisionMetadata(ctx, appName, to)
+
+	fromMan, err := c.manifestsAt(ctx, appName, from)
+	if err == nil {
+		var toMan []string
+		if toMan, err = c.manifestsAt(ctx, appName, to); err == nil {
+			out.FromManifests, out.ToManifests = fromMan, toMan
+		}
+	}
+	if err != nil {
+		if fromErr != nil && toErr != nil {
+			return RevisionComparison{}, err
+		}
+		out.ManifestsErr = err.Error()
+	}
+	return out, nil
+}
+
 func (c *HTTPClient) ChartDetails(ctx context.Context, appName, revision string) (ChartMeta, error) {
 	if err := c.ensureLogin(ctx); err != nil {
 		return ChartMeta{}, err
@@ -724,7 +1199,7 @@ func (c *HTTPClient) ChartDetails(ctx context.Context, appName, revision string)
 			Name string `json:"name"`
 		} `json:"maintainers"`
 	}
-	path := "/api/v1/applications/" + url.PathEscape(appName) + "/revisions/" + url.PathEscape(revision) + "/chartdetails"
+	path := c.appPath(appName, "/revisions/"+url.PathEscape(revision)+"/chartdetails", nil)
 	if err := c.doJSON(ctx, http.MethodGet, path, nil, &resp); err != nil {
 		return ChartMeta{}, err
 	}
@@ -737,108 +1212,245 @@ func (c *HTTPClient) ChartDetails(ctx context.Context, appName, revision string)
 	return ChartMeta{Description: resp.Description, Maintainers: m, Home: resp.Home}, nil
 }
 
+// GetSyncWindows returns the windows assigned to the app, with Active set on
+// those open now.
 func (c *HTTPClient) GetSyncWindows(ctx context.Context, appName string) ([]SyncWindow, error) {
 	if err := c.ensureLogin(ctx); err != nil {
 		return nil, err
 	}
+	type apiSyncWindow struct {
+		Kind         string   `json:"kind"`
+		Schedule     string   `json:"schedule"`
+		Duration     string   `json:"duration"`
+		Applications []string `json:"applications"`
+		Namespaces   []string `json:"namespaces"`
+		ManualSync   bool     `json:"manualSync"`
+		TimeZone     string   `json:"timeZone"`
+	}
 	var resp struct {
-		Items []struct {
-			Kind         string   `json:"kind"`
-			Schedule     string   `json:"schedule"`
-			Duration     string   `json:"duration"`
-			Applications []string `json:"applications"`
-			Namespaces   []string `json:"namespaces"`
-		} `json:"items"`
+		Assigned []apiSyncWindow `json:"assignedWindows"`
+		Active   []apiSyncWindow `json:"activeWindows"`
 	}
-	if err := c.doJSON(ctx, http.MethodGet, "/api/v1/applications/"+url.PathEscape(appName)+"/syncwindows", nil, &resp); err != nil {
+	if err := c.doJSON(ctx, http.MethodGet, c.appPath(appName, "/syncwindows", nil), nil, &resp); err != nil {
 		return nil, err
 	}
-	out := make([]SyncWindow, 0, len(resp.Items))
-	for _, it := range resp.Items {
-		out = append(out, SyncWindow{Kind: it.Kind, Schedule: it.Schedule, Duration: it.Duration, Applications: it.Applications, Namespaces: it.Namespaces})
+	active := func(w apiSyncWindow) bool {
+		for _, a := range resp.Active {
+			if a.Kind == w.Kind && a.Schedule == w.Schedule && a.Duration == w.Duration {
+				return true
+			}
+		}
+		return false
+	}
+	out := make([]SyncWindow, 0, len(resp.Assigned))
+	for _, it := range resp.Assigned {
+		out = append(out, SyncWindow{Kind: it.Kind, Schedule: it.Schedule, Duration: it.Duration, Applications: it.Applications, Namespaces: it.Namespaces, ManualSync: it.ManualSync, TimeZone: it.TimeZone, Active: active(it)})
 	}
 	return out, nil
 }
 
-func (c *HTTPClient) doJSON(ctx context.Context, method, path string, in any, out any) error {
-	u, err := url.Parse(c.Server)
-	if err != nil {
-		return fmt.Errorf("invalid server url: %w", err)
-	}
-	u.Path = strings.TrimRight(u.Path, "/") + path
+// maxRetryWait caps how long one 429 retry waits, whatever Retry-After says.
+const maxRetryWait = 30 * time.Second
 
-	var body io.Reader
-	if in != nil {
-		b, err := json.Marshal(in)
-		if err != nil {
-			return err
-		}
-		body = bytes.NewReader(b)
+// retryAfter is how long to wait before retry attempt+1 of a rate-limited
+// request: the Retry-After header (seconds or an HTTP date) when present,
+// else 500ms doubling per attempt.
+func retryAfter(header string, attempt int, now time.Time) time.Duration {
+	wait := (500 * time.Millisecond) << attempt
+	if secs, err := strconv.Atoi(strings.TrimSpace(header)); err == nil && secs >= 0 {
+		wait = time.Duration(secs) * time.Second
+	} else if at, err := http.ParseTime(header); err == nil {
+		wait = max(0, at.Sub(now))
 	}
+	return min(wait, maxRetryWait)
+}
 
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
-	if err != nil {
-		return err
+// traceCall writes one request to the trace, when tracing; bodies are only
+// rendered then.
+func (c *HTTPClient) traceCall(e TraceEntry, reqBody, respBody []byte) {
+	if c.Trace == nil {
+		return
 	}
-	req.Header.Set("Accept", "application/json")
-	if in != nil {
-		req.Header.Set("Content-Type", "application/json")
+	e.RequestBody = traceBody(reqBody)
+	e.ResponseBody = traceBody(respBody)
+	c.Trace.record(e)
+}
+
+func (c *HTTPClient) logger() *slog.Logger {
+	if c.Logger != nil {
+		return c.Logger
 	}
-	if c.UserAgent != "" {
-		req.Header.Set("User-Agent", c.UserAgent)
+	return slog.Default()
+}
+
+// send makes one API call, REST or gRPC-web, with the shared plumbing: auth
+// and User-Agent headers, the request log, the trace, and retrying a 429 up
+// to RateLimitRetries times. newReq builds each attempt's request around a
+// fresh reader over payload. Bodies are traced only when traceBodies is set,
+// since binary ones can't be redacted. The response body is read and closed.
+func (c *HTTPClient) send(ctx context.Context, path string, payload []byte, traceBodies bool, newReq func(body io.Reader) (*http.Request, error)) (*http.Response, []byte, error) {
+	return c.roundTrip(ctx, path, payload, traceBodies, false, newReq)
+}
+
+// sendStream is send for streaming responses: a successful response is
+// returned with its body unread for the caller to close; any other is read
+// as send does.
+func (c *HTTPClient) sendStream(ctx context.Context, path string, newReq func(body io.Reader) (*http.Request, error)) (*http.Response, []byte, error) {
+	return c.roundTrip(ctx, path, nil, false, true, newReq)
+}
+
+func (c *HTTPClient) roundTrip(ctx context.Context, path string, payload []byte, traceBodies, stream bool, newReq func(body io.Reader) (*http.Request, error)) (*http.Response, []byte, error) {
+	hc, err := c.client()
+	if err != nil {
+		return nil, nil, err
 	}
-	if tok := c.token(); tok != "" {
-		req.Header.Set("Authorization", "Bearer "+tok)
+	logger := c.logger()
+	tracedReq := payload
+	if !traceBodies {
+		tracedReq = nil
 	}
 
-	logger := c.Logger
-	if logger == nil {
-		logger = slog.Default()
-	}
+	for attempt := 0; ; attempt++ {
+		var body io.Reader
+		if payload != nil {
+			body = bytes.NewReader(payload)
+		}
+		req, err := newReq(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		if c.UserAgent != "" {
+			req.Header.Set("User-Agent", c.UserAgent)
+		}
+		if tok := c.token(); tok != "" {
+			req.Header.Set("Authorization", "Bearer "+tok)
+		}
+		method := req.Method
 
-	start := time.Now()
-	res, err := c.client().Do(req)
-	dur := time.Since(start)
-	if err != nil {
-		// Common local dev case: https://localhost:8080 via port-forward with a cert that isn't trusted.
-		hint := ""
-		es := err.Error()
-		if strings.Contains(es, "x509") || strings.Contains(es, "certificate") {
-			hint = " (TLS error: try --insecure or set ARGOCD_INSECURE=true)"
+		start := time.Now()
+		res, err := hc.Do(req)
+		dur := time.Since(start)
+		rec := RequestRecord{Time: start, Method: method, Path: path, Duration: dur}
+		trace := TraceEntry{Time: start, Method: method, URL: req.URL.String(), DurationMS: dur.Milliseconds()}
+		if err != nil {
+			rec.Err = err.Error()
+			c.Requests.add(rec)
+			trace.Err = rec.Err
+			c.traceCall(trace, tracedReq, nil)
+			// Common local dev case: https://localhost:8080 via port-forward with a cert that isn't trusted.
+			hint := ""
+			es := err.Error()
+			if strings.Contains(es, "x509") || strings.Contains(es, "certificate") {
+				hint = " (TLS error: trust the server's CA with --ca-cert, or skip verification with --insecure)"
+			}
+
+			logger.Error("argocd request failed",
+				"method", method,
+				"path", path,
+				"url", req.URL.String(),
+				"duration_ms", dur.Milliseconds(),
+				"err", err,
+			)
+			return nil, nil, fmt.Errorf("argocd request failed: %w%s", err, hint)
+		}
+		if stream && res.StatusCode >= 200 && res.StatusCode < 300 {
+			rec.Status = res.StatusCode
+			c.Requests.add(rec)
+			trace.Status = res.StatusCode
+			c.traceCall(trace, nil, nil)
+			logger.Debug("argocd request",
+				"method", method,
+				"path", path,
+				"status", res.StatusCode,
+				"duration_ms", dur.Milliseconds(),
+			)
+			return res, nil, nil
 		}
+		b, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		rec.Status = res.StatusCode
+		c.Requests.add(rec)
+		trace.Status = res.StatusCode
+		tracedResp := b
+		if !traceBodies {
+			tracedResp = nil
+		}
+		c.traceCall(trace, tracedReq, tracedResp)
 
-		logger.Error("argocd request failed",
+		logger.Debug("argocd request",
 			"method", method,
 			"path", path,
-			"url", u.String(),
+			"status", res.StatusCode,
 			"duration_ms", dur.Milliseconds(),
-			"err", err,
 		)
-		return fmt.Errorf("argocd request failed: %w%s", err, hint)
+
+		if res.StatusCode != http.StatusTooManyRequests || attempt >= c.RateLimitRetries {
+			return res, b, nil
+		}
+		wait := retryAfter(res.Header.Get("Retry-After"), attempt, time.Now())
+		logger.Warn("argocd rate limited, retrying",
+			"method", method,
+			"path", path,
+			"attempt", attempt+1,
+			"wait_ms", wait.Milliseconds(),
+		)
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (c *HTTPClient) doJSON(ctx context.Context, method, path string, in any, out any) error {
+	u, err := url.Parse(c.Server)
+	if err != nil {
+		return fmt.Errorf("invalid server url: %w", err)
+	}
+	// Callers pass "path?query"; keep the query out of u.Path or it gets escaped.
+	if p, q, ok := strings.Cut(path, "?"); ok {
+		path = p
+		u.RawQuery = q
 	}
-	defer res.Body.Close()
+	// Callers escape path segments with url.PathEscape; keep that escaping
+	// (RawPath) so a segment holding a slash, like a repo URL, stays one.
+	raw := strings.TrimRight(u.EscapedPath(), "/") + path
+	if u.Path, err = url.PathUnescape(raw); err != nil {
+		return fmt.Errorf("invalid request path %q: %w", path, err)
+	}
+	u.RawPath = raw
 
-	b, _ := io.ReadAll(res.Body)
+	var payload []byte
+	if in != nil {
+		if payload, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
 
-	logger.Debug("argocd request",
-		"method", method,
-		"path", path,
-		"status", res.StatusCode,
-		"duration_ms", dur.Milliseconds(),
-	)
+	res, b, err := c.send(ctx, path, payload, true, func(body io.Reader) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+		if in != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		msg := strings.TrimSpace(string(b))
-		if len(msg) > 500 {
-			msg = msg[:500] + "…"
-		}
-		logger.Warn("argocd non-2xx response",
+		apiErr := &APIError{Method: method, Path: path, Status: res.StatusCode, Body: string(b), ContentType: res.Header.Get("Content-Type")}
+		c.logger().Warn("argocd non-2xx response",
 			"method", method,
 			"path", path,
 			"status", res.StatusCode,
-			"response", msg,
+			"response", apiErr.Reason(),
 		)
-		return fmt.Errorf("argocd api %s %s failed: %s: %s", method, path, res.Status, msg)
+		return apiErr
 	}
 	if out == nil {
 		return nil
@@ -847,6 +1459,9 @@ func (c *HTTPClient) doJSON(ctx context.Context, method, path string, in any, ou
 		return nil
 	}
 	if err := json.Unmarshal(b, out); err != nil {
+		if isHTML(res.Header.Get("Content-Type"), b) {
+			return fmt.Errorf("decode %s: %w", path, ErrNotAPI)
+		}
 		return fmt.Errorf("decode response: %w", err)
 	}
 	return nil
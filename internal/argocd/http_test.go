@@ -0,0 +1,1014 @@
+package argocd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPClient_anonymous(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("unexpected Authorization header on %s", r.URL.Path)
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/applications":
+			w.Write([]byte(`{"items":[{"metadata":{"name":"guestbook"}}]}`))
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":"permission denied","code":7,"message":"permission denied"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL)
+	if _, err := c.ListApplications(context.Background()); err == nil {
+		t.Fatalf("expected missing auth to fail without Anonymous")
+	}
+
+	c.Anonymous = true
+	apps, err := c.ListApplications(context.Background())
+	if err != nil || len(apps) != 1 || apps[0].Name != "guestbook" {
+		t.Fatalf("expected anonymous read to work, got %v %v", apps, err)
+	}
+
+	err = c.SyncApplication(context.Background(), "guestbook", SyncOptions{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Summary() != "403 Forbidden: permission denied" {
+		t.Fatalf("expected the server's permission error, got %v", err)
+	}
+}
+
+// testCA is a throwaway certificate authority for TLS tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "lazyargo test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue signs a leaf certificate for usage and returns it as PEM cert and key.
+func (ca testCA) issue(t *testing.T, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "lazyargo test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kder, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: kder})
+}
+
+func writeFile(t *testing.T, name string, b []byte) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestHTTPClient_clientCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items":[]}`))
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: pool}
+	srv.StartTLS()
+	defer srv.Close()
+
+	// Settings are fixed once a client makes its first call, so each case
+	// gets its own.
+	list := func(cert, key string) error {
+		c := NewHTTPClient(srv.URL)
+		c.AuthToken = "t"
+		c.Insecure = true // the server certificate is httptest's own
+		c.ClientCert, c.ClientKey = cert, key
+		_, err := c.ListApplications(context.Background())
+		return err
+	}
+	if err := list("", ""); err == nil {
+		t.Fatalf("expected the gateway to reject a client without a certificate")
+	}
+
+	certPEM, keyPEM := ca.issue(t, x509.ExtKeyUsageClientAuth)
+	cert := writeFile(t, "client.crt", certPEM)
+	key := writeFile(t, "client.key", keyPEM)
+	if err := list(cert, key); err != nil {
+		t.Fatalf("expected mutual TLS to succeed: %v", err)
+	}
+	if err := list(cert, ""); err == nil {
+		t.Fatalf("expected a certificate without a key to be rejected")
+	}
+	if err := list(cert, writeFile(t, "bad.key", []byte("not a key"))); err == nil {
+		t.Fatalf("expected an unreadable key to be reported")
+	}
+}
+
+func TestHTTPClient_caBundle(t *testing.T) {
+	ca := newTestCA(t)
+	certPEM, keyPEM := ca.issue(t, x509.ExtKeyUsageServerAuth)
+	serverCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items":[]}`))
+	})
+	trusted := httptest.NewUnstartedServer(handler)
+	trusted.TLS = &tls.Config{Certificates: []tls.Certificate{serverCert}}
+	trusted.StartTLS()
+	defer trusted.Close()
+	other := httptest.NewTLSServer(handler)
+	defer other.Close()
+
+	bundle := writeFile(t, "ca.pem", ca.pem)
+	for _, tt := range []struct {
+		name   string
+		url    string
+		wantOK bool
+	}{
+		{"signed by the bundle", trusted.URL, true},
+		{"signed by another CA", other.URL, false},
+	} {
+		c := NewHTTPClient(tt.url)
+		c.AuthToken = "t"
+		c.CACert = bundle
+		_, err := c.ListApplications(context.Background())
+		if (err == nil) != tt.wantOK {
+			t.Errorf("%s: got err %v, want ok=%v", tt.name, err, tt.wantOK)
+		}
+	}
+
+	c := NewHTTPClient(trusted.URL)
+	c.AuthToken = "t"
+	c.CACert = writeFile(t, "empty.pem", []byte("no certificates here"))
+	if _, err := c.ListApplications(context.Background()); err == nil {
+		t.Fatalf("expected a bundle without certificates to be rejected")
+	}
+}
+
+func TestHTTPClient_proxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy sees the absolute target URL.
+		proxied = append(proxied, r.URL.String())
+		w.Write([]byte(`{"items":[]}`))
+	}))
+	defer proxy.Close()
+
+	c := NewHTTPClient("http://argocd.invalid")
+	c.AuthToken = "t"
+	c.Proxy = proxy.URL
+	if _, err := c.ListApplications(context.Background()); err != nil {
+		t.Fatalf("list through proxy: %v", err)
+	}
+	if len(proxied) != 1 || proxied[0] != "http://argocd.invalid/api/v1/applications?fields="+url.QueryEscape(listApplicationFields) {
+		t.Fatalf("expected the request to go through the proxy, got %v", proxied)
+	}
+
+	for _, bad := range []string{"proxy.corp:3128", "ftp://proxy", "http://"} {
+		if _, err := ParseProxyURL(bad); err == nil {
+			t.Errorf("ParseProxyURL(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestHTTPClient_syncWindows(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/applications/guestbook/syncwindows" {
+			t.Errorf("unexpected call %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"assignedWindows": [
+				{"kind": "allow", "schedule": "0 9 * * *", "duration": "8h"},
+				{"kind": "deny", "schedule": "0 12 * * *", "duration": "1h", "manualSync": true, "timeZone": "Europe/Berlin"}
+			],
+			"activeWindows": [{"kind": "deny", "schedule": "0 12 * * *", "duration": "1h"}],
+			"canSync": true
+		}`))
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "t"
+	ws, err := c.GetSyncWindows(context.Background(), "guestbook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ws) != 2 || ws[0].Active || !ws[1].Active || !ws[1].ManualSync || ws[1].TimeZone != "Europe/Berlin" {
+		t.Fatalf("windows = %+v", ws)
+	}
+}
+
+func TestHTTPClient_clientIsReused(t *testing.T) {
+	c := NewHTTPClient("https://argocd.example")
+	c.Insecure = true
+	first, err := c.client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := c.client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second || c.HTTP != first {
+		t.Fatalf("expected one shared client stored on HTTP, got %p and %p (HTTP %p)", first, second, c.HTTP)
+	}
+	tr, ok := first.Transport.(*http.Transport)
+	if !ok || !tr.TLSClientConfig.InsecureSkipVerify {
+		t.Fatalf("expected the TLS settings to be baked into the transport")
+	}
+
+	own := &http.Client{}
+	c = NewHTTPClient("https://argocd.example")
+	c.HTTP = own
+	if got, _ := c.client(); got != own {
+		t.Fatalf("expected a caller-provided client to be used as is")
+	}
+}
+
+func BenchmarkHTTPClient_client(b *testing.B) {
+	c := NewHTTPClient("https://argocd.example")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.client(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestHTTPClient_resourceTreeImages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/applications/guestbook":
+			w.Write([]byte(`{"metadata": {"name": "guestbook"}}`))
+		case "/api/v1/applications/guestbook/resource-tree":
+			w.Write([]byte(`{"nodes": [
+				{"group": "apps", "kind": "Deployment", "name": "web", "uid": "d1", "createdAt": "2024-05-01T12:00:00Z"},
+				{"group": "apps", "kind": "ReplicaSet", "name": "web-6d4", "uid": "rs1", "parentRefs": [{"uid": "d1"}]},
+				{"kind": "Pod", "name": "web-6d4-a", "uid": "p1", "parentRefs": [{"uid": "rs1"}], "images": ["nginx:1.25", "envoy:1.29"]},
+				{"kind": "Pod", "name": "web-6d4-b", "uid": "p2", "parentRefs": [{"uid": "rs1"}], "images": ["nginx:1.25"]},
+				{"kind": "Service", "name": "web", "uid": "s1"}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "t"
+	app, err := c.RefreshApplication(context.Background(), "guestbook", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(app.Resources) != 5 {
+		t.Fatalf("resources = %+v", app.Resources)
+	}
+	deploy, svc := app.Resources[0], app.Resources[4]
+	if deploy.CreatedAt != "2024-05-01T12:00:00Z" {
+		t.Errorf("CreatedAt = %q", deploy.CreatedAt)
+	}
+	if len(deploy.Images) != 2 || deploy.Images[0] != "nginx:1.25" || deploy.Images[1] != "envoy:1.29" {
+		t.Errorf("deployment images = %v, want the pods' images once each", deploy.Images)
+	}
+	if len(svc.Images) != 0 {
+		t.Errorf("service images = %v, want none", svc.Images)
+	}
+}
+
+func TestHTTPClient_syncWaves(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/applications/guestbook":
+			w.Write([]byte(`{"metadata": {"name": "guestbook"}, "status": {"resources": [
+				{"group": "apps", "kind": "Deployment", "namespace": "web", "name": "web", "syncWave": 2},
+				{"kind": "ConfigMap", "namespace": "web", "name": "web", "syncWave": -1},
+				{"kind": "Service", "namespace": "web", "name": "web"}
+			]}}`))
+		case "/api/v1/applications/guestbook/resource-tree":
+			w.Write([]byte(`{"nodes": [
+				{"group": "apps", "kind": "Deployment", "namespace": "web", "name": "web", "uid": "d1"},
+				{"group": "apps", "kind": "ReplicaSet", "namespace": "web", "name": "web-6d4", "uid": "rs1", "parentRefs": [{"uid": "d1"}]},
+				{"kind": "ConfigMap", "namespace": "web", "name": "web", "uid": "c1"},
+				{"kind": "Service", "namespace": "web", "name": "web", "uid": "s1"}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "t"
+	app, err := c.RefreshApplication(context.Background(), "guestbook", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waves := map[string]int{}
+	for _, r := range app.Resources {
+		waves[r.Kind] = r.SyncWave
+	}
+	want := map[string]int{"Deployment": 2, "ReplicaSet": 0, "ConfigMap": -1, "Service": 0}
+	if !reflect.DeepEqual(waves, want) {
+		t.Fatalf("waves = %v, want %v", waves, want)
+	}
+}
+
+func TestHTTPClient_retriesRateLimited(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"metadata": {"name": "guestbook"}}`))
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "t"
+	c.Requests = NewRequestLog(10)
+	var out struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+	}
+	if err := c.doJSON(context.Background(), http.MethodGet, "/api/v1/applications/guestbook", nil, &out); err != nil {
+		t.Fatalf("expected the third attempt to succeed, got %v", err)
+	}
+	if calls != 3 || out.Metadata.Name != "guestbook" || len(c.RecentRequests()) != 3 {
+		t.Fatalf("calls = %d, name = %q, recorded = %d", calls, out.Metadata.Name, len(c.RecentRequests()))
+	}
+
+	calls = 0
+	c.RateLimitRetries = 1
+	err := c.doJSON(context.Background(), http.MethodGet, "/api/v1/applications/guestbook", nil, &out)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusTooManyRequests || calls != 2 {
+		t.Fatalf("expected a 429 after one retry, got %v after %d calls", err, calls)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		header  string
+		attempt int
+		want    time.Duration
+	}{
+		{"", 0, 500 * time.Millisecond},
+		{"", 2, 2 * time.Second},
+		{"7", 0, 7 * time.Second},
+		{"3600", 0, maxRetryWait},
+		{now.Add(4 * time.Second).Format(http.TimeFormat), 0, 4 * time.Second},
+		{"soon", 1, time.Second},
+	}
+	for _, tc := range cases {
+		if got := retryAfter(tc.header, tc.attempt, now); got != tc.want {
+			t.Errorf("retryAfter(%q, %d) = %v, want %v", tc.header, tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestHTTPClient_trace(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/session":
+			w.Write([]byte(`{"token": "s3cr3t-session"}`))
+		case "/api/v1/applications":
+			w.Write([]byte(`{"items": [{"metadata": {"name": "guestbook"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	c := NewHTTPClient(srv.URL)
+	c.Username = "admin"
+	c.Password = "hunter2"
+	c.Trace = NewTracer(&buf)
+	if _, err := c.ListApplications(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = c.GetResource(context.Background(), "guestbook", ResourceRef{Kind: "Pod", Name: "missing"})
+
+	out := buf.String()
+	for _, secret := range []string{"hunter2", "s3cr3t-session", "Bearer"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("trace leaks %q:\n%s", secret, out)
+		}
+	}
+	var entries []TraceEntry
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var e TraceEntry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("bad trace line %q: %v", line, err)
+		}
+		entries = append(entries, e)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d:\n%s", len(entries), out)
+	}
+	login, list, missing := entries[0], entries[1], entries[2]
+	if login.Method != http.MethodPost || !strings.Contains(login.RequestBody, `"password":"REDACTED"`) || !strings.Contains(login.ResponseBody, `"token":"REDACTED"`) {
+		t.Fatalf("unexpected login entry %+v", login)
+	}
+	if list.Status != http.StatusOK || !strings.Contains(list.URL, "/api/v1/applications?fields=") || !strings.Contains(list.ResponseBody, "guestbook") {
+		t.Fatalf("unexpected list entry %+v", list)
+	}
+	if missing.Status != http.StatusNotFound || !strings.Contains(missing.ResponseBody, "not found") {
+		t.Fatalf("unexpected error entry %+v", missing)
+	}
+}
+
+func TestTraceBody(t *testing.T) {
+	long := strings.Repeat("x", traceBodyLimit+10)
+	if got := traceBody([]byte(long)); !strings.HasSuffix(got, fmt.Sprintf("…(%d bytes)", len(long))) || len(got) > traceBodyLimit+20 {
+		t.Fatalf("expected a truncated body, got %d bytes", len(got))
+	}
+	got := traceBody([]byte(`{"items": [{"bearerToken": "t", "config": {"tlsClientConfig": {"keyData": "k"}, "password": "p"}, "name": "c"}]}`))
+	if strings.Contains(got, `"t"`) || strings.Contains(got, `"p"`) || !strings.Contains(got, `"name":"c"`) {
+		t.Fatalf("expected nested credentials redacted, got %s", got)
+	}
+}
+
+func TestHTTPClient_appsInAnyNamespace(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, r.Method+" "+r.URL.Path+" "+r.URL.Query().Get("appNamespace")+" "+string(body))
+		switch r.URL.Path {
+		case "/api/v1/applications":
+			w.Write([]byte(`{"items": [
+				{"metadata": {"name": "guestbook", "namespace": "argocd"}},
+				{"metadata": {"name": "guestbook", "namespace": "team-a"}},
+				{"metadata": {"name": "web", "namespace": "team-b"}}
+			]}`))
+		case "/api/v1/applications/guestbook":
+			w.Write([]byte(`{"metadata": {"name": "guestbook", "namespace": "team-a"}}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "t"
+	ctx := context.Background()
+	apps, err := c.ListApplications(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, a := range apps {
+		names = append(names, a.Name)
+	}
+	if want := []string{"argocd/guestbook", "team-a/guestbook", "web"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("names = %v, want %v", names, want)
+	}
+
+	got = nil
+	app, err := c.GetApplication(ctx, "team-a/guestbook")
+	if err != nil || app.Name != "team-a/guestbook" || app.AppObjectNamespace != "team-a" {
+		t.Fatalf("GetApplication = %+v, %v", app, err)
+	}
+	if got[0] != "GET /api/v1/applications/guestbook team-a " {
+		t.Fatalf("unexpected get request %q", got[0])
+	}
+
+	got = nil
+	if err := c.SyncApplication(ctx, "web", SyncOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListEvents(ctx, "web"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`POST /api/v1/applications/web/sync  {"dryRun":false,"prune":false,"appNamespace":"team-b"}`,
+		"GET /api/v1/applications/web/events team-b ",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("requests = %q, want %q", got, want)
+	}
+}
+
+func TestHTTPClient_listRequestsAppNamespace(t *testing.T) {
+	var fields string
+	var syncBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/applications":
+			fields = r.URL.Query().Get("fields")
+			// Like Argo CD, leave out what the projection doesn't ask for.
+			ns := ""
+			if strings.Contains(","+fields+",", ",items.metadata.namespace,") {
+				ns = `, "namespace": "team-b"`
+			}
+			fmt.Fprintf(w, `{"items": [{"metadata": {"name": "web"%s}}]}`, ns)
+		case "/api/v1/applications/web/sync":
+			body, _ := io.ReadAll(r.Body)
+			syncBody = string(body)
+			w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "t"
+	ctx := context.Background()
+	apps, err := c.ListApplications(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields != listApplicationFields {
+		t.Fatalf("fields = %q, want %q", fields, listApplicationFields)
+	}
+	if len(apps) != 1 || apps[0].AppObjectNamespace != "team-b" {
+		t.Fatalf("expected the app's namespace to be decoded, got %+v", apps)
+	}
+	if err := c.SyncApplication(ctx, "web", SyncOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(syncBody, `"appNamespace":"team-b"`) {
+		t.Fatalf("expected sync to be routed to team-b, got %s", syncBody)
+	}
+}
+
+func TestHTTPClient_ValidateApplication(t *testing.T) {
+	var details map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v1/applications/taken":
+			w.Write([]byte(`{"metadata": {"name": "taken"}}`))
+		case "/api/v1/repositories/https:%2F%2Fgit.example%2Fsvc/appdetails":
+			_ = json.NewDecoder(r.Body).Decode(&details)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message": "app path does not exist"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "t"
+	app := Application{Name: "taken", Project: "default", RepoURL: "https://git.example/svc", Path: "deploy", Revision: "main", Cluster: "https://kubernetes.default.svc"}
+	err := c.ValidateApplication(context.Background(), app)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	msg := err.Error()
+	for _, want := range []string{"application taken already exists", `path "deploy" at main: app path does not exist`} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error %q does not mention %q", msg, want)
+		}
+	}
+	src, _ := details["source"].(map[string]any)
+	if src["path"] != "deploy" || details["appProject"] != "default" {
+		t.Fatalf("appdetails query = %v", details)
+	}
+
+	// Missing fields are reported without asking the server.
+	err = c.ValidateApplication(context.Background(), Application{Name: "Bad_Name"})
+	if err == nil || !strings.Contains(err.Error(), "not a valid Kubernetes name") || !strings.Contains(err.Error(), "repository is required") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestHTTPClient_ListRepositories(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items": [
+			{"repo": "https://git.example/b", "connectionState": {"status": "Failed", "message": "authentication required"}},
+			{"repo": "https://git.example/a", "name": "charts", "type": "helm", "connectionState": {"status": "Successful"}}
+		]}`))
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "t"
+	repos, err := c.ListRepositories(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Repository{
+		{Repo: "https://git.example/a", Name: "charts", Type: "helm", ConnectionStatus: "Successful"},
+		{Repo: "https://git.example/b", ConnectionStatus: "Failed", ConnectionMessage: "authentication required"},
+	}
+	if !reflect.DeepEqual(repos, want) {
+		t.Fatalf("repos = %+v", repos)
+	}
+}
+
+func TestHTTPClient_ListClusters(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items": [
+			{"server": "https://prod.example:6443", "name": "prod", "info": {"connectionState": {"status": "Failed", "message": "timeout"}}},
+			{"server": "https://kubernetes.default.svc", "name": "in-cluster", "connectionState": {"status": "Successful"}}
+		]}`))
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "t"
+	clusters, err := c.ListClusters(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Cluster{
+		{Server: "https://kubernetes.default.svc", Name: "in-cluster", ConnectionStatus: "Successful"},
+		{Server: "https://prod.example:6443", Name: "prod", ConnectionStatus: "Failed", ConnectionMessage: "timeout"},
+	}
+	if !reflect.DeepEqual(clusters, want) {
+		t.Fatalf("clusters = %+v", clusters)
+	}
+}
+
+func TestHTTPClient_getApplicationSkipsRevisionMetadata(t *testing.T) {
+	// Commit messages are the history overlay's to fetch; polling an app
+	// must stay one request (plus its resource tree).
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v1/applications/web":
+			w.Write([]byte(`{"metadata": {"name": "web"}, "status": {"history": [{"id": 1, "revision": "r1", "initiatedBy": {"username": "alice"}}]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "t"
+	app, err := c.GetApplication(context.Background(), "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(app.History) != 1 || app.History[0].Source != "alice" || app.History[0].Message != "" {
+		t.Fatalf("unexpected history %+v", app.History)
+	}
+	for _, p := range paths {
+		if strings.Contains(p, "/revisions/") {
+			t.Fatalf("expected no revision metadata requests, got %v", paths)
+		}
+	}
+}
+
+func TestHTTPClient_editRoundTripKeepsUnknownFields(t *testing.T) {
+	var put map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{
+				"metadata": {"name": "guestbook", "namespace": "argocd", "resourceVersion": "42", "finalizers": ["resources-finalizer.argocd.argoproj.io"], "managedFields": [{"manager": "argocd-server"}]},
+				"spec": {
+					"project": "default",
+					"sources": [{"repoURL": "https://git.example/r", "path": "p", "targetRevision": "main", "helm": {"parameters": [{"name": "image.tag", "value": "1.0"}]}}],
+					"destination": {"name": "in-cluster", "namespace": "web"},
+					"ignoreDifferences": [{"group": "apps", "kind": "Deployment", "jsonPointers": ["/spec/replicas"]}],
+					"syncPolicy": {"syncOptions": ["CreateNamespace=true"], "retry": {"limit": 3}}
+				},
+				"status": {"sync": {"status": "Synced"}}
+			}`))
+		case http.MethodPut:
+			if r.URL.Path != "/api/v1/applications/guestbook" {
+				t.Errorf("unexpected path %s", r.URL.Path)
+			}
+			json.NewDecoder(r.Body).Decode(&put)
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "t"
+	ctx := context.Background()
+	manifest, err := c.GetApplicationRaw(ctx, "guestbook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(manifest, "status:") || strings.Contains(manifest, "managedFields") {
+		t.Fatalf("expected status and managed fields dropped, got\n%s", manifest)
+	}
+	edited := strings.Replace(manifest, "targetRevision: main", "targetRevision: v2", 1)
+	if err := c.UpdateApplicationRaw(ctx, "guestbook", edited); err != nil {
+		t.Fatal(err)
+	}
+
+	spec, _ := put["spec"].(map[string]any)
+	sources, _ := spec["sources"].([]any)
+	if len(sources) != 1 {
+		t.Fatalf("expected the sources kept, got %v", spec)
+	}
+	src := sources[0].(map[string]any)
+	if src["targetRevision"] != "v2" || src["helm"] == nil {
+		t.Fatalf("expected the edit and the helm parameters, got %v", src)
+	}
+	dest, _ := spec["destination"].(map[string]any)
+	if dest["name"] != "in-cluster" || dest["server"] != nil {
+		t.Fatalf("expected destination.name kept, got %v", dest)
+	}
+	if spec["ignoreDifferences"] == nil || spec["syncPolicy"].(map[string]any)["retry"] == nil {
+		t.Fatalf("expected ignoreDifferences and retry kept, got %v", spec)
+	}
+	meta, _ := put["metadata"].(map[string]any)
+	if meta["resourceVersion"] != "42" || meta["finalizers"] == nil {
+		t.Fatalf("expected resourceVersion and finalizers kept, got %v", meta)
+	}
+}
+
+func TestHTTPClient_ListApplicationEvents(t *testing.T) {
+	var cancelAfterList context.CancelFunc
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/applications":
+			if cancelAfterList != nil {
+				cancelAfterList()
+			}
+			w.Write([]byte(`{"items": [{"metadata": {"name": "a"}}, {"metadata": {"name": "b"}}, {"metadata": {"name": "broken"}}, {"metadata": {"name": "c"}}]}`))
+		case "/api/v1/applications/broken/events":
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message": "boom"}`))
+		default:
+			w.Write([]byte(`{"items": [{"type": "Normal", "reason": "Synced", "lastTimestamp": "2026-03-01T12:00:00Z"}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "t"
+	evs, err := c.ListApplicationEvents(context.Background())
+	if err != nil {
+		t.Fatalf("expected the failing app to be skipped, got %v", err)
+	}
+	var apps []string
+	for _, e := range evs {
+		apps = append(apps, e.App)
+	}
+	sort.Strings(apps)
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(apps, want) {
+		t.Fatalf("events from %v, want %v", apps, want)
+	}
+
+	// Cancelled once the apps are listed: the fan-out gives up rather than
+	// queueing for slots.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelAfterList = cancel
+	if _, err := c.ListApplicationEvents(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a cancelled load to fail, got %v", err)
+	}
+}
+
+func TestHTTPClient_podLogs(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch {
+		case r.URL.Query().Get("container") == "missing":
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("<html>bad container</html>"))
+		case calls == 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte("line 1\nline 2\n"))
+		}
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "t"
+	c.Requests = NewRequestLog(10)
+	c.Trace = NewTracer(&buf)
+	rc, err := c.PodLogs(context.Background(), "guestbook", "web-0", "", true)
+	if err != nil {
+		t.Fatalf("expected the rate-limited request to be retried, got %v", err)
+	}
+	b, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(b) != "line 1\nline 2\n" {
+		t.Fatalf("logs = %q", b)
+	}
+	reqs := c.RecentRequests()
+	if len(reqs) != 2 || reqs[0].Status != http.StatusOK || reqs[1].Status != http.StatusTooManyRequests || !strings.HasSuffix(reqs[0].Path, "/pods/web-0/logs") {
+		t.Fatalf("expected both attempts in the request log, got %+v", reqs)
+	}
+	if n := strings.Count(buf.String(), "/pods/web-0/logs"); n != 2 {
+		t.Fatalf("expected both attempts traced, got %d:\n%s", n, buf.String())
+	}
+
+	_, err = c.PodLogs(context.Background(), "guestbook", "web-0", "missing", false)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusBadRequest || apiErr.ContentType != "text/html" {
+		t.Fatalf("expected an APIError with its content type, got %#v", err)
+	}
+}
+
+func TestNormalizeServerURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{in: "argocd.example.com", want: "https://argocd.example.com"},
+		{in: "localhost:8080", want: "https://localhost:8080"},
+		{in: "  localhost:8080/ ", want: "https://localhost:8080"},
+		{in: "https://argocd.example.com/", want: "https://argocd.example.com"},
+		{in: "HTTP://127.0.0.1:8080", want: "http://127.0.0.1:8080"},
+		{in: "https://gw.example.com/argocd//", want: "https://gw.example.com/argocd"},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeServerURL(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("NormalizeServerURL(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "ftp://argocd.example.com", "https://", "localhost:http", "argo cd.example.com", "https://argocd.example.com?x=1"} {
+		if _, err := NormalizeServerURL(bad); err == nil {
+			t.Errorf("NormalizeServerURL(%q): expected an error", bad)
+		}
+	}
+
+	if got := NewHTTPClient("localhost:8080/").Server; got != "https://localhost:8080" {
+		t.Errorf("NewHTTPClient server = %q", got)
+	}
+}
+
+func TestHTTPClient_webUIResponse(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/applications/missing" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"application not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(status)
+		w.Write([]byte("<!DOCTYPE html><html><head><title>Argo CD</title></head></html>"))
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "t"
+	_, err := c.ListApplications(context.Background())
+	if !errors.Is(err, ErrNotAPI) || !LooksLikeWebUI(err) {
+		t.Fatalf("200 HTML: err = %v, want ErrNotAPI", err)
+	}
+
+	status = http.StatusNotFound
+	if _, err := c.ListApplications(context.Background()); !LooksLikeWebUI(err) {
+		t.Fatalf("404 HTML: expected the web UI hint, err = %v", err)
+	}
+	if _, err := c.GetApplication(context.Background(), "missing"); err == nil || LooksLikeWebUI(err) {
+		t.Fatalf("a JSON 404 for one app is a normal API error, got %v", err)
+	}
+	if !LooksLikeWebUI(&APIError{Path: "/api/v1/applications", Status: http.StatusNotFound, Body: "404 page not found"}) {
+		t.Fatalf("a 404 for the list should suggest the wrong endpoint")
+	}
+}
+
+func TestHTTPClient_tokenFileIsReread(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Authorization"))
+		w.Write([]byte(`{"items":[]}`))
+	}))
+	defer srv.Close()
+
+	path := writeFile(t, "token", []byte("first\n"))
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "first"
+	c.TokenFile = path
+	list := func() {
+		t.Helper()
+		if _, err := c.ListApplications(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	list()
+	if err := os.WriteFile(path, []byte("second\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// Make the change visible even on filesystems with coarse mtimes.
+	if err := os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	list()
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	list()
+
+	want := []string{"Bearer first", "Bearer second", "Bearer second"}
+	if len(got) != len(want) {
+		t.Fatalf("requests = %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("request %d: Authorization = %q, want %q (all %v)", i, got[i], want[i], got)
+		}
+	}
+}
+
+func TestHTTPClient_DeleteResource(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotQuery map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotQuery = r.Method, r.URL.Path, r.URL.Query()
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := NewHTTPClient(srv.URL)
+	c.AuthToken = "t"
+	ref := ResourceRef{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "web"}
+	if err := c.DeleteResource(context.Background(), "guestbook", ref, true); err != nil {
+		t.Fatalf("DeleteResource: %v", err)
+	}
+
+	if gotMethod != http.MethodDelete || gotPath != "/api/v1/applications/guestbook/resource" {
+		t.Fatalf("expected DELETE /api/v1/applications/guestbook/resource, got %s %s", gotMethod, gotPath)
+	}
+	want := map[string]string{"namespace": "default", "resourceName": "web", "version": "v1", "kind": "Deployment", "group": "apps", "force": "true"}
+	for k, v := range want {
+		if got := gotQuery[k]; len(got) != 1 || got[0] != v {
+			t.Fatalf("expected query %s=%s, got %v", k, v, got)
+		}
+	}
+}
@@ -2,20 +2,102 @@ package argocd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 )
 
 type MockClient struct {
+	// mu guards apps, opStarted and opRevision: the UI calls the client
+	// from concurrent commands, reads and syncs alike.
+	mu   sync.Mutex
 	apps []Application
+
+	// SyncDuration is how long a sync operation stays Running before it
+	// succeeds; reads after that see it finished.
+	SyncDuration time.Duration
+	// Now is the mock's clock. Tests can replace it to step operations
+	// without sleeping.
+	Now func() time.Time
+
+	// opStarted holds the start time of each sync the mock is running, and
+	// opRevision the revision it was asked for (empty for the target).
+	opStarted  map[string]time.Time
+	opRevision map[string]string
+
+	// latency and errorRate slow down and fail calls; see WithLatency and
+	// WithErrorRate.
+	latency   time.Duration
+	errorRate float64
+	randMu    sync.Mutex
+	rand      *rand.Rand
+}
+
+// MockOption configures a MockClient.
+type MockOption func(*MockClient)
+
+// WithLatency makes every call take d (or until its context is done).
+func WithLatency(d time.Duration) MockOption {
+	return func(m *MockClient) { m.latency = d }
+}
+
+// WithErrorRate makes each call fail with probability p (0..1) with a 503
+// APIError. The sequence is seeded, so a given rate fails the same calls
+// on every run.
+func WithErrorRate(p float64) MockOption {
+	return func(m *MockClient) { m.errorRate = p }
 }
 
-func NewMockClient() *MockClient {
-	return &MockClient{apps: []Application{
+func NewMockClient(opts ...MockOption) *MockClient {
+	m := &MockClient{
+		apps:         sampleApps(),
+		SyncDuration: 3 * time.Second,
+		Now:          time.Now,
+		opStarted:    map[string]time.Time{},
+		opRevision:   map[string]string{},
+		rand:         rand.New(rand.NewSource(1)),
+	}
+	for _, o := range opts {
+		o(m)
+	}
+	return m
+}
+
+// simulate applies the configured latency and error injection to a call.
+func (m *MockClient) simulate(ctx context.Context) error {
+	if m.latency > 0 {
+		t := time.NewTimer(m.latency)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+	if m.errorRate <= 0 {
+		return nil
+	}
+	m.randMu.Lock()
+	fail := m.rand.Float64() < m.errorRate
+	m.randMu.Unlock()
+	if fail {
+		return &APIError{Method: "MOCK", Path: "(mock)", Status: 503, Body: `{"message":"mock: injected failure"}`}
+	}
+	return nil
+}
+
+// sampleApps is the mock's demo fleet.
+func sampleApps() []Application {
+	ago := func(d time.Duration) string { return time.Now().Add(-d).UTC().Format(time.RFC3339) }
+	return []Application{
 		{
 			Name:      "payments-api",
+			Labels:    map[string]string{"team": "payments", "env": "prod"},
 			Namespace: "payments",
 			Project:   "default",
 			Health:    "Healthy",
@@ -25,10 +107,10 @@ func NewMockClient() *MockClient {
 			Revision:  "main",
 			Cluster:   "https://kubernetes.default.svc",
 			Resources: []Resource{
-				{Group: "apps", Kind: "Deployment", Version: "v1", Name: "payments-api", Namespace: "payments", Status: "Synced", Health: "Healthy"},
+				{Group: "apps", Kind: "Deployment", Version: "v1", Name: "payments-api", Namespace: "payments", Status: "Synced", Health: "Healthy", Images: []string{"ghcr.io/example/payments-api:1.8.2", "ghcr.io/example/envoy:1.29"}, CreatedAt: ago(41 * 24 * time.Hour)},
 				{Group: "", Kind: "Service", Version: "v1", Name: "payments-api", Namespace: "payments", Status: "Synced", Health: "Healthy"},
-				{Group: "", Kind: "ConfigMap", Version: "v1", Name: "payments-config", Namespace: "payments", Status: "Synced", Health: "Healthy"},
-				{Group: "autoscaling", Kind: "HorizontalPodAutoscaler", Version: "v2", Name: "payments-api", Namespace: "payments", Status: "Synced", Health: "Healthy"},
+				{Group: "", Kind: "ConfigMap", Version: "v1", Name: "payments-config", Namespace: "payments", Status: "Synced", Health: "Healthy", SyncWave: -1},
+				{Group: "autoscaling", Kind: "HorizontalPodAutoscaler", Version: "v2", Name: "payments-api", Namespace: "payments", Status: "Synced", Health: "Healthy", SyncWave: 2},
 			},
 			History: []SyncHistoryEntry{
 				{Revision: "c0ffee", DeployedAt: "2026-01-20T18:00:00Z", Status: "Succeeded", Message: "initial deploy", Source: "ci"},
@@ -39,22 +121,25 @@ func NewMockClient() *MockClient {
 		},
 		{
 			Name:           "orders-worker",
+			Labels:         map[string]string{"team": "orders", "env": "prod"},
 			Namespace:      "orders",
 			Project:        "default",
 			Health:         "Progressing",
 			Sync:           "Synced",
 			OperationState: &OperationState{Phase: "Running", Message: "syncing"},
+			ReconciledAt:   ago(2 * time.Hour),
 			RepoURL:        "https://github.com/example/platform",
 			Path:           "apps/orders",
 			Revision:       "main",
 			Cluster:        "https://kubernetes.default.svc",
 			Resources: []Resource{
-				{Group: "apps", Kind: "Deployment", Version: "v1", Name: "orders-worker", Namespace: "orders", Status: "Synced", Health: "Progressing"},
-				{Group: "batch", Kind: "CronJob", Version: "v1", Name: "orders-reconciler", Namespace: "orders", Status: "Synced", Health: "Healthy"},
+				{Group: "apps", Kind: "Deployment", Version: "v1", Name: "orders-worker", Namespace: "orders", Status: "Synced", Health: "Progressing", Images: []string{"ghcr.io/example/orders-worker:2.3.0"}, CreatedAt: ago(6 * time.Hour)},
+				{Group: "batch", Kind: "CronJob", Version: "v1", Name: "orders-reconciler", Namespace: "orders", Status: "Synced", Health: "Healthy", Images: []string{"ghcr.io/example/orders-worker:2.3.0"}, CreatedAt: ago(90 * 24 * time.Hour)},
 			},
 		},
 		{
 			Name:      "web-frontend",
+			Labels:    map[string]string{"team": "web", "env": "staging"},
 			Namespace: "web",
 			Project:   "default",
 			Health:    "Healthy",
@@ -64,7 +149,7 @@ func NewMockClient() *MockClient {
 			Revision:  "main",
 			Cluster:   "https://kubernetes.default.svc",
 			Resources: []Resource{
-				{Group: "apps", Kind: "Deployment", Version: "v1", Name: "web-frontend", Namespace: "web", Status: "OutOfSync", Health: "Healthy"},
+				{Group: "apps", Kind: "Deployment", Version: "v1", Name: "web-frontend", Namespace: "web", Status: "OutOfSync", Health: "Healthy", Images: []string{"ghcr.io/example/web-frontend:5.0.1"}, CreatedAt: ago(3 * 24 * time.Hour)},
 				{Group: "", Kind: "Service", Version: "v1", Name: "web-frontend", Namespace: "web", Status: "Synced", Health: "Healthy"},
 				{Group: "networking.k8s.io", Kind: "Ingress", Version: "v1", Name: "web", Namespace: "web", Status: "OutOfSync", Health: "Healthy"},
 				{Group: "", Kind: "Secret", Version: "v1", Name: "web-tls", Namespace: "web", Status: "OutOfSync", Health: "—"},
@@ -72,6 +157,7 @@ func NewMockClient() *MockClient {
 		},
 		{
 			Name:      "observability",
+			Labels:    map[string]string{"team": "platform", "env": "prod"},
 			Namespace: "ops",
 			Project:   "platform",
 			Health:    "Degraded",
@@ -81,14 +167,15 @@ func NewMockClient() *MockClient {
 			Revision:  "main",
 			Cluster:   "https://kubernetes.default.svc",
 			Resources: []Resource{
-				{Group: "apps", Kind: "StatefulSet", Version: "v1", Name: "loki", Namespace: "ops", Status: "Synced", Health: "Degraded"},
-				{Group: "apps", Kind: "Deployment", Version: "v1", Name: "grafana", Namespace: "ops", Status: "Synced", Health: "Healthy"},
+				{Group: "apps", Kind: "StatefulSet", Version: "v1", Name: "loki", Namespace: "ops", Status: "Synced", Health: "Degraded", Images: []string{"grafana/loki:2.9.4"}, CreatedAt: ago(200 * 24 * time.Hour)},
+				{Group: "apps", Kind: "Deployment", Version: "v1", Name: "grafana", Namespace: "ops", Status: "Synced", Health: "Healthy", Images: []string{"grafana/grafana:10.4.1"}, CreatedAt: ago(200 * 24 * time.Hour)},
 				{Group: "", Kind: "Service", Version: "v1", Name: "grafana", Namespace: "ops", Status: "Synced", Health: "Healthy"},
-				{Group: "", Kind: "Job", Version: "v1", Name: "migrate-dashboards", Namespace: "ops", Status: "Synced", Health: "Healthy", Hook: true},
+				{Group: "", Kind: "Job", Version: "v1", Name: "migrate-dashboards", Namespace: "ops", Status: "Synced", Health: "Healthy", Hook: true, SyncWave: 5},
 			},
 		},
 		{
 			Name:      "cluster-addons",
+			Labels:    map[string]string{"team": "platform", "env": "dev"},
 			Namespace: "kube-system",
 			Project:   "platform",
 			Health:    "Missing",
@@ -102,14 +189,58 @@ func NewMockClient() *MockClient {
 				{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole", Version: "v1", Name: "addons-read", Namespace: "", Status: "Unknown", Health: "—"},
 			},
 		},
-	}}
+	}
+}
+
+// advanceOperations finishes the syncs that have been running for
+// SyncDuration: the operation succeeds and the app and its resources become
+// Synced. Reads call it, so progress shows up the way it would on a server.
+// The caller holds mu.
+func (m *MockClient) advanceOperations() {
+	for i := range m.apps {
+		a := &m.apps[i]
+		started, ok := m.opStarted[a.Name]
+		if !ok || m.Now().Sub(started) < m.SyncDuration {
+			continue
+		}
+		rev := m.opRevision[a.Name]
+		if rev == "" {
+			rev = a.Revision
+		}
+		delete(m.opStarted, a.Name)
+		delete(m.opRevision, a.Name)
+		// Replace rather than mutate: earlier reads share the pointer and
+		// the history and resource arrays.
+		a.OperationState = &OperationState{Phase: "Succeeded", Message: "successfully synced (all tasks run)", StartedAt: started.UTC().Format(time.RFC3339)}
+		a.History = append(slices.Clip(a.History), SyncHistoryEntry{Revision: rev, DeployedAt: m.Now().UTC().Format(time.RFC3339), Status: "Succeeded", Message: "mock sync", Source: "mock"})
+		a.Sync = "Synced"
+		a.Resources = slices.Clone(a.Resources)
+		for r := range a.Resources {
+			a.Resources[r].Status = "Synced"
+		}
+	}
+}
+
+// app returns a copy of the named application.
+func (m *MockClient) app(name string) (Application, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, a := range m.apps {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Application{}, false
 }
 
 func (m *MockClient) ListApplications(ctx context.Context) ([]Application, error) {
-	_ = ctx
-	out := make([]Application, len(m.apps))
-	copy(out, m.apps)
-	return out, nil
+	if err := m.simulate(ctx); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.advanceOperations()
+	return slices.Clone(m.apps), nil
 }
 
 func (m *MockClient) GetApplication(ctx context.Context, name string) (Application, error) {
@@ -117,18 +248,29 @@ func (m *MockClient) GetApplication(ctx context.Context, name string) (Applicati
 }
 
 func (m *MockClient) RefreshApplication(ctx context.Context, name string, hard bool) (Application, error) {
-	_ = ctx
-	_ = hard
-	for _, a := range m.apps {
+	if err := m.simulate(ctx); err != nil {
+		return Application{}, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.advanceOperations()
+	for i, a := range m.apps {
 		if a.Name == name {
-			return a, nil
+			if hard {
+				m.apps[i].ReconciledAt = time.Now().UTC().Format(time.RFC3339)
+			}
+			return m.apps[i], nil
 		}
 	}
 	return Application{}, fmt.Errorf("application not found: %s", name)
 }
 
 func (m *MockClient) ListRevisions(ctx context.Context, name string) ([]Revision, error) {
-	_ = ctx
+	if err := m.simulate(ctx); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	// Use a stable sample history for the demo.
 	for _, a := range m.apps {
 		if a.Name == name {
@@ -143,7 +285,11 @@ func (m *MockClient) ListRevisions(ctx context.Context, name string) ([]Revision
 }
 
 func (m *MockClient) RollbackApplication(ctx context.Context, name string, revisionID int64) error {
-	_ = ctx
+	if err := m.simulate(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	for i := range m.apps {
 		if m.apps[i].Name == name {
 			m.apps[i].Sync = "OutOfSync"
@@ -155,10 +301,16 @@ func (m *MockClient) RollbackApplication(ctx context.Context, name string, revis
 }
 
 func (m *MockClient) TerminateOperation(ctx context.Context, name string) error {
-	_ = ctx
+	if err := m.simulate(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	for i := range m.apps {
 		if m.apps[i].Name == name {
 			m.apps[i].OperationState = nil
+			delete(m.opStarted, name)
+			delete(m.opRevision, name)
 			return nil
 		}
 	}
@@ -166,7 +318,11 @@ func (m *MockClient) TerminateOperation(ctx context.Context, name string) error
 }
 
 func (m *MockClient) DeleteApplication(ctx context.Context, name string, cascade bool) error {
-	_ = ctx
+	if err := m.simulate(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	_ = cascade
 	for i := range m.apps {
 		if m.apps[i].Name == name {
@@ -178,10 +334,14 @@ func (m *MockClient) DeleteApplication(ctx context.Context, name string, cascade
 }
 
 func (m *MockClient) CreateApplication(ctx context.Context, app Application) error {
-	_ = ctx
+	if err := m.simulate(ctx); err != nil {
+		return err
+	}
 	if app.Name == "" {
 		return fmt.Errorf("missing application name")
 	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	for _, a := range m.apps {
 		if a.Name == app.Name {
 			return fmt.Errorf("application already exists: %s", app.Name)
@@ -194,23 +354,64 @@ func (m *MockClient) CreateApplication(ctx context.Context, app Application) err
 	return nil
 }
 
+func (m *MockClient) CreateApplicationRaw(ctx context.Context, manifest string) error {
+	app, err := ParseApplicationYAML(manifest)
+	if err != nil {
+		return err
+	}
+	return m.CreateApplication(ctx, app)
+}
+
+// ValidateApplication checks the required fields and that the name is free;
+// the mock has no repositories to resolve the source against.
+func (m *MockClient) ValidateApplication(ctx context.Context, app Application) error {
+	if err := m.simulate(ctx); err != nil {
+		return err
+	}
+	errs := checkNewApplication(app)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, a := range m.apps {
+		if a.Name == app.Name {
+			errs = append(errs, fmt.Errorf("application %s already exists", app.Name))
+			break
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (m *MockClient) ListProjects(ctx context.Context) ([]string, error) {
-	_ = ctx
+	if err := m.simulate(ctx); err != nil {
+		return nil, err
+	}
 	return []string{"default", "platform"}, nil
 }
 
-func (m *MockClient) ListClusters(ctx context.Context) ([]string, error) {
-	_ = ctx
-	return []string{"https://kubernetes.default.svc"}, nil
+func (m *MockClient) ListClusters(ctx context.Context) ([]Cluster, error) {
+	if err := m.simulate(ctx); err != nil {
+		return nil, err
+	}
+	return []Cluster{{Server: "https://kubernetes.default.svc", Name: "in-cluster", ConnectionStatus: "Successful"}}, nil
 }
 
-func (m *MockClient) ListRepositories(ctx context.Context) ([]string, error) {
-	_ = ctx
-	return []string{"https://github.com/example/platform", "https://github.com/example/ops"}, nil
+func (m *MockClient) ListRepositories(ctx context.Context) ([]Repository, error) {
+	if err := m.simulate(ctx); err != nil {
+		return nil, err
+	}
+	return []Repository{
+		{Repo: "https://github.com/example/platform", Type: "git", ConnectionStatus: "Successful"},
+		{Repo: "https://github.com/example/ops", Type: "git", ConnectionStatus: "Successful"},
+		{Repo: "https://charts.example.com", Name: "example-charts", Type: "helm", ConnectionStatus: "Successful"},
+		{Repo: "https://git.example.internal/legacy", Type: "git", ConnectionStatus: "Failed", ConnectionMessage: "dial tcp: lookup git.example.internal: no such host"},
+	}, nil
 }
 
 func (m *MockClient) UpdateApplication(ctx context.Context, app Application) error {
-	_ = ctx
+	if err := m.simulate(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	for i := range m.apps {
 		if m.apps[i].Name == app.Name {
 			m.apps[i].Project = app.Project
@@ -226,32 +427,112 @@ func (m *MockClient) UpdateApplication(ctx context.Context, app Application) err
 	return fmt.Errorf("application not found: %s", app.Name)
 }
 
-func (m *MockClient) SyncApplication(ctx context.Context, name string, dryRun bool) error {
-	_ = ctx
+// GetApplicationRaw renders the fields the mock keeps; it has no others.
+func (m *MockClient) GetApplicationRaw(ctx context.Context, name string) (string, error) {
+	if err := m.simulate(ctx); err != nil {
+		return "", err
+	}
+	a, ok := m.app(name)
+	if !ok {
+		return "", fmt.Errorf("application not found: %s", name)
+	}
+	return ApplicationYAML(a)
+}
+
+func (m *MockClient) UpdateApplicationRaw(ctx context.Context, name, manifest string) error {
+	app, err := ParseApplicationYAML(manifest)
+	if err != nil {
+		return err
+	}
+	app.Name = name
+	return m.UpdateApplication(ctx, app)
+}
+
+func (m *MockClient) SyncApplication(ctx context.Context, name string, opts SyncOptions) error {
+	if err := m.simulate(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	for i := range m.apps {
 		if m.apps[i].Name != name {
 			continue
 		}
-		if dryRun {
+		if opts.DryRun {
 			return nil
 		}
-		m.apps[i].Sync = "Synced"
-		for r := range m.apps[i].Resources {
-			if m.apps[i].Resources[r].Status != "Synced" {
-				m.apps[i].Resources[r].Status = "Synced"
-			}
+		now := m.Now()
+		m.opStarted[name] = now
+		m.opRevision[name] = opts.Revision
+		msg := "syncing"
+		if opts.Revision != "" {
+			msg = "syncing to " + opts.Revision
 		}
+		m.apps[i].OperationState = &OperationState{Phase: "Running", Message: msg, StartedAt: now.UTC().Format(time.RFC3339)}
 		return nil
 	}
 	return fmt.Errorf("application not found: %s", name)
 }
 
-func (m *MockClient) GetResource(ctx context.Context, appName string, resource ResourceRef) (string, error) {
-	_ = ctx
-	for _, a := range m.apps {
-		if a.Name != appName {
+func (m *MockClient) DeleteResource(ctx context.Context, appName string, ref ResourceRef, force bool) error {
+	if err := m.simulate(ctx); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_ = force
+	for i := range m.apps {
+		if m.apps[i].Name != appName {
 			continue
 		}
+		rs := m.apps[i].Resources
+		for j, r := range rs {
+			if r.Group == ref.Group && r.Kind == ref.Kind && r.Name == ref.Name && r.Namespace == ref.Namespace {
+				m.apps[i].Resources = append(rs[:j:j], rs[j+1:]...)
+				return nil
+			}
+		}
+		return fmt.Errorf("resource not found: %s/%s", ref.Kind, ref.Name)
+	}
+	return fmt.Errorf("application not found: %s", appName)
+}
+
+func (m *MockClient) ListResourceActions(ctx context.Context, appName string, ref ResourceRef) ([]string, error) {
+	if err := m.simulate(ctx); err != nil {
+		return nil, err
+	}
+	if _, ok := m.app(appName); ok {
+		switch ref.Kind {
+		case "Deployment", "StatefulSet", "DaemonSet":
+			return []string{"restart"}, nil
+		case "Rollout":
+			return []string{"restart", "pause", "resume", "abort", "promote-full"}, nil
+		case "CronJob":
+			return []string{"create-job", "suspend", "resume"}, nil
+		}
+		return nil, nil
+	}
+	return nil, fmt.Errorf("application not found: %s", appName)
+}
+
+func (m *MockClient) RunResourceAction(ctx context.Context, appName string, ref ResourceRef, action string) error {
+	actions, err := m.ListResourceActions(ctx, appName, ref)
+	if err != nil {
+		return err
+	}
+	for _, a := range actions {
+		if a == action {
+			return nil
+		}
+	}
+	return fmt.Errorf("action %q not available for %s/%s", action, ref.Kind, ref.Name)
+}
+
+func (m *MockClient) GetResource(ctx context.Context, appName string, resource ResourceRef) (string, error) {
+	if err := m.simulate(ctx); err != nil {
+		return "", err
+	}
+	if _, ok := m.app(appName); ok {
 		// A very rough live object.
 		apiVersion := resource.Version
 		if apiVersion == "" {
@@ -272,11 +553,10 @@ func (m *MockClient) GetResource(ctx context.Context, appName string, resource R
 }
 
 func (m *MockClient) GetManifests(ctx context.Context, appName string) ([]string, error) {
-	_ = ctx
-	for _, a := range m.apps {
-		if a.Name != appName {
-			continue
-		}
+	if err := m.simulate(ctx); err != nil {
+		return nil, err
+	}
+	if a, ok := m.app(appName); ok {
 		out := make([]string, 0, len(a.Resources))
 		for _, r := range a.Resources {
 			ref := ResourceRef{Group: r.Group, Kind: r.Kind, Name: r.Name, Namespace: r.Namespace, Version: r.Version}
@@ -289,21 +569,41 @@ func (m *MockClient) GetManifests(ctx context.Context, appName string) ([]string
 }
 
 func (m *MockClient) ListEvents(ctx context.Context, appName string) ([]Event, error) {
-	_ = ctx
+	if err := m.simulate(ctx); err != nil {
+		return nil, err
+	}
 	// Provide a tiny stable sample.
-	for _, a := range m.apps {
-		if a.Name == appName {
-			return []Event{
-				{Timestamp: time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339), Type: "Normal", Reason: "Synced", Message: "application synced", InvolvedObject: "Application/" + appName},
-				{Timestamp: time.Now().Add(-2 * time.Minute).UTC().Format(time.RFC3339), Type: "Warning", Reason: "Drift", Message: "resource out of sync detected", InvolvedObject: "Deployment/example"},
-			}, nil
-		}
+	if _, ok := m.app(appName); ok {
+		return []Event{
+			{Timestamp: time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339), Type: "Normal", Reason: "Synced", Message: "application synced", InvolvedObject: "Application/" + appName},
+			{Timestamp: time.Now().Add(-2 * time.Minute).UTC().Format(time.RFC3339), Type: "Warning", Reason: "Drift", Message: "resource out of sync detected", InvolvedObject: "Deployment/example"},
+		}, nil
 	}
 	return nil, fmt.Errorf("application not found: %s", appName)
 }
 
+func (m *MockClient) ListApplicationEvents(ctx context.Context) ([]Event, error) {
+	m.mu.Lock()
+	apps := slices.Clone(m.apps)
+	m.mu.Unlock()
+	out := make([]Event, 0, len(apps)*2)
+	for _, a := range apps {
+		evs, err := m.ListEvents(ctx, a.Name)
+		if err != nil {
+			return nil, err
+		}
+		for i := range evs {
+			evs[i].App = a.Name
+		}
+		out = append(out, evs...)
+	}
+	return out, nil
+}
+
 func (m *MockClient) PodLogs(ctx context.Context, appName, podName, container string, follow bool) (io.ReadCloser, error) {
-	_ = ctx
+	if err := m.simulate(ctx); err != nil {
+		return nil, err
+	}
 	_ = appName
 	_ = podName
 	_ = container
@@ -318,33 +618,67 @@ func (m *MockClient) PodLogs(ctx context.Context, appName, podName, container st
 }
 
 func (m *MockClient) ServerSideDiff(ctx context.Context, appName string) ([]DiffResult, error) {
-	_ = ctx
-	for _, a := range m.apps {
-		if a.Name == appName {
-			return []DiffResult{{
-				Ref:      ResourceRef{Group: "apps", Kind: "Deployment", Name: appName, Namespace: a.Namespace, Version: "v1"},
-				Modified: a.Sync != "Synced",
-				Diff:     "--- live\n+++ desired\n@@\n- replicas: 1\n+ replicas: 2\n",
-			}}, nil
-		}
+	if err := m.simulate(ctx); err != nil {
+		return nil, err
+	}
+	if a, ok := m.app(appName); ok {
+		return []DiffResult{{
+			Ref:      ResourceRef{Group: "apps", Kind: "Deployment", Name: appName, Namespace: a.Namespace, Version: "v1"},
+			Modified: a.Sync != "Synced",
+			Diff:     "--- live\n+++ desired\n@@\n- replicas: 1\n+ replicas: 2\n",
+		}}, nil
 	}
 	return nil, fmt.Errorf("application not found: %s", appName)
 }
 
 func (m *MockClient) RevisionMetadata(ctx context.Context, appName, revision string) (RevisionMeta, error) {
-	_ = ctx
+	if err := m.simulate(ctx); err != nil {
+		return RevisionMeta{}, err
+	}
 	_ = appName
 	return RevisionMeta{Author: "alice", Date: "2026-02-01T12:34:56Z", Tags: []string{"v1.0.0"}, Message: "demo metadata for " + revision}, nil
 }
 
+func (m *MockClient) RevisionsDiff(ctx context.Context, appName, from, to string) (RevisionComparison, error) {
+	fromMeta, err := m.RevisionMetadata(ctx, appName, from)
+	if err != nil {
+		return RevisionComparison{}, err
+	}
+	toMeta, err := m.RevisionMetadata(ctx, appName, to)
+	if err != nil {
+		return RevisionComparison{}, err
+	}
+	manifests, err := m.GetManifests(ctx, appName)
+	if err != nil {
+		return RevisionComparison{}, err
+	}
+	// Stamp each revision into the sample manifests so the diff isn't empty.
+	at := func(rev string) []string {
+		out := make([]string, 0, len(manifests))
+		for _, man := range manifests {
+			out = append(out, strings.Replace(man, "spec: {}", "spec:\n  revision: "+rev, 1))
+		}
+		return out
+	}
+	return RevisionComparison{From: fromMeta, To: toMeta, FromManifests: at(from), ToManifests: at(to)}, nil
+}
+
 func (m *MockClient) ChartDetails(ctx context.Context, appName, revision string) (ChartMeta, error) {
-	_ = ctx
+	if err := m.simulate(ctx); err != nil {
+		return ChartMeta{}, err
+	}
 	_ = appName
 	return ChartMeta{Description: "demo chart for " + revision, Maintainers: []string{"team-platform"}, Home: "https://example.com/charts"}, nil
 }
 
 func (m *MockClient) GetSyncWindows(ctx context.Context, appName string) ([]SyncWindow, error) {
-	_ = ctx
-	_ = appName
-	return []SyncWindow{{Kind: "allow", Schedule: "* * * * *", Duration: "1h", Applications: []string{appName}, Namespaces: []string{"*"}}}, nil
+	if err := m.simulate(ctx); err != nil {
+		return nil, err
+	}
+	ws := []SyncWindow{{Kind: "allow", Schedule: "* * * * *", Duration: "1h", Applications: []string{appName}, Namespaces: []string{"*"}, Active: true}}
+	if appName == "payments-api" {
+		// A freeze that's always on, so the deny state can be seen in --mock.
+		ws = append(ws, SyncWindow{Kind: "deny", Schedule: "0 * * * *", Duration: "1h", Applications: []string{appName}, Active: true})
+	}
+	return ws, nil
 }
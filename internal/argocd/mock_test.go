@@ -0,0 +1,115 @@
+package argocd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMockClient_syncProgresses(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mock := NewMockClient()
+	mock.Now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if err := mock.SyncApplication(ctx, "web-frontend", SyncOptions{}); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	a, _ := mock.GetApplication(ctx, "web-frontend")
+	if a.OperationState == nil || a.OperationState.Phase != "Running" {
+		t.Fatalf("expected a running operation, got %+v", a.OperationState)
+	}
+
+	now = now.Add(mock.SyncDuration)
+	a, _ = mock.GetApplication(ctx, "web-frontend")
+	if a.OperationState == nil || a.OperationState.Phase != "Succeeded" || a.Sync != "Synced" {
+		t.Fatalf("expected the sync to finish, got %+v sync=%s", a.OperationState, a.Sync)
+	}
+}
+
+func TestMockClient_syncRecordsRevision(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mock := NewMockClient()
+	mock.Now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if err := mock.SyncApplication(ctx, "web-frontend", SyncOptions{Revision: "v1.2.3"}); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	a, _ := mock.GetApplication(ctx, "web-frontend")
+	if a.OperationState == nil || a.OperationState.Message != "syncing to v1.2.3" {
+		t.Fatalf("expected the pinned revision in the operation, got %+v", a.OperationState)
+	}
+	now = now.Add(mock.SyncDuration)
+	a, _ = mock.GetApplication(ctx, "web-frontend")
+	if n := len(a.History); n == 0 || a.History[n-1].Revision != "v1.2.3" {
+		t.Fatalf("expected the pinned revision in history, got %+v", a.History)
+	}
+
+	// Without a revision the app syncs to its target.
+	if err := mock.SyncApplication(ctx, "web-frontend", SyncOptions{}); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	now = now.Add(mock.SyncDuration)
+	a, _ = mock.GetApplication(ctx, "web-frontend")
+	if n := len(a.History); n == 0 || a.History[n-1].Revision != a.Revision {
+		t.Fatalf("expected the target revision %q in history, got %+v", a.Revision, a.History)
+	}
+}
+
+func TestMockClient_concurrentReadsDuringSync(t *testing.T) {
+	mock := NewMockClient()
+	mock.SyncDuration = 0
+	ctx := context.Background()
+
+	before, err := mock.GetApplication(ctx, "web-frontend")
+	if err != nil || len(before.Resources) == 0 {
+		t.Fatalf("expected an app with resources, got %+v err=%v", before, err)
+	}
+	statuses := make([]string, len(before.Resources))
+	for i, r := range before.Resources {
+		statuses[i] = r.Status
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				_ = mock.SyncApplication(ctx, "web-frontend", SyncOptions{})
+				_, _ = mock.ListApplications(ctx)
+				_, _ = mock.RefreshApplication(ctx, "web-frontend", true)
+				_, _ = mock.GetManifests(ctx, "web-frontend")
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Finished syncs replace the resources rather than mutating them under
+	// an earlier read.
+	for i, r := range before.Resources {
+		if r.Status != statuses[i] {
+			t.Fatalf("resource %s changed under an earlier read: %s -> %s", r.Name, statuses[i], r.Status)
+		}
+	}
+}
+
+func TestMockClient_faultInjection(t *testing.T) {
+	failing := NewMockClient(WithErrorRate(1))
+	_, err := failing.ListApplications(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != 503 {
+		t.Fatalf("expected an injected 503, got %v", err)
+	}
+
+	slow := NewMockClient(WithLatency(time.Hour))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := slow.ListApplications(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected latency to honor cancellation, got %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package argocd
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// A minimal protobuf wire-format codec for the handful of Argo CD messages
+// the gRPC-web client exchanges. Field numbers follow Argo CD's
+// generated.proto; unknown fields are skipped.
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errProtoTruncated = errors.New("protobuf: truncated message")
+
+// protoEncoder appends fields to a message.
+type protoEncoder []byte
+
+func (e *protoEncoder) tag(field, wire int) {
+	*e = binary.AppendUvarint(*e, uint64(field)<<3|uint64(wire))
+}
+
+func (e *protoEncoder) string(field int, s string) {
+	if s == "" {
+		return
+	}
+	e.tag(field, wireBytes)
+	*e = binary.AppendUvarint(*e, uint64(len(s)))
+	*e = append(*e, s...)
+}
+
+func (e *protoEncoder) bool(field int, v bool) {
+	if !v {
+		return
+	}
+	e.tag(field, wireVarint)
+	*e = append(*e, 1)
+}
+
+func (e *protoEncoder) message(field int, m protoEncoder) {
+	e.tag(field, wireBytes)
+	*e = binary.AppendUvarint(*e, uint64(len(m)))
+	*e = append(*e, m...)
+}
+
+// protoField is one decoded field: n for varints, b for length-delimited
+// values (strings, bytes and nested messages).
+type protoField struct {
+	num int
+	n   uint64
+	b   []byte
+}
+
+// protoMessage is a decoded message, fields in wire order.
+type protoMessage []protoField
+
+func decodeProto(b []byte) (protoMessage, error) {
+	var msg protoMessage
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, errProtoTruncated
+		}
+		b = b[n:]
+		f := protoField{num: int(key >> 3)}
+		switch key & 7 {
+		case wireVarint:
+			f.n, n = binary.Uvarint(b)
+			if n <= 0 {
+				return nil, errProtoTruncated
+			}
+			b = b[n:]
+		case wireBytes:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < l {
+				return nil, errProtoTruncated
+			}
+			f.b = b[n : n+int(l)]
+			b = b[n+int(l):]
+		case wireFixed64:
+			if len(b) < 8 {
+				return nil, errProtoTruncated
+			}
+			b = b[8:]
+		case wireFixed32:
+			if len(b) < 4 {
+				return nil, errProtoTruncated
+			}
+			b = b[4:]
+		default:
+			return nil, errors.New("protobuf: unsupported wire type")
+		}
+		msg = append(msg, f)
+	}
+	return msg, nil
+}
+
+func (m protoMessage) string(num int) string {
+	var s string
+	for _, f := range m {
+		if f.num == num {
+			s = string(f.b) // the last occurrence wins
+		}
+	}
+	return s
+}
+
+func (m protoMessage) uint(num int) uint64 {
+	var v uint64
+	for _, f := range m {
+		if f.num == num {
+			v = f.n
+		}
+	}
+	return v
+}
+
+func (m protoMessage) bool(num int) bool { return m.uint(num) != 0 }
+
+// has reports whether the field is present, e.g. an empty nested message.
+func (m protoMessage) has(num int) bool {
+	for _, f := range m {
+		if f.num == num {
+			return true
+		}
+	}
+	return false
+}
+
+// message decodes a nested message; a missing or malformed one is empty.
+func (m protoMessage) message(num int) protoMessage {
+	var b []byte
+	for _, f := range m {
+		if f.num == num {
+			b = f.b
+		}
+	}
+	sub, _ := decodeProto(b)
+	return sub
+}
+
+func (m protoMessage) repeated(num int) []protoMessage {
+	var out []protoMessage
+	for _, f := range m {
+		if f.num != num {
+			continue
+		}
+		if sub, err := decodeProto(f.b); err == nil {
+			out = append(out, sub)
+		}
+	}
+	return out
+}
+
+// time decodes a Kubernetes meta/v1 Time (seconds, nanos) as RFC3339, or
+// "" when absent.
+func (m protoMessage) time(num int) string {
+	if !m.has(num) {
+		return ""
+	}
+	t := m.message(num)
+	return time.Unix(int64(t.uint(1)), int64(t.uint(2))).UTC().Format(time.RFC3339)
+}
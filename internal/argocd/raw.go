@@ -0,0 +1,104 @@
+package argocd
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// rawApplication is the subset of an Application manifest lazyargo understands.
+type rawApplication struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace,omitempty"`
+	} `json:"metadata"`
+	Spec struct {
+		Project string `json:"project"`
+		Source  struct {
+			RepoURL        string `json:"repoURL"`
+			Path           string `json:"path"`
+			TargetRevision string `json:"targetRevision"`
+		} `json:"source"`
+		Destination struct {
+			Server    string `json:"server,omitempty"`
+			Name      string `json:"name,omitempty"`
+			Namespace string `json:"namespace"`
+		} `json:"destination"`
+		SyncPolicy *rawSyncPolicy `json:"syncPolicy,omitempty"`
+	} `json:"spec"`
+}
+
+type rawSyncPolicy struct {
+	Automated *struct{} `json:"automated,omitempty"`
+}
+
+// ApplicationYAML renders the fields lazyargo tracks as an Application
+// manifest; ParseApplicationYAML reads it back. A qualified name ("ns/name")
+// is written as metadata.namespace and metadata.name.
+func ApplicationYAML(app Application) (string, error) {
+	var raw rawApplication
+	raw.APIVersion = "argoproj.io/v1alpha1"
+	raw.Kind = "Application"
+	ns, name := SplitAppName(app.Name)
+	if ns == "" {
+		ns = app.AppObjectNamespace
+	}
+	raw.Metadata.Name = name
+	raw.Metadata.Namespace = ns
+	raw.Spec.Project = app.Project
+	raw.Spec.Source.RepoURL = app.RepoURL
+	raw.Spec.Source.Path = app.Path
+	raw.Spec.Source.TargetRevision = app.Revision
+	raw.Spec.Destination.Server = app.Cluster
+	raw.Spec.Destination.Namespace = app.Namespace
+	if strings.EqualFold(app.SyncPolicy, "auto") {
+		raw.Spec.SyncPolicy = &rawSyncPolicy{Automated: &struct{}{}}
+	}
+	b, err := yaml.Marshal(raw)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ParseApplicationYAML validates a raw Application manifest and returns the
+// fields lazyargo tracks. It only checks what the server can't do without:
+// valid YAML, kind Application (if set), and a name.
+func ParseApplicationYAML(s string) (Application, error) {
+	if strings.TrimSpace(s) == "" {
+		return Application{}, fmt.Errorf("empty manifest")
+	}
+	var raw rawApplication
+	if err := yaml.Unmarshal([]byte(s), &raw); err != nil {
+		return Application{}, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if raw.Kind != "" && raw.Kind != "Application" {
+		return Application{}, fmt.Errorf("kind must be Application, got %q", raw.Kind)
+	}
+	if strings.TrimSpace(raw.Metadata.Name) == "" {
+		return Application{}, fmt.Errorf("metadata.name is required")
+	}
+
+	app := Application{
+		Name:               raw.Metadata.Name,
+		AppObjectNamespace: raw.Metadata.Namespace,
+		Project:            raw.Spec.Project,
+		RepoURL:            raw.Spec.Source.RepoURL,
+		Path:               raw.Spec.Source.Path,
+		Revision:           raw.Spec.Source.TargetRevision,
+		Cluster:            raw.Spec.Destination.Server,
+		Namespace:          raw.Spec.Destination.Namespace,
+	}
+	if app.Cluster == "" {
+		app.Cluster = raw.Spec.Destination.Name
+	}
+	if raw.Spec.SyncPolicy != nil && raw.Spec.SyncPolicy.Automated != nil {
+		app.SyncPolicy = "auto"
+	} else {
+		app.SyncPolicy = "manual"
+	}
+	return app, nil
+}
@@ -0,0 +1,30 @@
+package argocd
+
+import "testing"
+
+func TestApplicationYAML_roundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		app    Application
+		wantNS string
+	}{
+		{name: "plain name", app: Application{Name: "guestbook", Project: "default", SyncPolicy: "manual"}},
+		{name: "qualified name", app: Application{Name: "team-a/guestbook", Project: "default", SyncPolicy: "auto"}, wantNS: "team-a"},
+		{name: "app namespace", app: Application{Name: "guestbook", AppObjectNamespace: "argocd", Project: "default", SyncPolicy: "manual"}, wantNS: "argocd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest, err := ApplicationYAML(tt.app)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ParseApplicationYAML(manifest)
+			if err != nil {
+				t.Fatalf("parse:\n%s\n%v", manifest, err)
+			}
+			if got.Name != "guestbook" || got.AppObjectNamespace != tt.wantNS || got.SyncPolicy != tt.app.SyncPolicy {
+				t.Fatalf("round trip = %+v from\n%s", got, manifest)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package argocd
+
+import (
+	"sync"
+	"time"
+)
+
+// RequestRecord is one API call, as kept by a RequestLog.
+type RequestRecord struct {
+	Time     time.Time
+	Method   string
+	Path     string
+	Status   int // 0 when the request failed before a response
+	Duration time.Duration
+	Err      string
+}
+
+// RequestLog is a fixed-size ring buffer of the most recent API calls. It is
+// safe for concurrent use; commands run requests from several goroutines.
+type RequestLog struct {
+	mu   sync.Mutex
+	buf  []RequestRecord
+	next int
+	full bool
+}
+
+// NewRequestLog keeps the last n requests.
+func NewRequestLog(n int) *RequestLog {
+	return &RequestLog{buf: make([]RequestRecord, max(1, n))}
+}
+
+func (l *RequestLog) add(r RequestRecord) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buf[l.next] = r
+	l.next = (l.next + 1) % len(l.buf)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// Recent returns the kept requests, newest first.
+func (l *RequestLog) Recent() []RequestRecord {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := l.next
+	if l.full {
+		n = len(l.buf)
+	}
+	out := make([]RequestRecord, 0, n)
+	for i := 1; i <= n; i++ {
+		out = append(out, l.buf[(l.next-i+len(l.buf))%len(l.buf)])
+	}
+	return out
+}
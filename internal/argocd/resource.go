@@ -17,6 +17,8 @@ type DiffResult struct {
 }
 
 type Event struct {
+	// App is set when events from several applications are merged.
+	App            string
 	Type           string
 	Reason         string
 	Message        string
@@ -32,12 +34,21 @@ type SyncHistoryEntry struct {
 	Source     string
 }
 
+// SyncWindow is a sync window assigned to an application; see
+// syncwindow.go for evaluating it.
 type SyncWindow struct {
 	Kind         string
 	Schedule     string
 	Duration     string
 	Applications []string
 	Namespaces   []string
+	// ManualSync lets manual syncs through a deny window (or outside the
+	// allow windows).
+	ManualSync bool
+	// TimeZone is the schedule's IANA zone; empty means UTC.
+	TimeZone string
+	// Active is set when the window was open as of the request.
+	Active bool
 }
 
 type AppCondition struct {
@@ -52,6 +63,19 @@ type RevisionMeta struct {
 	Message string
 }
 
+// RevisionComparison is what changed between two revisions of an
+// application's source.
+type RevisionComparison struct {
+	From RevisionMeta
+	To   RevisionMeta
+	// FromManifests and ToManifests are the desired manifests rendered at
+	// each revision. They are nil, with ManifestsErr set, when the server
+	// can't render a revision.
+	FromManifests []string
+	ToManifests   []string
+	ManifestsErr  string
+}
+
 type ChartMeta struct {
 	Description string
 	Maintainers []string
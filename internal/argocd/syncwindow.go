@@ -0,0 +1,146 @@
+package argocd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// syncWindowMaxLookback bounds the minute-by-minute search for a window's
+// latest start in End.
+const syncWindowMaxLookback = 7 * 24 * time.Hour
+
+// ManualSyncAllowed reports whether a manual sync may run now given an app's
+// windows, following Argo CD: an active deny window blocks unless it allows
+// manual syncs; otherwise, when allow windows exist, one must be active (or
+// allow manual syncs).
+func ManualSyncAllowed(ws []SyncWindow) bool {
+	denied, denyManual := false, true
+	hasAllow, allowed := false, false
+	for _, w := range ws {
+		switch strings.ToLower(w.Kind) {
+		case "deny":
+			if w.Active {
+				denied = true
+				denyManual = denyManual && w.ManualSync
+			}
+		case "allow":
+			hasAllow = true
+			if w.Active || w.ManualSync {
+				allowed = true
+			}
+		}
+	}
+	if denied {
+		return denyManual
+	}
+	return !hasAllow || allowed
+}
+
+// End returns when the window, open at now, closes: its schedule's latest
+// firing within the last Duration, plus Duration. ok is false when the
+// window isn't open at now or its schedule or duration can't be parsed.
+func (w SyncWindow) End(now time.Time) (end time.Time, ok bool) {
+	d, err := time.ParseDuration(w.Duration)
+	if err != nil || d <= 0 {
+		return time.Time{}, false
+	}
+	sched, ok := parseCron(w.Schedule)
+	if !ok {
+		return time.Time{}, false
+	}
+	loc := time.UTC
+	if w.TimeZone != "" {
+		if l, err := time.LoadLocation(w.TimeZone); err == nil {
+			loc = l
+		}
+	}
+	now = now.In(loc)
+	for t := now.Truncate(time.Minute); now.Sub(t) < min(d, syncWindowMaxLookback); t = t.Add(-time.Minute) {
+		if sched.matches(t) {
+			return t.Add(d), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// cronSchedule is a parsed five-field cron expression: minute, hour, day of
+// month, month and day of week, each the set of values it matches.
+type cronSchedule struct {
+	fields [5]map[int]bool
+	// A restricted day of month and day of week match either, as in cron.
+	anyDOM, anyDOW bool
+}
+
+var cronRanges = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+func parseCron(s string) (cronSchedule, bool) {
+	parts := strings.Fields(s)
+	if len(parts) != 5 {
+		return cronSchedule{}, false
+	}
+	var c cronSchedule
+	for i, p := range parts {
+		set, ok := parseCronField(p, cronRanges[i][0], cronRanges[i][1])
+		if !ok {
+			return cronSchedule{}, false
+		}
+		c.fields[i] = set
+	}
+	if c.fields[4][7] {
+		c.fields[4][0] = true // 7 is Sunday too
+	}
+	c.anyDOM, c.anyDOW = parts[2] == "*", parts[4] == "*"
+	return c, true
+}
+
+// parseCronField parses a comma list of "*", "n" or "a-b", each optionally
+// with a "/step".
+func parseCronField(s string, lo, hi int) (map[int]bool, bool) {
+	set := map[int]bool{}
+	for _, part := range strings.Split(s, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return nil, false
+			}
+			step = n
+		}
+		from, to := lo, hi
+		if rng != "*" {
+			a, b, isRange := strings.Cut(rng, "-")
+			var err error
+			if from, err = strconv.Atoi(a); err != nil {
+				return nil, false
+			}
+			to = from
+			if isRange {
+				if to, err = strconv.Atoi(b); err != nil {
+					return nil, false
+				}
+			} else if hasStep {
+				to = hi
+			}
+		}
+		if from < lo || to > hi || from > to {
+			return nil, false
+		}
+		for v := from; v <= to; v += step {
+			set[v] = true
+		}
+	}
+	return set, true
+}
+
+func (c cronSchedule) matches(t time.Time) bool {
+	if !c.fields[0][t.Minute()] || !c.fields[1][t.Hour()] || !c.fields[3][int(t.Month())] {
+		return false
+	}
+	dom, dow := c.fields[2][t.Day()], c.fields[4][int(t.Weekday())]
+	if c.anyDOM || c.anyDOW {
+		return dom && dow
+	}
+	return dom || dow
+}
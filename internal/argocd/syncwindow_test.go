@@ -0,0 +1,54 @@
+package argocd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSyncWindow_End(t *testing.T) {
+	now := time.Date(2024, 5, 6, 14, 30, 0, 0, time.UTC) // a Monday
+	for _, tt := range []struct {
+		name   string
+		w      SyncWindow
+		want   string
+		wantOK bool
+	}{
+		{"hourly", SyncWindow{Schedule: "0 * * * *", Duration: "1h"}, "15:00", true},
+		{"nightly freeze still open", SyncWindow{Schedule: "0 22 * * *", Duration: "17h"}, "15:00", true},
+		{"weekdays at 9 for 8h", SyncWindow{Schedule: "0 9 * * 1-5", Duration: "8h"}, "17:00", true},
+		{"weekends only", SyncWindow{Schedule: "0 9 * * 0,6", Duration: "8h"}, "", false},
+		{"every 20 minutes", SyncWindow{Schedule: "*/20 * * * *", Duration: "15m"}, "14:35", true},
+		{"in another zone", SyncWindow{Schedule: "0 16 * * *", Duration: "1h", TimeZone: "Europe/Berlin"}, "15:00", true},
+		{"bad schedule", SyncWindow{Schedule: "0 25 * * *", Duration: "1h"}, "", false},
+		{"bad duration", SyncWindow{Schedule: "* * * * *", Duration: "soon"}, "", false},
+	} {
+		end, ok := tt.w.End(now)
+		if ok != tt.wantOK || (ok && end.UTC().Format("15:04") != tt.want) {
+			t.Errorf("%s: End = %v, %v; want %s, %v", tt.name, end.UTC(), ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestManualSyncAllowed(t *testing.T) {
+	allow := SyncWindow{Kind: "allow"}
+	deny := SyncWindow{Kind: "deny"}
+	on := func(w SyncWindow) SyncWindow { w.Active = true; return w }
+	manual := func(w SyncWindow) SyncWindow { w.ManualSync = true; return w }
+	for _, tt := range []struct {
+		name string
+		ws   []SyncWindow
+		want bool
+	}{
+		{"no windows", nil, true},
+		{"inside an allow window", []SyncWindow{on(allow)}, true},
+		{"outside the allow windows", []SyncWindow{allow}, false},
+		{"outside, manual sync allowed", []SyncWindow{manual(allow)}, true},
+		{"active deny", []SyncWindow{on(allow), on(deny)}, false},
+		{"active deny, manual sync allowed", []SyncWindow{on(manual(deny))}, true},
+		{"inactive deny", []SyncWindow{deny}, true},
+	} {
+		if got := ManualSyncAllowed(tt.ws); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
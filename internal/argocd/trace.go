@@ -0,0 +1,124 @@
+package argocd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// traceBodyLimit caps each request and response body kept in a trace.
+const traceBodyLimit = 4096
+
+// TraceEntry is one line of a request trace.
+type TraceEntry struct {
+	Time         time.Time `json:"time"`
+	Method       string    `json:"method"`
+	URL          string    `json:"url"`
+	Status       int       `json:"status,omitempty"`
+	DurationMS   int64     `json:"durationMs"`
+	RequestBody  string    `json:"requestBody,omitempty"`
+	ResponseBody string    `json:"responseBody,omitempty"`
+	Err          string    `json:"error,omitempty"`
+}
+
+// Tracer writes every API request with its response as JSON lines, for bug
+// reports (--trace). Headers are left out, so the Authorization header never
+// reaches the file, and credential fields in bodies are redacted. It is safe
+// for concurrent use; a nil Tracer records nothing.
+type Tracer struct {
+	mu sync.Mutex
+	w  io.Writer
+	c  io.Closer
+}
+
+// NewTracer traces to w.
+func NewTracer(w io.Writer) *Tracer {
+	return &Tracer{w: w}
+}
+
+// OpenTraceFile truncates or creates path and traces to it; Close closes it.
+func OpenTraceFile(path string) (*Tracer, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &Tracer{w: f, c: f}, nil
+}
+
+// Close closes the file opened by OpenTraceFile.
+func (t *Tracer) Close() error {
+	if t == nil || t.c == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.c.Close()
+}
+
+func (t *Tracer) record(e TraceEntry) {
+	if t == nil {
+		return
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// A failing trace must not fail the request it describes.
+	_, _ = t.w.Write(append(b, '\n'))
+}
+
+// traceBody renders a body for the trace: credential fields of JSON bodies
+// are redacted, and the result is cut at traceBodyLimit.
+func traceBody(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err == nil && redactSecrets(v) {
+		if r, err := json.Marshal(v); err == nil {
+			b = r
+		}
+	}
+	if len(b) > traceBodyLimit {
+		return fmt.Sprintf("%s…(%d bytes)", b[:traceBodyLimit], len(b))
+	}
+	return string(b)
+}
+
+// redactSecrets replaces the values of credential-looking keys (password,
+// token, secret, private key) anywhere in v and reports whether it did.
+func redactSecrets(v any) bool {
+	redacted := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if isSecretKey(k) {
+				v[k] = "REDACTED"
+				redacted = true
+				continue
+			}
+			redacted = redactSecrets(child) || redacted
+		}
+	case []any:
+		for _, child := range v {
+			redacted = redactSecrets(child) || redacted
+		}
+	}
+	return redacted
+}
+
+func isSecretKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, s := range []string{"password", "token", "secret", "privatekey", "authorization"} {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ArgoCDContext is the current context of an argocd CLI config file
+// (argocd login writes it): enough to reach the same server as the CLI.
+type ArgoCDContext struct {
+	Name      string
+	Server    string
+	Token     string
+	Insecure  bool
+	PlainText bool
+	GRPCWeb   bool
+}
+
+// argocdCLIConfig is the subset of the argocd CLI's config file we read.
+type argocdCLIConfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Contexts       []struct {
+		Name   string `yaml:"name"`
+		Server string `yaml:"server"`
+		User   string `yaml:"user"`
+	} `yaml:"contexts"`
+	Servers []struct {
+		Server          string `yaml:"server"`
+		Insecure        bool   `yaml:"insecure"`
+		PlainText       bool   `yaml:"plain-text"`
+		GRPCWeb         bool   `yaml:"grpc-web"`
+		GRPCWebRootPath string `yaml:"grpc-web-root-path"`
+	} `yaml:"servers"`
+	Users []struct {
+		Name      string `yaml:"name"`
+		AuthToken string `yaml:"auth-token"`
+	} `yaml:"users"`
+}
+
+// DefaultArgoCDConfigPath is where the argocd CLI keeps its config:
+// ARGOCD_CONFIG_DIR if set, else ~/.config/argocd/config, falling back to
+// the legacy ~/.argocd/config when only that exists.
+func DefaultArgoCDConfigPath() (string, error) {
+	if dir := os.Getenv("ARGOCD_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, "config"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("home dir: %w", err)
+	}
+	path := filepath.Join(home, ".config", "argocd", "config")
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		legacy := filepath.Join(home, ".argocd", "config")
+		if _, err := os.Stat(legacy); err == nil {
+			return legacy, nil
+		}
+	}
+	return path, nil
+}
+
+// LoadArgoCDContext reads the current context of the argocd CLI config at
+// path. The server comes back as a URL (http:// for plain-text servers).
+func LoadArgoCDContext(path string) (ArgoCDContext, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return ArgoCDContext{}, fmt.Errorf("argocd config: %w", err)
+	}
+	var f argocdCLIConfig
+	if err := yaml.Unmarshal(b, &f); err != nil {
+		return ArgoCDContext{}, fmt.Errorf("parse argocd config %q: %w", path, err)
+	}
+	if f.CurrentContext == "" {
+		return ArgoCDContext{}, fmt.Errorf("argocd config %q: no current context (run argocd login)", path)
+	}
+
+	ctx := ArgoCDContext{Name: f.CurrentContext}
+	user := ""
+	for _, c := range f.Contexts {
+		if c.Name == f.CurrentContext {
+			ctx.Server, user = c.Server, c.User
+		}
+	}
+	if ctx.Server == "" {
+		return ArgoCDContext{}, fmt.Errorf("argocd config %q: context %q has no server", path, f.CurrentContext)
+	}
+	rootPath := ""
+	for _, s := range f.Servers {
+		if s.Server == ctx.Server {
+			ctx.Insecure, ctx.PlainText, ctx.GRPCWeb = s.Insecure, s.PlainText, s.GRPCWeb
+			rootPath = strings.Trim(s.GRPCWebRootPath, "/")
+		}
+	}
+	for _, u := range f.Users {
+		if u.Name == user {
+			ctx.Token = u.AuthToken
+		}
+	}
+
+	// The CLI stores bare host:port; the scheme is implied by plain-text.
+	if !strings.Contains(ctx.Server, "://") {
+		scheme := "https://"
+		if ctx.PlainText {
+			scheme = "http://"
+		}
+		ctx.Server = scheme + ctx.Server
+	}
+	if rootPath != "" {
+		ctx.Server = strings.TrimRight(ctx.Server, "/") + "/" + rootPath
+	}
+	return ctx, nil
+}
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -18,18 +21,322 @@ type Config struct {
 		Server             string `yaml:"server"`
 		Token              string `yaml:"token"`
 		InsecureSkipVerify bool   `yaml:"insecureSkipVerify"`
+		// TokenFile holds the auth token (e.g. a mounted Kubernetes
+		// secret); it takes precedence over Token and ARGOCD_AUTH_TOKEN.
+		TokenFile string `yaml:"tokenFile"`
+		// Anonymous talks to instances with anonymous access enabled: no
+		// login and no Authorization header when no token is set.
+		Anonymous bool `yaml:"anonymous"`
+		// ClientCert and ClientKey are PEM file paths for servers behind a
+		// gateway that requires mutual TLS.
+		ClientCert string `yaml:"clientCert"`
+		ClientKey  string `yaml:"clientKey"`
+		// CACert is a PEM bundle of extra CAs to trust; the safer
+		// alternative to InsecureSkipVerify.
+		CACert string `yaml:"caCert"`
+		// Proxy is a fixed proxy URL for all API calls; it overrides the
+		// HTTP(S)_PROXY environment.
+		Proxy string `yaml:"proxy"`
+		// Transport selects the API protocol: "rest" (the default) or
+		// "grpc-web" for servers that only expose the gRPC-web gateway.
+		Transport string `yaml:"transport"`
+		// MaxIdleConnsPerHost, IdleConnTimeout and KeepAlive tune connection
+		// reuse; zero keeps lazyargo's defaults.
+		MaxIdleConnsPerHost int           `yaml:"maxIdleConnsPerHost"`
+		IdleConnTimeout     time.Duration `yaml:"idleConnTimeout"`
+		KeepAlive           time.Duration `yaml:"keepAlive"`
+		// RateLimitRetries is how often a 429 response is retried; zero
+		// keeps the default of 3 and a negative value turns retries off.
+		RateLimitRetries int `yaml:"rateLimitRetries"`
 	} `yaml:"argocd"`
 
 	UI struct {
 		SidebarWidth int `yaml:"sidebarWidth"`
+		// GroupLabel is the label key the sidebar can group by (e.g. "team");
+		// empty leaves only project grouping.
+		GroupLabel string `yaml:"groupLabel"`
+		// SidebarDriftCounts annotates sidebar items with their out-of-sync
+		// resource count. It makes the app list request include resources.
+		SidebarDriftCounts bool  `yaml:"sidebarDriftCounts"`
+		Theme              Theme `yaml:"theme"`
+		// NoColor disables all colors (also set by NO_COLOR or --no-color).
+		NoColor bool `yaml:"noColor"`
+		// RefreshInterval re-polls the application list periodically (e.g. "30s"); 0 disables it.
+		RefreshInterval time.Duration `yaml:"refreshInterval"`
+		// DetailDebounce is how long the selection must rest on an app before
+		// its details load, so scrolling past apps doesn't fetch each one.
+		DetailDebounce time.Duration `yaml:"detailDebounce"`
+		Alerts         Alerts        `yaml:"alerts"`
+		// StaleReconcileMinutes flags an app whose status.reconciledAt is older
+		// than this in the detail panel; 0 disables the hint.
+		StaleReconcileMinutes int `yaml:"staleReconcileMinutes"`
+		// Notifications sends a desktop notification when a watched sync finishes.
+		Notifications bool `yaml:"notifications"`
+		// ConfirmDestructive requires typing the app name, as delete does,
+		// before terminate and rollback run.
+		ConfirmDestructive bool `yaml:"confirmDestructive"`
 	} `yaml:"ui"`
 
+	// CreatePresets prefill the create wizard for apps of a standard shape.
+	CreatePresets []CreatePreset `yaml:"createPresets"`
+	// CustomActions are user commands bound to keys in the app list.
+	CustomActions []CustomAction `yaml:"customActions"`
+
 	LogLevel string `yaml:"logLevel"`
+	// LogFile receives structured logs while the TUI is running. When empty,
+	// logs are dropped during the session so they can't smear the screen.
+	LogFile string `yaml:"logFile"`
+	// StateFile is where UI state (e.g. the resized sidebar width) is kept.
+	// Load defaults it to state.yaml in the lazyargo config directory.
+	StateFile string `yaml:"stateFile"`
+	// Debug records recent API requests for the in-app debug overlay.
+	Debug bool `yaml:"debug"`
+}
+
+// Alerts controls what happens when auto-refresh sees an app regress to
+// Degraded or Missing.
+type Alerts struct {
+	Enabled bool `yaml:"enabled"`
+	// Bell rings the terminal bell in addition to the footer alert.
+	Bell bool `yaml:"bell"`
+}
+
+// ThemeNames are the built-in color presets selectable via ui.theme.name.
+// "auto" (or leaving the name empty) follows the terminal background.
+var ThemeNames = []string{"auto", "dark", "light"}
+
+// Theme selects a color preset and optional per-role overrides.
+// Colors are ANSI 256 codes ("57") or hex ("#5f00ff"); empty keeps the preset's color.
+type Theme struct {
+	Name     string    `yaml:"name"`
+	Header   ColorPair `yaml:"header"`
+	Selected ColorPair `yaml:"selected"`
+	Warn     string    `yaml:"warn"`
+	Error    string    `yaml:"error"`
+	Success  string    `yaml:"success"`
+	// Hook dims hook resources and secondary details in the resource tree.
+	Hook string `yaml:"hook"`
+	// Gutter is the manifest viewer's line-number gutter.
+	Gutter string       `yaml:"gutter"`
+	Syntax SyntaxColors `yaml:"syntax"`
+}
+
+// SyntaxColors color the tokens of manifests in the YAML/JSON viewer.
+type SyntaxColors struct {
+	Key     string `yaml:"key"`
+	String  string `yaml:"string"`
+	Number  string `yaml:"number"`
+	Bool    string `yaml:"bool"`
+	Comment string `yaml:"comment"`
+	Punct   string `yaml:"punct"`
+}
+
+// ColorPair is a foreground/background color pair.
+type ColorPair struct {
+	FG string `yaml:"fg"`
+	BG string `yaml:"bg"`
+}
+
+// Validate checks the preset name and every color override.
+func (t Theme) Validate() error {
+	if t.Name != "" {
+		known := false
+		for _, n := range ThemeNames {
+			if t.Name == n {
+				known = true
+			}
+		}
+		if !known {
+			return fmt.Errorf("ui.theme.name: unknown theme %q (want one of %s)", t.Name, strings.Join(ThemeNames, ", "))
+		}
+	}
+	colors := []struct{ field, v string }{
+		{"header.fg", t.Header.FG},
+		{"header.bg", t.Header.BG},
+		{"selected.fg", t.Selected.FG},
+		{"selected.bg", t.Selected.BG},
+		{"warn", t.Warn},
+		{"error", t.Error},
+		{"success", t.Success},
+		{"hook", t.Hook},
+		{"gutter", t.Gutter},
+		{"syntax.key", t.Syntax.Key},
+		{"syntax.string", t.Syntax.String},
+		{"syntax.number", t.Syntax.Number},
+		{"syntax.bool", t.Syntax.Bool},
+		{"syntax.comment", t.Syntax.Comment},
+		{"syntax.punct", t.Syntax.Punct},
+	}
+	for _, c := range colors {
+		if err := validateColor(c.v); err != nil {
+			return fmt.Errorf("ui.theme.%s: %w", c.field, err)
+		}
+	}
+	return nil
+}
+
+// validateColor accepts "", an ANSI 256 color code (0-255), or #rgb / #rrggbb.
+func validateColor(s string) error {
+	if s == "" {
+		return nil
+	}
+	if strings.HasPrefix(s, "#") {
+		hex := s[1:]
+		if len(hex) != 3 && len(hex) != 6 {
+			return fmt.Errorf("invalid hex color %q", s)
+		}
+		if _, err := strconv.ParseUint(hex, 16, 32); err != nil {
+			return fmt.Errorf("invalid hex color %q", s)
+		}
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > 255 {
+		return fmt.Errorf("invalid color %q (want 0-255 or #rrggbb)", s)
+	}
+	return nil
+}
+
+// Transports are the accepted argocd.transport values; "" means "rest".
+var Transports = []string{"rest", "grpc-web"}
+
+// ValidateTransport checks an argocd.transport value.
+func ValidateTransport(s string) error {
+	if s == "" {
+		return nil
+	}
+	for _, t := range Transports {
+		if s == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("argocd.transport: unknown transport %q (want one of %s)", s, strings.Join(Transports, ", "))
+}
+
+// CreatePreset is a named set of create wizard defaults. Empty fields are
+// left for the wizard to ask; every field can still be changed there.
+type CreatePreset struct {
+	Name       string `yaml:"name"`
+	Project    string `yaml:"project"`
+	Cluster    string `yaml:"cluster"`
+	Namespace  string `yaml:"namespace"`
+	SyncPolicy string `yaml:"syncPolicy"`
+	Revision   string `yaml:"revision"`
+}
+
+// ValidateCreatePresets checks that presets are named uniquely and use a
+// known sync policy.
+func ValidateCreatePresets(ps []CreatePreset) error {
+	seen := map[string]bool{}
+	for i, p := range ps {
+		if strings.TrimSpace(p.Name) == "" {
+			return fmt.Errorf("createPresets[%d]: name is required", i)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("createPresets[%d]: duplicate name %q", i, p.Name)
+		}
+		seen[p.Name] = true
+		switch p.SyncPolicy {
+		case "", "manual", "auto":
+		default:
+			return fmt.Errorf("createPresets[%d] (%s): unknown syncPolicy %q (want manual or auto)", i, p.Name, p.SyncPolicy)
+		}
+	}
+	return nil
+}
+
+// CustomAction is a shell command run against the selected app. Command is a
+// text/template over CustomActionVars, e.g. "open {{.Server}}/applications/{{.Name}}".
+// Every value is shell-quoted before it is substituted: app fields come from
+// the server, and a name or path must not be able to run commands.
+type CustomAction struct {
+	Key     string `yaml:"key"`
+	Name    string `yaml:"name"`
+	Command string `yaml:"command"`
+}
+
+// CustomActionVars are the fields a custom action's command can use.
+// Namespace is the destination namespace; AppNamespace is where the
+// Application object lives.
+type CustomActionVars struct {
+	Name         string
+	Namespace    string
+	AppNamespace string
+	Project      string
+	Cluster      string
+	RepoURL      string
+	Path         string
+	Revision     string
+	Server       string
+}
+
+// customActionFuncs are the template functions commands can use.
+var customActionFuncs = template.FuncMap{
+	// quote is kept for configs written when values weren't quoted; they
+	// are now, so it returns its argument.
+	"quote": func(s string) string { return s },
+}
+
+// shellQuote single-quotes s for sh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Render expands the command template for one app, with each value
+// shell-quoted.
+func (a CustomAction) Render(v CustomActionVars) (string, error) {
+	v = CustomActionVars{
+		Name:         shellQuote(v.Name),
+		Namespace:    shellQuote(v.Namespace),
+		AppNamespace: shellQuote(v.AppNamespace),
+		Project:      shellQuote(v.Project),
+		Cluster:      shellQuote(v.Cluster),
+		RepoURL:      shellQuote(v.RepoURL),
+		Path:         shellQuote(v.Path),
+		Revision:     shellQuote(v.Revision),
+		Server:       shellQuote(v.Server),
+	}
+	t, err := template.New(a.Name).Funcs(customActionFuncs).Option("missingkey=error").Parse(a.Command)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, v); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// ValidateCustomActions checks that actions are complete, use distinct keys
+// and have commands that render (so a typo like {{.Nmae}} fails at load).
+// Collisions with built-in keys are checked by the UI.
+func ValidateCustomActions(as []CustomAction) error {
+	seen := map[string]bool{}
+	for i, a := range as {
+		switch {
+		case strings.TrimSpace(a.Name) == "":
+			return fmt.Errorf("customActions[%d]: name is required", i)
+		case strings.TrimSpace(a.Key) == "":
+			return fmt.Errorf("customActions[%d] (%s): key is required", i, a.Name)
+		case strings.TrimSpace(a.Command) == "":
+			return fmt.Errorf("customActions[%d] (%s): command is required", i, a.Name)
+		case seen[a.Key]:
+			return fmt.Errorf("customActions[%d] (%s): key %q is used by another action", i, a.Name, a.Key)
+		}
+		seen[a.Key] = true
+		if _, err := a.Render(CustomActionVars{}); err != nil {
+			return fmt.Errorf("customActions[%d] (%s): %w", i, a.Name, err)
+		}
+	}
+	return nil
 }
 
 func Default() Config {
 	var c Config
 	c.UI.SidebarWidth = 28
+	c.UI.DetailDebounce = 150 * time.Millisecond
+	c.UI.Alerts.Enabled = true
+	c.UI.StaleReconcileMinutes = 30
 	c.LogLevel = "info"
 
 	// Common defaults so a port-forward (or local argocd-server) works with minimal config.
@@ -86,6 +393,19 @@ func Load(path string) (Config, error) {
 			return Config{}, fmt.Errorf("parse config %q: %w", path, err)
 		}
 
+		if err := overlay.UI.Theme.Validate(); err != nil {
+			return Config{}, fmt.Errorf("config %q: %w", path, err)
+		}
+		if err := ValidateTransport(overlay.ArgoCD.Transport); err != nil {
+			return Config{}, fmt.Errorf("config %q: %w", path, err)
+		}
+		if err := ValidateCreatePresets(overlay.CreatePresets); err != nil {
+			return Config{}, fmt.Errorf("config %q: %w", path, err)
+		}
+		if err := ValidateCustomActions(overlay.CustomActions); err != nil {
+			return Config{}, fmt.Errorf("config %q: %w", path, err)
+		}
+
 		c = overlay
 	}
 
@@ -100,9 +420,50 @@ func Load(path string) (Config, error) {
 		// Matches argocd CLI: ARGOCD_INSECURE=true
 		c.ArgoCD.InsecureSkipVerify = parseBoolish(v)
 	}
+	if v := os.Getenv("NO_COLOR"); v != "" {
+		// https://no-color.org: any non-empty value disables color.
+		c.UI.NoColor = true
+	}
 	if v := os.Getenv("LAZYARGO_LOG_LEVEL"); v != "" {
 		c.LogLevel = v
 	}
+	if v := os.Getenv("LAZYARGO_LOG_FILE"); v != "" {
+		c.LogFile = v
+	}
+	if v := os.Getenv("LAZYARGO_STATE_FILE"); v != "" {
+		c.StateFile = v
+	}
+	if c.StateFile == "" {
+		if p, err := defaultStatePath(); err == nil {
+			c.StateFile = p
+		}
+	}
 
 	return c, nil
 }
+
+// ReadTokenFile reads an auth token file, trimming surrounding whitespace
+// and the trailing newline editors and secret mounts tend to leave.
+func ReadTokenFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("token file: %w", err)
+	}
+	tok := strings.TrimSpace(string(b))
+	if tok == "" {
+		return "", fmt.Errorf("token file %q is empty", path)
+	}
+	return tok, nil
+}
+
+// ResolveToken picks the auth token: an explicit one (--token) first, then
+// argocd.tokenFile, then the env/config token already in c.
+func ResolveToken(explicit string, c Config) (string, error) {
+	if explicit != "" {
+		return explicit, nil
+	}
+	if c.ArgoCD.TokenFile != "" {
+		return ReadTokenFile(c.ArgoCD.TokenFile)
+	}
+	return c.ArgoCD.Token, nil
+}
@@ -0,0 +1,224 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestThemeValidate(t *testing.T) {
+	ok := []Theme{
+		{},
+		{Name: "light"},
+		{Name: "dark", Header: ColorPair{FG: "229", BG: "#5f00ff"}, Warn: "#f80"},
+		{Hook: "244", Gutter: "#888", Syntax: SyntaxColors{Key: "25", Comment: "#808080"}},
+	}
+	for _, th := range ok {
+		if err := th.Validate(); err != nil {
+			t.Fatalf("expected %+v to be valid, got %v", th, err)
+		}
+	}
+
+	bad := []Theme{
+		{Name: "solarized"},
+		{Selected: ColorPair{BG: "256"}},
+		{Error: "red"},
+		{Success: "#12345"},
+		{Gutter: "grey"},
+		{Syntax: SyntaxColors{String: "300"}},
+	}
+	for _, th := range bad {
+		if err := th.Validate(); err == nil {
+			t.Fatalf("expected %+v to be rejected", th)
+		}
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lazyargo", "state.yaml")
+	if s, err := LoadState(path); err != nil || !reflect.DeepEqual(s, State{}) {
+		t.Fatalf("expected zero state for a missing file, got %+v, %v", s, err)
+	}
+	if err := SaveState(path, State{SidebarWidth: 42, Pinned: []string{"billing", "web"}}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	s, err := LoadState(path)
+	if err != nil || s.SidebarWidth != 42 || !reflect.DeepEqual(s.Pinned, []string{"billing", "web"}) {
+		t.Fatalf("expected sidebar width 42 and two pins, got %+v, %v", s, err)
+	}
+}
+
+func TestLoad_createPresets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yml := "createPresets:\n  - name: prod-service\n    project: services\n    syncPolicy: auto\n    revision: release\n"
+	if err := os.WriteFile(path, []byte(yml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	want := []CreatePreset{{Name: "prod-service", Project: "services", SyncPolicy: "auto", Revision: "release"}}
+	if !reflect.DeepEqual(c.CreatePresets, want) {
+		t.Fatalf("presets = %+v", c.CreatePresets)
+	}
+
+	bad := [][]CreatePreset{
+		{{Project: "services"}},
+		{{Name: "a"}, {Name: "a"}},
+		{{Name: "a", SyncPolicy: "automatic"}},
+	}
+	for _, ps := range bad {
+		if err := ValidateCreatePresets(ps); err == nil {
+			t.Errorf("expected %+v to be rejected", ps)
+		}
+	}
+}
+
+func TestLoad_noColorEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("ui:\n  sidebarWidth: 30\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("NO_COLOR", "")
+	c, err := Load(path)
+	if err != nil || c.UI.NoColor {
+		t.Fatalf("expected colors without NO_COLOR, got %v (err %v)", c.UI.NoColor, err)
+	}
+	t.Setenv("NO_COLOR", "1")
+	c, err = Load(path)
+	if err != nil || !c.UI.NoColor {
+		t.Fatalf("expected NO_COLOR to disable colors, got %v (err %v)", c.UI.NoColor, err)
+	}
+}
+
+func TestLoad_logFileEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("logFile: /tmp/from-config.log\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := Load(path)
+	if err != nil || c.LogFile != "/tmp/from-config.log" {
+		t.Fatalf("expected logFile from the config, got %q (err %v)", c.LogFile, err)
+	}
+	t.Setenv("LAZYARGO_LOG_FILE", "/tmp/from-env.log")
+	c, err = Load(path)
+	if err != nil || c.LogFile != "/tmp/from-env.log" {
+		t.Fatalf("expected LAZYARGO_LOG_FILE to win, got %q (err %v)", c.LogFile, err)
+	}
+}
+
+func TestLoad_customActions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yml := "customActions:\n  - key: ctrl+o\n    name: pods\n    command: kubectl get pods -n {{quote .Namespace}} -l app={{.Name}}\n"
+	if err := os.WriteFile(path, []byte(yml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(c.CustomActions) != 1 {
+		t.Fatalf("actions = %+v", c.CustomActions)
+	}
+	got, err := c.CustomActions[0].Render(CustomActionVars{Name: "web", Namespace: "it's"})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if want := `kubectl get pods -n 'it'\''s' -l app='web'`; got != want {
+		t.Fatalf("render = %q, want %q", got, want)
+	}
+	// Values are quoted without being asked to.
+	a := CustomAction{Name: "echo", Command: "echo {{.Path}}"}
+	if got, _ := a.Render(CustomActionVars{Path: "$(touch pwned); `id`"}); got != "echo '$(touch pwned); `id`'" {
+		t.Fatalf("render = %q, want the path quoted", got)
+	}
+
+	bad := [][]CustomAction{
+		{{Key: "ctrl+o", Command: "true"}},
+		{{Name: "a", Command: "true"}},
+		{{Name: "a", Key: "ctrl+o"}},
+		{{Name: "a", Key: "ctrl+o", Command: "true"}, {Name: "b", Key: "ctrl+o", Command: "true"}},
+		{{Name: "a", Key: "ctrl+o", Command: "echo {{.Nmae}}"}},
+		{{Name: "a", Key: "ctrl+o", Command: "echo {{.Name"}},
+	}
+	for _, as := range bad {
+		if err := ValidateCustomActions(as); err == nil {
+			t.Errorf("expected %+v to be rejected", as)
+		}
+	}
+}
+
+func TestLoadArgoCDContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	yml := `contexts:
+- name: prod
+  server: argocd.example.com
+  user: prod
+- name: local
+  server: localhost:8080
+  user: local
+current-context: local
+servers:
+- server: argocd.example.com
+  grpc-web: true
+- server: localhost:8080
+  insecure: true
+  plain-text: true
+users:
+- name: prod
+  auth-token: prod-token
+- name: local
+  auth-token: local-token
+`
+	if err := os.WriteFile(path, []byte(yml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ctx, err := LoadArgoCDContext(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	want := ArgoCDContext{Name: "local", Server: "http://localhost:8080", Token: "local-token", Insecure: true, PlainText: true}
+	if ctx != want {
+		t.Fatalf("context = %+v, want %+v", ctx, want)
+	}
+
+	if err := os.WriteFile(path, []byte("contexts: []\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadArgoCDContext(path); err == nil {
+		t.Fatalf("expected an error without a current context")
+	}
+}
+
+func TestResolveToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("  file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var c Config
+	c.ArgoCD.Token = "env-token"
+
+	if tok, err := ResolveToken("", c); err != nil || tok != "env-token" {
+		t.Fatalf("without a file: %q, %v", tok, err)
+	}
+	c.ArgoCD.TokenFile = path
+	if tok, err := ResolveToken("", c); err != nil || tok != "file-token" {
+		t.Fatalf("token file should beat env and be trimmed: %q, %v", tok, err)
+	}
+	if tok, err := ResolveToken("flag-token", c); err != nil || tok != "flag-token" {
+		t.Fatalf("explicit token should win: %q, %v", tok, err)
+	}
+
+	if err := os.WriteFile(path, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ResolveToken("", c); err == nil {
+		t.Fatalf("expected an empty token file to be an error")
+	}
+	c.ArgoCD.TokenFile = filepath.Join(t.TempDir(), "missing")
+	if _, err := ResolveToken("", c); err == nil {
+		t.Fatalf("expected a missing token file to be an error")
+	}
+}
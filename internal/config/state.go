@@ -0,0 +1,64 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// State is UI state lazyargo remembers between runs (as opposed to Config,
+// which the user writes). It lives next to the config file by default.
+type State struct {
+	// SidebarWidth is the last width set with the resize keys; 0 falls back
+	// to ui.sidebarWidth.
+	SidebarWidth int `yaml:"sidebarWidth,omitempty"`
+	// Pinned lists the apps pinned to the top of the sidebar.
+	Pinned []string `yaml:"pinned,omitempty"`
+}
+
+func defaultStatePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("user config dir: %w", err)
+	}
+	return filepath.Join(dir, "lazyargo", "state.yaml"), nil
+}
+
+// LoadState reads the state file. A missing file (or an empty path) is not
+// an error; it yields the zero State.
+func LoadState(path string) (State, error) {
+	var s State
+	if path == "" {
+		return s, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return s, err
+	}
+	if err := yaml.Unmarshal(b, &s); err != nil {
+		return State{}, fmt.Errorf("parse state %q: %w", path, err)
+	}
+	return s, nil
+}
+
+// SaveState writes the state file, creating its directory. An empty path
+// disables persistence.
+func SaveState(path string, s State) error {
+	if path == "" {
+		return nil
+	}
+	b, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
@@ -0,0 +1,39 @@
+// Package notify sends best-effort desktop notifications by shelling out to
+// the platform's notifier (notify-send on Linux/BSD, osascript on macOS).
+package notify
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// ErrUnsupported is returned when no notifier is available on this system.
+var ErrUnsupported = errors.New("desktop notifications not available")
+
+// Send shows a desktop notification. When urgent is true the notification is
+// marked critical where the platform supports it.
+func Send(title, body string, urgent bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	switch runtime.GOOS {
+	case "darwin":
+		script := "display notification " + strconv.Quote(body) + " with title " + strconv.Quote(title)
+		return exec.CommandContext(ctx, "osascript", "-e", script).Run()
+	case "linux", "freebsd", "openbsd", "netbsd":
+		if _, err := exec.LookPath("notify-send"); err != nil {
+			return ErrUnsupported
+		}
+		urgency := "normal"
+		if urgent {
+			urgency = "critical"
+		}
+		return exec.CommandContext(ctx, "notify-send", "-u", urgency, "-a", "lazyargo", title, body).Run()
+	default:
+		return ErrUnsupported
+	}
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// labelSelector is one "label:" term of the app filter:
+//
+//	label:env=prod   label equals value
+//	label:env!=prod  label missing or different
+//	label:env        label present
+type labelSelector struct {
+	key   string
+	value string
+	op    string // "=", "!=" or "" (exists)
+}
+
+// parseAppFilter splits a filter query into label selectors and the free
+// text that is fuzzy-matched against app names.
+func parseAppFilter(q string) (string, []labelSelector) {
+	var text []string
+	var sels []labelSelector
+	for _, f := range strings.Fields(q) {
+		rest, ok := strings.CutPrefix(f, "label:")
+		if !ok || rest == "" {
+			text = append(text, f)
+			continue
+		}
+		switch {
+		case strings.Contains(rest, "!="):
+			k, v, _ := strings.Cut(rest, "!=")
+			sels = append(sels, labelSelector{key: k, value: v, op: "!="})
+		case strings.Contains(rest, "="):
+			k, v, _ := strings.Cut(rest, "=")
+			sels = append(sels, labelSelector{key: k, value: v, op: "="})
+		default:
+			sels = append(sels, labelSelector{key: rest})
+		}
+	}
+	return strings.Join(text, " "), sels
+}
+
+func (s labelSelector) matches(labels map[string]string) bool {
+	v, ok := labels[s.key]
+	switch s.op {
+	case "=":
+		return ok && v == s.value
+	case "!=":
+		return !ok || v != s.value
+	default:
+		return ok
+	}
+}
+
+func matchesAllLabels(labels map[string]string, sels []labelSelector) bool {
+	for _, s := range sels {
+		if !s.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatLabels renders labels as sorted "k=v" pairs.
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "—"
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, labels[k]))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"lazyargo/internal/argocd"
+)
+
+// errNoBrowser means there is no browser to hand a URL to, e.g. over SSH or
+// on a host without a display.
+var errNoBrowser = errors.New("no browser available")
+
+// openBrowser opens u in the default browser with the platform's opener
+// (open, rundll32, xdg-open). It does not wait for the browser.
+func openBrowser(u string) error {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		if os.Getenv("SSH_CONNECTION") != "" {
+			return errNoBrowser
+		}
+		c = exec.Command("open", u)
+	case "windows":
+		c = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+	default:
+		if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			return errNoBrowser
+		}
+		if _, err := exec.LookPath("xdg-open"); err != nil {
+			return errNoBrowser
+		}
+		c = exec.Command("xdg-open", u)
+	}
+	if err := c.Start(); err != nil {
+		return err
+	}
+	go c.Wait()
+	return nil
+}
+
+// appWebURL is the Argo CD web UI page for app. Apps outside the Argo CD
+// namespace are addressed as /applications/<namespace>/<name>.
+func appWebURL(server string, app argocd.Application) string {
+	ns, name := argocd.SplitAppName(app.Name)
+	if ns == "" {
+		ns = app.AppObjectNamespace
+	}
+	p := "/applications/" + url.PathEscape(name)
+	if ns != "" {
+		p = "/applications/" + url.PathEscape(ns) + "/" + url.PathEscape(name)
+	}
+	return strings.TrimSuffix(server, "/") + p
+}
+
+// browserOpenedMsg reports how opening a URL went.
+type browserOpenedMsg struct {
+	url string
+	err error
+}
+
+func openBrowserCmd(u string) tea.Cmd {
+	return func() tea.Msg {
+		return browserOpenedMsg{url: u, err: openBrowser(u)}
+	}
+}
+
+// clipboardDoneMsg takes the clipboard request back out of the frame.
+type clipboardDoneMsg struct{ gen int }
+
+// copyToClipboard asks the terminal to put s on the clipboard (OSC 52) with
+// the next frame, the way ringBell rings the bell. Most terminals honor it,
+// including over SSH; the rest ignore it, so it can only be requested.
+func (m *Model) copyToClipboard(s string) tea.Cmd {
+	m.clipboard = "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(s)) + "\a"
+	m.clipboardGen++
+	gen := m.clipboardGen
+	return tea.Tick(bellHold, func(time.Time) tea.Msg { return clipboardDoneMsg{gen: gen} })
+}
+
+// handleBrowserOpened falls back to showing (and copying) the URL when it
+// could not be opened.
+func (m Model) handleBrowserOpened(msg browserOpenedMsg) (Model, tea.Cmd) {
+	if msg.err == nil {
+		m.statusLine = "opened " + msg.url
+		return m, nil
+	}
+	m.statusLine = fmt.Sprintf("could not open a browser (%v); URL: %s (copy requested)", msg.err, msg.url)
+	return m, m.copyToClipboard(msg.url)
+}
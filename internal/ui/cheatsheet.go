@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"lazyargo/internal/config"
+)
+
+// helpEntry is one line of the cheatsheet: the keys and what they do.
+type helpEntry struct {
+	keys string
+	desc string
+}
+
+// helpSection groups the keys that apply in one context.
+type helpSection struct {
+	title   string
+	entries []helpEntry
+}
+
+// cheatsheetSections lists every keybinding by context. The application list
+// comes from the keyMap; the other contexts handle their keys locally, so
+// they are listed here by hand and must follow changes to those handlers.
+// Custom actions from the config get a section of their own.
+func cheatsheetSections(k keyMap, actions []config.CustomAction) []helpSection {
+	list := helpSection{title: "Application list"}
+	seen := map[string]bool{}
+	for _, group := range k.FullHelp() {
+		for _, b := range group {
+			h := b.Help()
+			if seen[h.Key] {
+				continue
+			}
+			seen[h.Key] = true
+			list.entries = append(list.entries, helpEntry{h.Key, h.Desc})
+		}
+	}
+	list.entries = append(list.entries,
+		helpEntry{"tab", "focus the resource pane"},
+		helpEntry{"space / Z", "collapse group / expand all (while grouped)"},
+		helpEntry{"A", "events of all apps"},
+		helpEntry{"!", "expand the error details"},
+		helpEntry{"backspace", "back to the parent app"},
+		helpEntry{"ctrl+g", "API request log (--debug)"},
+	)
+
+	sections := []helpSection{
+		list,
+		{title: "Resource pane", entries: []helpEntry{
+			{"space", "collapse/expand group"},
+			{"z", "zoom into group"},
+			{"/", "filter by kind/name"},
+			{"S", "sort: name → health → sync → wave"},
+			{"D", "problem resources only"},
+			{"H", "hide/show hooks"},
+			{"enter / v", "open the resource viewer"},
+			{"l", "pod logs"},
+			{"L", "pod logs + app events"},
+			{"a", "resource actions"},
+			{"ctrl+d", "delete from the cluster"},
+		}},
+		{title: "Sync modal", entries: []helpEntry{
+			{"y", "run the sync"},
+			{"w", "run the sync and watch its operation in the modal"},
+			{"n / esc", "cancel"},
+		}},
+		{title: "Every overlay", entries: []helpEntry{
+			{"esc / q", "close"},
+			{"g / G", "top / bottom"},
+			{"r", "retry after a load error"},
+		}},
+		{title: "Resource viewer", entries: []helpEntry{
+			{"tab", "live → desired → diff"},
+			{"t", "yaml / json"},
+			{"h / l", "scroll sideways (0 = first column)"},
+			{"#", "line numbers"},
+			{":", "go to line"},
+			{".", "show only a path, e.g. spec.containers[*].image"},
+		}},
+		{title: "Diff", entries: []helpEntry{
+			{"W", "ignore whitespace"},
+			{"h / l", "scroll sideways (0 = first column)"},
+			{"n / N", "next / previous app (diff of all drifted apps)"},
+		}},
+		{title: "Logs", entries: []helpEntry{
+			{"f", "follow"},
+			{"w", "wrap"},
+			{"/", "search"},
+			{"n", "next match"},
+		}},
+		{title: "Logs + events", entries: []helpEntry{
+			{"tab", "switch focus"},
+			{"+ / -", "resize the split"},
+		}},
+		{title: "Events", entries: []helpEntry{
+			{"w", "warnings only"},
+			{"o", "oldest / newest first"},
+			{"t", "relative / absolute time"},
+		}},
+		{title: "History", entries: []helpEntry{
+			{"enter", "revision details"},
+			{"space", "mark an entry"},
+			{"d", "diff selected against marked (or previous)"},
+		}},
+		{title: "Request log", entries: []helpEntry{
+			{"w", "errors only"},
+			{"r", "reload"},
+		}},
+	}
+	if len(actions) > 0 {
+		custom := helpSection{title: "Custom actions (selected app)"}
+		for _, a := range actions {
+			custom.entries = append(custom.entries, helpEntry{a.Key, a.Name})
+		}
+		sections = append([]helpSection{sections[0], custom}, sections[1:]...)
+	}
+	return sections
+}
+
+// cheatsheetModel is the full-screen keybinding legend (F1).
+type cheatsheetModel struct {
+	styles   styles
+	sections []helpSection
+
+	width  int
+	height int
+	vp     viewport.Model
+}
+
+func newCheatsheetModel(st styles, k keyMap, actions []config.CustomAction) cheatsheetModel {
+	vp := viewport.New(0, 0)
+	vp.MouseWheelEnabled = false
+	m := cheatsheetModel{styles: st, sections: cheatsheetSections(k, actions), vp: vp}
+	m.vp.SetContent(m.renderBody())
+	return m
+}
+
+func (m *cheatsheetModel) setSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.vp.Width = max(1, w)
+	m.vp.Height = max(1, h-2)
+	m.vp.SetContent(m.renderBody())
+}
+
+func (m cheatsheetModel) Update(msg tea.Msg) (cheatsheetModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.setSize(msg.Width, msg.Height)
+		return m, nil
+	case tea.KeyMsg:
+		if scrollEnds(msg, &m.vp) {
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.vp, cmd = m.vp.Update(msg)
+	return m, cmd
+}
+
+func (m cheatsheetModel) View() string {
+	head := "Keybindings  F1/esc=close"
+	return lipgloss.JoinVertical(lipgloss.Top, m.styles.OverlayHeader.Width(m.width).Render(head+scrollIndicator(m.vp)), m.vp.View())
+}
+
+func (m cheatsheetModel) renderBody() string {
+	keyW := 0
+	for _, s := range m.sections {
+		for _, e := range s.entries {
+			keyW = max(keyW, lipgloss.Width(e.keys))
+		}
+	}
+	var lines []string
+	for i, s := range m.sections {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, m.styles.SidebarTitle.Render(s.title))
+		for _, e := range s.entries {
+			pad := strings.Repeat(" ", keyW-lipgloss.Width(e.keys))
+			lines = append(lines, fmt.Sprintf("  %s%s  %s", m.styles.StatusLabel.Render(e.keys), pad, e.desc))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
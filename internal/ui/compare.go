@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"lazyargo/internal/argocd"
+)
+
+// compareModel shows two apps' key spec fields side by side, highlighting
+// the ones that differ (e.g. staging vs prod of one service). It uses the
+// data already loaded; nothing is fetched.
+type compareModel struct {
+	styles styles
+	a, b   argocd.Application
+
+	width  int
+	height int
+	vp     viewport.Model
+}
+
+func newCompareModel(st styles, a, b argocd.Application) compareModel {
+	vp := viewport.New(0, 0)
+	vp.MouseWheelEnabled = false
+	m := compareModel{styles: st, a: a, b: b, vp: vp}
+	m.vp.SetContent(m.renderBody())
+	return m
+}
+
+func (m *compareModel) setSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.vp.Width = max(1, w)
+	m.vp.Height = max(1, h-2)
+	m.vp.SetContent(m.renderBody())
+}
+
+func (m compareModel) Update(msg tea.Msg) (compareModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.setSize(msg.Width, msg.Height)
+		return m, nil
+	case tea.KeyMsg:
+		if scrollEnds(msg, &m.vp) {
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.vp, cmd = m.vp.Update(msg)
+	return m, cmd
+}
+
+func (m compareModel) View() string {
+	head := fmt.Sprintf("Compare: %s ↔ %s  %d differences  esc=close", m.a.Name, m.b.Name, len(m.differences()))
+	return lipgloss.JoinVertical(lipgloss.Top, m.styles.OverlayHeader.Width(m.width).Render(head+scrollIndicator(m.vp)), m.vp.View())
+}
+
+// compareField is one compared row.
+type compareField struct {
+	label string
+	a, b  string
+}
+
+func (m compareModel) fields() []compareField {
+	f := func(label string, get func(argocd.Application) string) compareField {
+		return compareField{label: label, a: get(m.a), b: get(m.b)}
+	}
+	return []compareField{
+		f("Project", func(a argocd.Application) string { return a.Project }),
+		f("Repo", func(a argocd.Application) string { return a.RepoURL }),
+		f("Path", func(a argocd.Application) string { return a.Path }),
+		f("Revision", func(a argocd.Application) string { return a.Revision }),
+		f("Cluster", func(a argocd.Application) string { return a.Cluster }),
+		f("Namespace", func(a argocd.Application) string { return a.Namespace }),
+		f("Sync policy", func(a argocd.Application) string { return a.SyncPolicy }),
+	}
+}
+
+// differences returns the labels of the fields that differ.
+func (m compareModel) differences() []string {
+	var out []string
+	for _, f := range m.fields() {
+		if f.a != f.b {
+			out = append(out, f.label)
+		}
+	}
+	return out
+}
+
+func (m compareModel) renderBody() string {
+	const labelW = 12
+	colW := max(10, (m.width-labelW-4)/2)
+	cell := func(s string) string {
+		s = blankIfEmpty(s, "—")
+		if lipgloss.Width(s) > colW {
+			s = truncate(s, colW)
+		}
+		return s + strings.Repeat(" ", max(0, colW-lipgloss.Width(s)))
+	}
+
+	lines := []string{
+		m.styles.SidebarTitle.Render(fmt.Sprintf("  %-*s%s  %s", labelW, "", cell(m.a.Name), cell(m.b.Name))),
+	}
+	for _, f := range m.fields() {
+		mark, st := "  ", m.styles.StatusValue
+		if f.a != f.b {
+			mark, st = "≠ ", m.styles.StatusWarn
+		}
+		lines = append(lines, st.Render(fmt.Sprintf("%s%-*s%s  %s", mark, labelW, f.label, cell(f.a), cell(f.b))))
+	}
+	if len(m.differences()) == 0 {
+		lines = append(lines, "", "These fields are identical.")
+	}
+	return strings.Join(lines, "\n")
+}
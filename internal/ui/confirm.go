@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// confirmText guards a destructive action behind typing an exact name (an
+// application or resource) instead of a single keypress.
+type confirmText struct {
+	input textinput.Model
+	want  string
+}
+
+func newConfirmText(placeholder string, charLimit int) confirmText {
+	in := textinput.New()
+	in.Placeholder = placeholder
+	in.Prompt = "> "
+	in.CharLimit = charLimit
+	in.Width = 32
+	return confirmText{input: in}
+}
+
+// open clears the input and waits for want to be typed.
+func (c *confirmText) open(want string) {
+	c.want = want
+	c.input.SetValue("")
+	c.input.Focus()
+}
+
+func (c *confirmText) close() {
+	c.want = ""
+	c.input.SetValue("")
+	c.input.Blur()
+}
+
+// active reports whether the input is waiting for a name.
+func (c confirmText) active() bool { return c.input.Focused() }
+
+// confirmed reports whether the typed text matches the expected name.
+func (c confirmText) confirmed() bool {
+	return c.want != "" && strings.TrimSpace(c.input.Value()) == c.want
+}
+
+func (c confirmText) Update(msg tea.Msg) (confirmText, tea.Cmd) {
+	var cmd tea.Cmd
+	c.input, cmd = c.input.Update(msg)
+	return c, cmd
+}
+
+func (c confirmText) View() string { return c.input.View() }
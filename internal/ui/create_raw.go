@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"lazyargo/internal/argocd"
+)
+
+type rawCreateMsg struct {
+	appName string
+	err     error
+}
+
+func (m Model) createAppRawCmd(manifest, appName string) tea.Cmd {
+	return func() tea.Msg {
+		err := m.client.CreateApplicationRaw(context.Background(), manifest)
+		return rawCreateMsg{appName: appName, err: err}
+	}
+}
+
+func (m *Model) sizeRawCreateInput() {
+	m.rawCreateInput.SetWidth(max(20, m.width-8))
+	m.rawCreateInput.SetHeight(max(3, m.height-14))
+}
+
+func (m Model) resetRawCreate() Model {
+	m.rawCreateModal = false
+	m.rawCreateErr = nil
+	m.rawCreating = false
+	m.rawCreateInput.Blur()
+	m.rawCreateInput.Reset()
+	return m
+}
+
+// rawCreateManifest returns the manifest to submit: the textarea contents, or
+// the contents of the file when the input is a single "@path" line.
+func (m Model) rawCreateManifest() (string, error) {
+	v := strings.TrimSpace(m.rawCreateInput.Value())
+	path, ok := strings.CutPrefix(v, "@")
+	if !ok || strings.Contains(path, "\n") {
+		return v, nil
+	}
+	b, err := os.ReadFile(strings.TrimSpace(path))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (m Model) updateRawCreate(k tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.rawCreating {
+		return m, nil
+	}
+	switch k.String() {
+	case "esc":
+		m = m.resetRawCreate()
+		m.statusLine = "create cancelled"
+		return m, nil
+	case "ctrl+s":
+		manifest, err := m.rawCreateManifest()
+		if err != nil {
+			m.rawCreateErr = err
+			return m, nil
+		}
+		// Catch malformed YAML locally rather than round-tripping to the server.
+		app, err := argocd.ParseApplicationYAML(manifest)
+		if err != nil {
+			m.rawCreateErr = err
+			return m, nil
+		}
+		m.rawCreateErr = nil
+		m.rawCreating = true
+		m.statusLine = fmt.Sprintf("creating %s…", app.Name)
+		return m, m.createAppRawCmd(manifest, app.Name)
+	}
+
+	var cmd tea.Cmd
+	m.rawCreateInput, cmd = m.rawCreateInput.Update(k)
+	return m, cmd
+}
+
+func (m Model) renderRawCreate() string {
+	lines := []string{"Create application from YAML", ""}
+	if m.rawCreateErr != nil {
+		lines = append(lines, m.styles.Error.Render("Error: "+m.rawCreateErr.Error()), "")
+	}
+	if m.rawCreating {
+		lines = append(lines, "Creating…", "")
+	}
+	lines = append(lines, m.rawCreateInput.View(), "", "ctrl+s=create  esc=cancel  (a single @path line reads the manifest from a file)")
+	return strings.Join(lines, "\n")
+}
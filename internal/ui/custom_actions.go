@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"lazyargo/internal/argocd"
+	"lazyargo/internal/config"
+)
+
+// listKeys are the application list keys handled outside the keyMap (see the
+// list switch in Update); custom actions cannot take them either.
+var listKeys = []string{"tab", " ", "z", "Z", "enter", "v", "a", "A", "E", "l", "L", "H", "!", "backspace", "ctrl+g", "ctrl+d", "esc"}
+
+// ValidateCustomActionKeys rejects custom actions bound to a key lazyargo
+// already uses in the application list or resource pane.
+func ValidateCustomActionKeys(actions []config.CustomAction) error {
+	builtin := map[string]bool{}
+	for _, k := range listKeys {
+		builtin[k] = true
+	}
+	for _, group := range newKeyMap().FullHelp() {
+		for _, b := range group {
+			for _, k := range b.Keys() {
+				builtin[k] = true
+			}
+		}
+	}
+	for _, a := range actions {
+		if builtin[a.Key] {
+			return fmt.Errorf("custom action %q: key %q is a built-in key", a.Name, a.Key)
+		}
+	}
+	return nil
+}
+
+// customAction returns the configured action bound to key, if any.
+func (m Model) customAction(key string) (config.CustomAction, bool) {
+	for _, a := range m.cfg.CustomActions {
+		if a.Key == key {
+			return a, true
+		}
+	}
+	return config.CustomAction{}, false
+}
+
+// customActionVars are the template values for app. Name is the plain
+// Application name, without the namespace prefix lazyargo adds when a name is
+// shared.
+func (m Model) customActionVars(app argocd.Application) config.CustomActionVars {
+	_, name := argocd.SplitAppName(app.Name)
+	return config.CustomActionVars{
+		Name:         name,
+		Namespace:    app.Namespace,
+		AppNamespace: app.AppObjectNamespace,
+		Project:      app.Project,
+		Cluster:      app.Cluster,
+		RepoURL:      app.RepoURL,
+		Path:         app.Path,
+		Revision:     app.Revision,
+		Server:       m.cfg.ArgoCD.Server,
+	}
+}
+
+// customActionDoneMsg is sent once a custom action's command exits.
+type customActionDoneMsg struct {
+	action string
+	app    string
+	output string
+	err    error
+}
+
+// execCustomActionCmd suspends the TUI and runs command with sh. The output
+// goes to the terminal as it runs and is kept for the result overlay.
+func execCustomActionCmd(action, app, command string) tea.Cmd {
+	var out bytes.Buffer
+	c := exec.Command("sh", "-c", command)
+	w := io.MultiWriter(os.Stdout, &out)
+	c.Stdout = w
+	c.Stderr = w
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		return customActionDoneMsg{action: action, app: app, output: out.String(), err: err}
+	})
+}
+
+// runCustomAction renders a's command for the selected app and runs it.
+func (m Model) runCustomAction(a config.CustomAction) (Model, tea.Cmd) {
+	if len(m.apps) == 0 {
+		return m, nil
+	}
+	app := m.apps[m.selected]
+	command, err := a.Render(m.customActionVars(app))
+	if err != nil {
+		m.statusLine = fmt.Sprintf("%s: %v", a.Name, err)
+		return m, nil
+	}
+	m.statusLine = fmt.Sprintf("running %s for %s…", a.Name, app.Name)
+	return m, execCustomActionCmd(a.Name, app.Name, command)
+}
+
+// handleCustomActionDone opens the output overlay for a finished action.
+func (m Model) handleCustomActionDone(msg customActionDoneMsg) (Model, tea.Cmd) {
+	ao := newActionOutputModel(m.styles, msg)
+	ao.setSize(m.overlaySize())
+	m.actionOutput = &ao
+	if msg.err != nil {
+		m.statusLine = fmt.Sprintf("%s failed: %v", msg.action, msg.err)
+	} else {
+		m.statusLine = msg.action + " done"
+	}
+	return m, nil
+}
+
+// actionOutputModel shows what a custom action printed.
+type actionOutputModel struct {
+	styles styles
+	action string
+	app    string
+	output string
+	err    error
+
+	width  int
+	height int
+	vp     viewport.Model
+}
+
+func newActionOutputModel(st styles, msg customActionDoneMsg) actionOutputModel {
+	vp := viewport.New(0, 0)
+	vp.MouseWheelEnabled = false
+	m := actionOutputModel{styles: st, action: msg.action, app: msg.app, output: stripControl(msg.output), err: msg.err, vp: vp}
+	m.vp.SetContent(m.renderBody())
+	return m
+}
+
+// stripControl removes escape sequences and other control characters but
+// newlines and tabs from command output, so what a command prints can't
+// restyle or move around the rest of the screen.
+func stripControl(s string) string {
+	var b strings.Builder
+	rs := []rune(s)
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		switch {
+		case r == '\x1b':
+			i = skipEscape(rs, i)
+		case r == '\n' || r == '\t':
+			b.WriteRune(r)
+		case r < 0x20 || (r >= 0x7f && r < 0xa0):
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// skipEscape returns the index of the last rune of the escape sequence
+// starting at rs[i]: a CSI (ESC [ ... final byte), a string sequence such
+// as OSC (ESC ] ... BEL or ESC \), or ESC and one more rune.
+func skipEscape(rs []rune, i int) int {
+	j := i + 1
+	if j >= len(rs) {
+		return i
+	}
+	switch rs[j] {
+	case '[':
+		for j++; j < len(rs) && (rs[j] < 0x40 || rs[j] > 0x7e); j++ {
+		}
+		return min(j, len(rs)-1)
+	case ']', 'P', '_', '^', 'X':
+		for j++; j < len(rs); j++ {
+			if rs[j] == '\a' {
+				return j
+			}
+			if rs[j] == '\x1b' && j+1 < len(rs) && rs[j+1] == '\\' {
+				return j + 1
+			}
+		}
+		return len(rs) - 1
+	}
+	return j
+}
+
+func (m *actionOutputModel) setSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.vp.Width = max(1, w)
+	m.vp.Height = max(1, h-2)
+	m.vp.SetContent(m.renderBody())
+}
+
+func (m actionOutputModel) Update(msg tea.Msg) (actionOutputModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.setSize(msg.Width, msg.Height)
+		return m, nil
+	case tea.KeyMsg:
+		if scrollEnds(msg, &m.vp) {
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.vp, cmd = m.vp.Update(msg)
+	return m, cmd
+}
+
+func (m actionOutputModel) View() string {
+	head := fmt.Sprintf("Action: %s (%s)  esc=close", m.action, m.app)
+	return lipgloss.JoinVertical(lipgloss.Top, m.styles.OverlayHeader.Width(m.width).Render(head+scrollIndicator(m.vp)), m.vp.View())
+}
+
+func (m actionOutputModel) renderBody() string {
+	body := strings.TrimRight(m.output, "\n")
+	if body == "" {
+		body = "(no output)"
+	}
+	if m.err != nil {
+		body += "\n\n" + m.styles.Error.Render("failed: "+m.err.Error())
+	}
+	return body
+}
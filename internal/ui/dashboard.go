@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"lazyargo/internal/argocd"
+)
+
+// dashboardTopN caps the "most degraded" list.
+const dashboardTopN = 10
+
+var (
+	dashboardHealthOrder = []string{"Healthy", "Progressing", "Degraded", "Suspended", "Missing", "Unknown"}
+	dashboardSyncOrder   = []string{"Synced", "OutOfSync", "Unknown"}
+)
+
+// dashboardModel is a summary of every loaded app: counts by health and sync
+// status plus the apps in the worst state. It is derived purely from appsAll.
+type dashboardModel struct {
+	styles styles
+	apps   []argocd.Application
+
+	width  int
+	height int
+	vp     viewport.Model
+}
+
+func newDashboardModel(st styles, apps []argocd.Application) dashboardModel {
+	vp := viewport.New(0, 0)
+	vp.MouseWheelEnabled = false
+	m := dashboardModel{styles: st, apps: apps, vp: vp}
+	m.vp.SetContent(m.renderBody())
+	return m
+}
+
+func (m *dashboardModel) setSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.vp.Width = max(1, w)
+	m.vp.Height = max(1, h-2)
+	m.vp.SetContent(m.renderBody())
+}
+
+func (m dashboardModel) Update(msg tea.Msg) (dashboardModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.setSize(msg.Width, msg.Height)
+		return m, nil
+	case tea.KeyMsg:
+		if scrollEnds(msg, &m.vp) {
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.vp, cmd = m.vp.Update(msg)
+	return m, cmd
+}
+
+func (m dashboardModel) View() string {
+	head := fmt.Sprintf("Dashboard: %d apps  esc=close", len(m.apps))
+	return lipgloss.JoinVertical(lipgloss.Top, m.styles.OverlayHeader.Width(m.width).Render(head+scrollIndicator(m.vp)), m.vp.View())
+}
+
+func (m dashboardModel) renderBody() string {
+	if len(m.apps) == 0 {
+		return "(no applications loaded)"
+	}
+
+	health := map[string]int{}
+	syncs := map[string]int{}
+	for _, a := range m.apps {
+		health[blankIfEmpty(a.Health, "Unknown")]++
+		syncs[blankIfEmpty(a.Sync, "Unknown")]++
+	}
+
+	lines := []string{m.styles.SidebarTitle.Render("Health")}
+	lines = append(lines, m.renderBars(health, dashboardHealthOrder)...)
+	lines = append(lines, "", m.styles.SidebarTitle.Render("Sync"))
+	lines = append(lines, m.renderBars(syncs, dashboardSyncOrder)...)
+
+	worst := make([]argocd.Application, 0)
+	for _, a := range m.apps {
+		if appSeverity(a) > 0 {
+			worst = append(worst, a)
+		}
+	}
+	sort.SliceStable(worst, func(i, j int) bool {
+		si, sj := appSeverity(worst[i]), appSeverity(worst[j])
+		if si != sj {
+			return si > sj
+		}
+		return worst[i].Name < worst[j].Name
+	})
+	lines = append(lines, "", m.styles.SidebarTitle.Render("Most degraded"))
+	if len(worst) == 0 {
+		lines = append(lines, "  (all apps healthy and synced)")
+	}
+	for i, a := range worst {
+		if i == dashboardTopN {
+			lines = append(lines, fmt.Sprintf("  … and %d more", len(worst)-dashboardTopN))
+			break
+		}
+		st := m.styles.StatusWarn
+		if appSeverity(a) >= 3 {
+			st = m.styles.Error
+		}
+		lines = append(lines, st.Render(fmt.Sprintf("  %s %-32s %s/%s", appStateGlyph(a), a.Name, blankIfEmpty(a.Health, "—"), blankIfEmpty(a.Sync, "—"))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderBars draws one bar per status: the known ones in order, then any
+// unexpected values the server reported.
+func (m dashboardModel) renderBars(counts map[string]int, order []string) []string {
+	keys := append([]string{}, order...)
+	extra := make([]string, 0)
+	for k := range counts {
+		known := false
+		for _, o := range order {
+			if k == o {
+				known = true
+			}
+		}
+		if !known {
+			extra = append(extra, k)
+		}
+	}
+	sort.Strings(extra)
+	keys = append(keys, extra...)
+
+	maxCount := 0
+	for _, n := range counts {
+		maxCount = max(maxCount, n)
+	}
+	barW := max(10, m.width-24)
+
+	lines := make([]string, 0, len(keys))
+	for _, k := range keys {
+		n := counts[k]
+		if n == 0 {
+			continue
+		}
+		w := max(1, n*barW/max(1, maxCount))
+		bar := strings.Repeat("█", w)
+		switch k {
+		case "Healthy", "Synced":
+			bar = m.styles.Success.Render(bar)
+		case "Degraded", "Missing":
+			bar = m.styles.Error.Render(bar)
+		case "Progressing", "OutOfSync", "Suspended":
+			bar = m.styles.StatusWarn.Render(bar)
+		}
+		lines = append(lines, fmt.Sprintf("  %-12s %4d %s", k, n, bar))
+	}
+	return lines
+}
+
+// appSeverity ranks how bad an app's state is: 3 degraded/missing,
+// 2 progressing, 1 out of sync, 0 fine.
+func appSeverity(a argocd.Application) int {
+	switch {
+	case strings.EqualFold(a.Health, "degraded") || strings.EqualFold(a.Health, "missing"):
+		return 3
+	case strings.EqualFold(a.Health, "progressing"):
+		return 2
+	case a.Sync != "" && a.Sync != "Synced":
+		return 1
+	default:
+		return 0
+	}
+}
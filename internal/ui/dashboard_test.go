@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"lazyargo/internal/argocd"
+	"lazyargo/internal/config"
+)
+
+func TestAppSeverity(t *testing.T) {
+	cases := []struct {
+		health, sync string
+		want         int
+	}{
+		{"Degraded", "Synced", 3},
+		{"missing", "Unknown", 3},
+		{"Progressing", "OutOfSync", 2},
+		{"Healthy", "OutOfSync", 1},
+		{"Healthy", "Synced", 0},
+		{"Healthy", "", 0},
+	}
+	for _, tc := range cases {
+		if got := appSeverity(argocd.Application{Health: tc.health, Sync: tc.sync}); got != tc.want {
+			t.Errorf("appSeverity(%s/%s) = %d, want %d", tc.health, tc.sync, got, tc.want)
+		}
+	}
+}
+
+func TestDashboardModel_renderBody(t *testing.T) {
+	st := newStyles(config.Theme{})
+	apps := []argocd.Application{
+		{Name: "ok", Health: "Healthy", Sync: "Synced"},
+		{Name: "drifted", Health: "Healthy", Sync: "OutOfSync"},
+		{Name: "rolling", Health: "Progressing", Sync: "Synced"},
+		{Name: "broken", Health: "Degraded", Sync: "Synced"},
+		{Name: "blank"},
+	}
+	body := newDashboardModel(st, apps).renderBody()
+
+	for _, want := range []string{"Healthy         2", "Progressing     1", "Degraded        1", "Unknown         1", "Synced          3", "OutOfSync       1"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in body:\n%s", want, body)
+		}
+	}
+	_, worst, _ := strings.Cut(body, "Most degraded")
+	order := []string{"broken", "rolling", "drifted"}
+	last := -1
+	for _, name := range order {
+		i := strings.Index(worst, name)
+		if i < 0 || i < last {
+			t.Fatalf("expected most degraded in order %v, got:\n%s", order, worst)
+		}
+		last = i
+	}
+	if strings.Contains(worst, " ok ") || strings.Contains(worst, "blank") {
+		t.Fatalf("apps in a fine state should not be listed:\n%s", worst)
+	}
+
+	body = newDashboardModel(st, apps[:1]).renderBody()
+	if !strings.Contains(body, "(all apps healthy and synced)") {
+		t.Fatalf("expected the all-clear line, got:\n%s", body)
+	}
+	if got := newDashboardModel(st, nil).renderBody(); got != "(no applications loaded)" {
+		t.Fatalf("expected the empty state, got %q", got)
+	}
+}
+
+func TestDashboardModel_capsMostDegraded(t *testing.T) {
+	var apps []argocd.Application
+	for i := 0; i < dashboardTopN+3; i++ {
+		apps = append(apps, argocd.Application{Name: fmt.Sprintf("app-%02d", i), Health: "Degraded", Sync: "Synced"})
+	}
+	body := newDashboardModel(newStyles(config.Theme{}), apps).renderBody()
+	if !strings.Contains(body, "… and 3 more") {
+		t.Fatalf("expected the overflow line, got:\n%s", body)
+	}
+	if strings.Contains(body, fmt.Sprintf("app-%02d", dashboardTopN)) {
+		t.Fatalf("expected only the first %d apps listed, got:\n%s", dashboardTopN, body)
+	}
+}
+
+func TestModel_dashboardKey(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.appsAll = []argocd.Application{
+		{Name: "a", Health: "Healthy", Sync: "Synced"},
+		{Name: "b", Health: "Degraded", Sync: "OutOfSync"},
+	}
+	m.apps = m.appsAll
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	m = updated.(Model)
+	if m.dashboardView == nil {
+		t.Fatal("expected O to open the dashboard")
+	}
+	if got := m.dashboardView.View(); !strings.Contains(got, "Dashboard: 2 apps") {
+		t.Fatalf("expected the dashboard header, got:\n%s", got)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.dashboardView != nil {
+		t.Fatal("expected esc to close the dashboard")
+	}
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"lazyargo/internal/argocd"
+)
+
+// requestRecorder is implemented by clients that keep a log of recent API
+// calls (the HTTP client when started with --debug).
+type requestRecorder interface {
+	RecentRequests() []argocd.RequestRecord
+}
+
+// debugModel lists recent API requests: method, path, status and duration.
+// It shows a snapshot; r re-reads the log.
+type debugModel struct {
+	styles styles
+	client argocd.Client
+
+	width  int
+	height int
+	vp     viewport.Model
+
+	records []argocd.RequestRecord
+	// errorsOnly hides successful requests.
+	errorsOnly bool
+}
+
+func newDebugModel(st styles, c argocd.Client) debugModel {
+	vp := viewport.New(0, 0)
+	vp.MouseWheelEnabled = false
+	m := debugModel{styles: st, client: c, vp: vp}
+	m.reload()
+	return m
+}
+
+func (m *debugModel) reload() {
+	m.records = nil
+	if rr, ok := m.client.(requestRecorder); ok {
+		m.records = rr.RecentRequests()
+	}
+}
+
+func (m *debugModel) setSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.vp.Width = max(1, w)
+	m.vp.Height = max(1, h-2)
+	m.vp.SetContent(m.renderBody())
+}
+
+func (m debugModel) Update(msg tea.Msg) (debugModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.setSize(msg.Width, msg.Height)
+		return m, nil
+	case tea.KeyMsg:
+		if scrollEnds(msg, &m.vp) {
+			return m, nil
+		}
+		switch msg.String() {
+		case "r":
+			m.reload()
+			m.vp.SetContent(m.renderBody())
+			m.vp.GotoTop()
+			return m, nil
+		case "w":
+			m.errorsOnly = !m.errorsOnly
+			m.vp.SetContent(m.renderBody())
+			m.vp.GotoTop()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.vp, cmd = m.vp.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m debugModel) View() string {
+	head := fmt.Sprintf("API requests (newest first)  [w=%s]  r=reload  esc=close",
+		map[bool]string{false: "all", true: "errors only"}[m.errorsOnly])
+	return lipgloss.JoinVertical(lipgloss.Top, m.styles.OverlayHeader.Width(m.width).Render(head+scrollIndicator(m.vp)), m.vp.View())
+}
+
+func (m debugModel) renderBody() string {
+	if _, ok := m.client.(requestRecorder); !ok {
+		return "(request log is only kept by the HTTP client)"
+	}
+	lines := make([]string, 0, len(m.records))
+	for _, r := range m.records {
+		failed := r.Err != "" || r.Status < 200 || r.Status >= 300
+		if m.errorsOnly && !failed {
+			continue
+		}
+		status := fmt.Sprintf("%d", r.Status)
+		if r.Err != "" {
+			status = "ERR"
+		}
+		line := fmt.Sprintf("%s  %-6s %-4s %6dms  %s",
+			r.Time.Format("15:04:05"), r.Method, status, r.Duration.Milliseconds(), r.Path)
+		if r.Err != "" {
+			line += "  " + r.Err
+		}
+		line = truncate(line, max(20, m.width))
+		if failed {
+			line = m.styles.StatusWarn.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return "(no requests recorded yet)"
+	}
+	return strings.Join(lines, "\n")
+}
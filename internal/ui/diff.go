@@ -10,12 +10,18 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"lazyargo/internal/argocd"
+	"lazyargo/internal/config"
 )
 
 type diffModel struct {
 	styles styles
 	client argocd.Client
 	app    string
+	// gen tags this view's load so a late result from a diff that was
+	// closed (or replaced) doesn't land here.
+	gen int
+	// ctx is cancelled when the view closes; nil means no cancellation.
+	ctx context.Context
 
 	filter *argocd.ResourceRef
 
@@ -23,14 +29,15 @@ type diffModel struct {
 	height int
 	vp     viewport.Model
 
-	loading bool
-	err     error
-	diffs   []argocd.DiffResult
+	load  loadState
+	diffs []argocd.DiffResult
 
 	showWhitespace bool
+	hs             hScroll
 }
 
 type diffLoadedMsg struct {
+	gen   int
 	diffs []argocd.DiffResult
 	err   error
 }
@@ -38,13 +45,19 @@ type diffLoadedMsg struct {
 func newDiffModel(st styles, c argocd.Client, appName string, filter *argocd.ResourceRef) diffModel {
 	vp := viewport.New(0, 0)
 	vp.MouseWheelEnabled = false
-	return diffModel{styles: st, client: c, app: appName, filter: filter, vp: vp, loading: true}
+	m := diffModel{styles: st, client: c, app: appName, filter: filter, vp: vp}
+	m.load.start()
+	return m
 }
 
 func (m diffModel) initCmd() tea.Cmd {
+	gen, ctx := m.gen, m.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	return func() tea.Msg {
-		d, err := m.client.ServerSideDiff(context.Background(), m.app)
-		return diffLoadedMsg{diffs: d, err: err}
+		d, err := m.client.ServerSideDiff(ctx, m.app)
+		return diffLoadedMsg{gen: gen, diffs: d, err: err}
 	}
 }
 
@@ -53,25 +66,41 @@ func (m *diffModel) setSize(w, h int) {
 	m.height = h
 	m.vp.Width = max(1, w)
 	m.vp.Height = max(1, h-2)
-	m.vp.SetContent(m.renderBody())
+	m.refresh()
 }
 
 func (m diffModel) Update(msg tea.Msg) (diffModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case diffLoadedMsg:
-		m.loading = false
-		m.err = msg.err
+		if msg.gen != m.gen {
+			return m, nil
+		}
+		m.load.finish(msg.err)
 		m.diffs = msg.diffs
-		m.vp.SetContent(m.renderBody())
+		m.refresh()
 		return m, nil
 	case tea.WindowSizeMsg:
 		m.setSize(msg.Width, msg.Height)
 		return m, nil
 	case tea.KeyMsg:
+		if handled, retry := m.load.handleKey(msg); handled {
+			m.refresh()
+			if retry {
+				return m, m.initCmd()
+			}
+			return m, nil
+		}
+		if m.hs.update(msg) {
+			m.refresh()
+			return m, nil
+		}
+		if scrollEnds(msg, &m.vp) {
+			return m, nil
+		}
 		switch msg.String() {
 		case "W":
 			m.showWhitespace = !m.showWhitespace
-			m.vp.SetContent(m.renderBody())
+			m.refresh()
 			return m, nil
 		}
 		var cmd tea.Cmd
@@ -89,42 +118,31 @@ func (m diffModel) View() string {
 	if m.filter != nil {
 		filter = fmt.Sprintf("  [resource:%s/%s]", m.filter.Kind, m.filter.Name)
 	}
-	head := fmt.Sprintf("Diff: %s%s  W=whitespace  esc=close", m.app, filter)
-	headStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Padding(0, 1)
-	return lipgloss.JoinVertical(lipgloss.Top, headStyle.Width(m.width).Render(head), m.vp.View())
+	head := fmt.Sprintf("Diff: %s%s%s  W=whitespace  %s  esc=close", m.app, filter, m.load.headerTag(), m.hs.hint())
+	return lipgloss.JoinVertical(lipgloss.Top, m.styles.OverlayHeader.Width(m.width).Render(head+scrollIndicator(m.vp)), m.vp.View())
+}
+
+// refresh re-renders the viewport, cut to the horizontal scroll window.
+func (m *diffModel) refresh() {
+	body := m.renderBody()
+	m.hs.fit(body, m.vp.Width)
+	m.vp.SetContent(m.hs.apply(body, m.vp.Width))
 }
 
 func (m diffModel) renderBody() string {
-	if m.loading {
-		return "Loading…"
-	}
-	if m.err != nil {
-		return "Error:\n\n" + m.err.Error()
+	if body, ok := m.load.body(); ok {
+		return body
 	}
 	if len(m.diffs) == 0 {
 		return "(no diffs)"
 	}
 
-	pick := func(d argocd.DiffResult) bool {
-		if m.filter == nil {
-			return true
-		}
-		f := *m.filter
-		return d.Ref.Kind == f.Kind && d.Ref.Name == f.Name && d.Ref.Namespace == f.Namespace && d.Ref.Group == f.Group
-	}
-
 	parts := make([]string, 0)
 	for _, d := range m.diffs {
-		if !pick(d) {
+		if m.filter != nil && !diffMatchesRef(d, *m.filter) {
 			continue
 		}
-		title := d.Ref.Kind + "/" + d.Ref.Name
-		if d.Ref.Namespace != "" {
-			title += " (" + d.Ref.Namespace + ")"
-		}
-		if d.Ref.Group != "" {
-			title = d.Ref.Group + "/" + title
-		}
+		title := diffTitle(d.Ref)
 		if d.Modified {
 			title = m.styles.StatusWarn.Render(title + " *")
 		} else {
@@ -140,6 +158,40 @@ func (m diffModel) renderBody() string {
 	return strings.Join(parts, "\n")
 }
 
+// diffMatchesRef reports whether d is the diff of ref. The API version is
+// ignored: the diff and the resource tree may name different ones.
+func diffMatchesRef(d argocd.DiffResult, ref argocd.ResourceRef) bool {
+	return d.Ref.Kind == ref.Kind && d.Ref.Name == ref.Name && d.Ref.Namespace == ref.Namespace && d.Ref.Group == ref.Group
+}
+
+// diffTitle labels a resource as [group/]kind/name (namespace).
+func diffTitle(ref argocd.ResourceRef) string {
+	title := ref.Kind + "/" + ref.Name
+	if ref.Namespace != "" {
+		title += " (" + ref.Namespace + ")"
+	}
+	if ref.Group != "" {
+		title = ref.Group + "/" + title
+	}
+	return title
+}
+
+// RenderDiffs renders the modified resources of a server-side diff for
+// output outside the TUI (`lazyargo diff`). Colors follow lipgloss's
+// detected profile, so piped output is plain text.
+func RenderDiffs(diffs []argocd.DiffResult, theme config.Theme) string {
+	st := newStyles(theme)
+	parts := make([]string, 0)
+	for _, d := range diffs {
+		if !d.Modified {
+			continue
+		}
+		title := diffTitle(d.Ref)
+		parts = append(parts, st.StatusWarn.Render(title), renderUnifiedDiff(d.Diff, false, st), "")
+	}
+	return strings.Join(parts, "\n")
+}
+
 func renderUnifiedDiff(diff string, showWhitespace bool, st styles) string {
 	if strings.TrimSpace(diff) == "" {
 		return "(empty diff)"
@@ -155,9 +207,9 @@ func renderUnifiedDiff(diff string, showWhitespace bool, st styles) string {
 		}
 		switch {
 		case strings.HasPrefix(orig, "+") && !strings.HasPrefix(orig, "+++"):
-			out = append(out, lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Render(l))
+			out = append(out, st.Success.Render(l))
 		case strings.HasPrefix(orig, "-") && !strings.HasPrefix(orig, "---"):
-			out = append(out, lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(l))
+			out = append(out, st.Error.Render(l))
 		default:
 			out = append(out, l)
 		}
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"lazyargo/internal/argocd"
+)
+
+// driftDiffConcurrency bounds the diff requests the drifted-apps view has in
+// flight at once.
+const driftDiffConcurrency = 4
+
+// driftDiffsModel shows the server-side diff of every drifted app in one
+// scrollable view, one section per app; n / N jump between apps. Diffs are
+// fetched concurrently and each app's section fills in as its diff lands.
+type driftDiffsModel struct {
+	styles styles
+	client argocd.Client
+	gen    int
+	ctx    context.Context
+
+	apps    []string
+	results map[string]driftDiffResult
+	// appLines is the body line each app's header starts on, for n / N.
+	appLines []int
+
+	width  int
+	height int
+	vp     viewport.Model
+
+	showWhitespace bool
+	hs             hScroll
+}
+
+type driftDiffResult struct {
+	diffs []argocd.DiffResult
+	err   error
+}
+
+type driftDiffLoadedMsg struct {
+	gen   int
+	app   string
+	diffs []argocd.DiffResult
+	err   error
+}
+
+func newDriftDiffsModel(st styles, c argocd.Client, apps []string) driftDiffsModel {
+	vp := viewport.New(0, 0)
+	vp.MouseWheelEnabled = false
+	return driftDiffsModel{styles: st, client: c, apps: apps, results: map[string]driftDiffResult{}, vp: vp}
+}
+
+// initCmd fetches every app's diff, at most driftDiffConcurrency at a time.
+func (m driftDiffsModel) initCmd() tea.Cmd {
+	gen, ctx, c := m.gen, m.ctx, m.client
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	sem := make(chan struct{}, driftDiffConcurrency)
+	cmds := make([]tea.Cmd, 0, len(m.apps))
+	for _, app := range m.apps {
+		cmds = append(cmds, func() tea.Msg {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			d, err := c.ServerSideDiff(ctx, app)
+			return driftDiffLoadedMsg{gen: gen, app: app, diffs: d, err: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m driftDiffsModel) loaded() int { return len(m.results) }
+
+func (m *driftDiffsModel) setSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.vp.Width = max(1, w)
+	m.vp.Height = max(1, h-2)
+	m.refresh()
+}
+
+func (m driftDiffsModel) Update(msg tea.Msg) (driftDiffsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case driftDiffLoadedMsg:
+		if msg.gen != m.gen {
+			return m, nil
+		}
+		m.results[msg.app] = driftDiffResult{diffs: msg.diffs, err: msg.err}
+		m.refresh()
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.setSize(msg.Width, msg.Height)
+		return m, nil
+	case tea.KeyMsg:
+		if m.hs.update(msg) {
+			m.refresh()
+			return m, nil
+		}
+		if scrollEnds(msg, &m.vp) {
+			return m, nil
+		}
+		switch msg.String() {
+		case "W":
+			m.showWhitespace = !m.showWhitespace
+			m.refresh()
+			return m, nil
+		case "n":
+			m.jumpApp(1)
+			return m, nil
+		case "N":
+			m.jumpApp(-1)
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.vp, cmd = m.vp.Update(msg)
+	return m, cmd
+}
+
+// jumpApp scrolls to the next (dir > 0) or previous app's section.
+func (m *driftDiffsModel) jumpApp(dir int) {
+	at := m.vp.YOffset
+	if dir > 0 {
+		for _, l := range m.appLines {
+			if l > at {
+				m.vp.SetYOffset(l)
+				return
+			}
+		}
+		return
+	}
+	for i := len(m.appLines) - 1; i >= 0; i-- {
+		if m.appLines[i] < at {
+			m.vp.SetYOffset(m.appLines[i])
+			return
+		}
+	}
+}
+
+func (m driftDiffsModel) View() string {
+	progress := ""
+	if n := m.loaded(); n < len(m.apps) {
+		progress = fmt.Sprintf("  loading %d/%d…", n, len(m.apps))
+	}
+	head := fmt.Sprintf("Diff: %d drifted apps%s  n/N=next/prev app  W=whitespace  %s  esc=close", len(m.apps), progress, m.hs.hint())
+	return lipgloss.JoinVertical(lipgloss.Top, m.styles.OverlayHeader.Width(m.width).Render(head+scrollIndicator(m.vp)), m.vp.View())
+}
+
+func (m *driftDiffsModel) refresh() {
+	body := m.renderBody()
+	m.hs.fit(body, m.vp.Width)
+	m.vp.SetContent(m.hs.apply(body, m.vp.Width))
+}
+
+// renderBody lists each app's modified resources under a header, in the
+// order the apps were given, and records where each header starts.
+func (m *driftDiffsModel) renderBody() string {
+	m.appLines = m.appLines[:0]
+	var lines []string
+	for _, app := range m.apps {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		m.appLines = append(m.appLines, len(lines))
+		res, ok := m.results[app]
+		if !ok {
+			lines = append(lines, m.styles.SidebarTitle.Render("━━ "+app), "  loading…")
+			continue
+		}
+		if res.err != nil {
+			lines = append(lines, m.styles.SidebarTitle.Render("━━ "+app), m.styles.Error.Render("  diff failed: "+errorText(res.err, false)))
+			continue
+		}
+		var parts []string
+		modified := 0
+		for _, d := range res.diffs {
+			if !d.Modified {
+				continue
+			}
+			modified++
+			parts = append(parts, m.styles.StatusWarn.Render(diffTitle(d.Ref)), renderUnifiedDiff(d.Diff, m.showWhitespace, m.styles), "")
+		}
+		lines = append(lines, m.styles.SidebarTitle.Render(fmt.Sprintf("━━ %s (%d modified)", app, modified)))
+		if modified == 0 {
+			lines = append(lines, "  (no diffs)")
+			continue
+		}
+		lines = append(lines, strings.Split(strings.TrimSuffix(strings.Join(parts, "\n"), "\n"), "\n")...)
+	}
+	return strings.Join(lines, "\n")
+}
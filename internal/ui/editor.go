@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"sigs.k8s.io/yaml"
+
+	"lazyargo/internal/argocd"
+)
+
+// editorCommand returns the user's editor command line ($VISUAL, then
+// $EDITOR), split into argv so values like "code --wait" work.
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if f := strings.Fields(os.Getenv(env)); len(f) > 0 {
+			return f
+		}
+	}
+	return nil
+}
+
+// editorPrepMsg carries a freshly fetched app manifest written to a temp
+// file, ready to hand to the editor.
+type editorPrepMsg struct {
+	name     string
+	path     string
+	original string
+	err      error
+}
+
+// editorDoneMsg is sent once the editor process exits.
+type editorDoneMsg struct {
+	name     string
+	path     string
+	original string
+	err      error
+}
+
+type editorUpdateMsg struct {
+	appName string
+	changed []string
+	err     error
+}
+
+func (m Model) editorPrepCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		manifest, err := m.client.GetApplicationRaw(context.Background(), name)
+		if err != nil {
+			return editorPrepMsg{err: err}
+		}
+		// A qualified name ("ns/app") can't go into the pattern as is.
+		f, err := os.CreateTemp("", "lazyargo-"+strings.ReplaceAll(name, "/", "_")+"-*.yaml")
+		if err != nil {
+			return editorPrepMsg{err: err}
+		}
+		defer f.Close()
+		if _, err := f.WriteString(manifest); err != nil {
+			os.Remove(f.Name())
+			return editorPrepMsg{err: err}
+		}
+		return editorPrepMsg{name: name, path: f.Name(), original: manifest}
+	}
+}
+
+// execEditorCmd suspends the TUI and runs the editor on the temp file.
+func execEditorCmd(argv []string, p editorPrepMsg) tea.Cmd {
+	c := exec.Command(argv[0], append(argv[1:], p.path)...)
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		return editorDoneMsg{name: p.name, path: p.path, original: p.original, err: err}
+	})
+}
+
+func (m Model) editorUpdateCmd(name, manifest string, changed []string) tea.Cmd {
+	return func() tea.Msg {
+		err := m.client.UpdateApplicationRaw(context.Background(), name, manifest)
+		return editorUpdateMsg{appName: name, changed: changed, err: err}
+	}
+}
+
+// handleEditorDone reads back the edited manifest and, if anything changed,
+// sends it to the server as edited, so fields lazyargo doesn't model are
+// kept. The temp file is always removed.
+func (m Model) handleEditorDone(msg editorDoneMsg) (Model, tea.Cmd) {
+	defer os.Remove(msg.path)
+	if msg.err != nil {
+		m.statusLine = fmt.Sprintf("editor failed, changes discarded: %v", msg.err)
+		return m, nil
+	}
+	b, err := os.ReadFile(msg.path)
+	if err != nil {
+		m.statusLine = fmt.Sprintf("read edited spec: %v", err)
+		return m, nil
+	}
+	if sameYAML(string(b), msg.original) {
+		m.statusLine = "no changes"
+		return m, nil
+	}
+	edited, err := argocd.ParseApplicationYAML(string(b))
+	if err != nil {
+		m.statusLine = fmt.Sprintf("edit discarded: %v", err)
+		return m, nil
+	}
+	original, err := argocd.ParseApplicationYAML(msg.original)
+	if err != nil {
+		m.statusLine = fmt.Sprintf("edit discarded: %v", err)
+		return m, nil
+	}
+	// The manifest names the object the PUT replaces, so it must still be
+	// this app, namespace included.
+	if edited.Name != original.Name || edited.AppObjectNamespace != original.AppObjectNamespace {
+		m.statusLine = fmt.Sprintf("edit discarded: renaming %s is not supported", msg.name)
+		return m, nil
+	}
+	changed := changedAppFields(original, edited)
+	if len(changed) == 0 {
+		changed = []string{"other fields"}
+	}
+	m.statusLine = fmt.Sprintf("updating %s…", msg.name)
+	return m, m.editorUpdateCmd(msg.name, string(b), changed)
+}
+
+// sameYAML reports whether a and b hold the same document, so an edit that
+// only reformats the file isn't sent.
+func sameYAML(a, b string) bool {
+	var va, vb any
+	if yaml.Unmarshal([]byte(a), &va) != nil || yaml.Unmarshal([]byte(b), &vb) != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// changedAppFields lists the spec fields that differ between a and b, by
+// their manifest names.
+func changedAppFields(a, b argocd.Application) []string {
+	fields := []struct {
+		name string
+		a, b string
+	}{
+		{"project", a.Project, b.Project},
+		{"repoURL", a.RepoURL, b.RepoURL},
+		{"path", a.Path, b.Path},
+		{"targetRevision", a.Revision, b.Revision},
+		{"destination.server", a.Cluster, b.Cluster},
+		{"destination.namespace", a.Namespace, b.Namespace},
+		{"syncPolicy", blankIfEmpty(a.SyncPolicy, "manual"), blankIfEmpty(b.SyncPolicy, "manual")},
+	}
+	out := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f.a != f.b {
+			out = append(out, f.name)
+		}
+	}
+	return out
+}
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"lazyargo/internal/argocd"
+	"lazyargo/internal/config"
+)
+
+func TestChangedAppFields(t *testing.T) {
+	base := argocd.Application{Name: "a", Project: "default", RepoURL: "https://git.example/r", Path: "p", Revision: "main", Cluster: "https://kubernetes.default.svc", Namespace: "web"}
+	tests := []struct {
+		name string
+		edit func(*argocd.Application)
+		want []string
+	}{
+		{name: "unchanged", edit: func(*argocd.Application) {}, want: []string{}},
+		{name: "revision", edit: func(a *argocd.Application) { a.Revision = "v2" }, want: []string{"targetRevision"}},
+		{name: "destination", edit: func(a *argocd.Application) { a.Cluster, a.Namespace = "https://other", "api" }, want: []string{"destination.server", "destination.namespace"}},
+		{name: "empty sync policy is manual", edit: func(a *argocd.Application) { a.SyncPolicy = "manual" }, want: []string{}},
+		{name: "auto sync", edit: func(a *argocd.Application) { a.SyncPolicy = "auto" }, want: []string{"syncPolicy"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := base
+			tt.edit(&b)
+			if got := changedAppFields(base, b); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("changedAppFields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_handleEditorDone(t *testing.T) {
+	// Fields lazyargo doesn't model must reach the server as they were.
+	const original = `apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  finalizers:
+  - resources-finalizer.argocd.argoproj.io
+  name: guestbook
+  namespace: team-a
+  resourceVersion: "42"
+spec:
+  destination:
+    name: in-cluster
+    namespace: web
+  ignoreDifferences:
+  - group: apps
+    jsonPointers:
+    - /spec/replicas
+    kind: Deployment
+  project: default
+  source:
+    helm:
+      parameters:
+      - name: image.tag
+        value: "1.0"
+    path: p
+    repoURL: https://git.example/r
+    targetRevision: main
+  syncPolicy:
+    retry:
+      limit: 3
+    syncOptions:
+    - CreateNamespace=true
+`
+
+	tests := []struct {
+		name        string
+		edited      string
+		wantStatus  string
+		wantChanged []string
+	}{
+		{name: "untouched", edited: original, wantStatus: "no changes"},
+		{name: "reformatted only", edited: original + "\n", wantStatus: "no changes"},
+		{name: "parse error", edited: "metadata: [", wantStatus: "edit discarded: invalid YAML"},
+		{name: "rename", edited: strings.Replace(original, "name: guestbook", "name: other", 1), wantStatus: "edit discarded: renaming team-a/guestbook"},
+		{name: "namespace dropped", edited: strings.Replace(original, "  namespace: team-a\n", "", 1), wantStatus: "edit discarded: renaming team-a/guestbook"},
+		{name: "revision", edited: strings.Replace(original, "targetRevision: main", "targetRevision: v2", 1), wantStatus: "updating team-a/guestbook", wantChanged: []string{"targetRevision"}},
+		{name: "unmodelled field", edited: strings.Replace(original, `value: "1.0"`, `value: "1.1"`, 1), wantStatus: "updating team-a/guestbook", wantChanged: []string{"other fields"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "app.yaml")
+			if err := os.WriteFile(path, []byte(tt.edited), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			client := &fakeClient{}
+			m := NewModel(config.Default(), client)
+			m, cmd := m.handleEditorDone(editorDoneMsg{name: "team-a/guestbook", path: path, original: original})
+			if !strings.HasPrefix(m.statusLine, tt.wantStatus) {
+				t.Fatalf("status = %q, want prefix %q", m.statusLine, tt.wantStatus)
+			}
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Fatalf("expected the temp file to be removed")
+			}
+			if tt.wantChanged == nil {
+				if cmd != nil {
+					t.Fatalf("expected no update")
+				}
+				return
+			}
+			msg, ok := cmd().(editorUpdateMsg)
+			if !ok || msg.appName != "team-a/guestbook" || !reflect.DeepEqual(msg.changed, tt.wantChanged) {
+				t.Fatalf("update = %+v", msg)
+			}
+			if client.updatedRaw != tt.edited {
+				t.Fatalf("expected the edited manifest sent as is, got\n%s", client.updatedRaw)
+			}
+		})
+	}
+}
+
+func TestModel_editorPrepQualifiedName(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	msg, ok := m.editorPrepCmd("team-a/guestbook")().(editorPrepMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("prep = %+v", msg)
+	}
+	defer os.Remove(msg.path)
+	if !strings.Contains(msg.original, "name: guestbook") || !strings.Contains(msg.original, "namespace: team-a") {
+		t.Fatalf("expected the name split into metadata, got\n%s", msg.original)
+	}
+}
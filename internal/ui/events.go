@@ -3,7 +3,9 @@ package ui
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,14 +18,22 @@ type eventsModel struct {
 	styles styles
 	client argocd.Client
 	app    string
+	// allApps merges the events of every application (app is unused).
+	allApps bool
 
 	width  int
 	height int
 	vp     viewport.Model
 
-	loading bool
-	err     error
-	events  []argocd.Event
+	load   loadState
+	events []argocd.Event
+
+	// absTime shows absolute timestamps instead of "2m ago".
+	absTime bool
+	// warningsOnly hides Normal events; oldestFirst flips the default
+	// newest-first order. Both are applied to events at render time.
+	warningsOnly bool
+	oldestFirst  bool
 }
 
 type eventsLoadedMsg struct {
@@ -34,11 +44,24 @@ type eventsLoadedMsg struct {
 func newEventsModel(st styles, c argocd.Client, appName string) eventsModel {
 	vp := viewport.New(0, 0)
 	vp.MouseWheelEnabled = false
-	return eventsModel{styles: st, client: c, app: appName, vp: vp, loading: true}
+	m := eventsModel{styles: st, client: c, app: appName, vp: vp}
+	m.load.start()
+	return m
+}
+
+// newAllEventsModel builds the fleet-wide view over every application's events.
+func newAllEventsModel(st styles, c argocd.Client) eventsModel {
+	m := newEventsModel(st, c, "")
+	m.allApps = true
+	return m
 }
 
 func (m eventsModel) initCmd() tea.Cmd {
 	return func() tea.Msg {
+		if m.allApps {
+			ev, err := m.client.ListApplicationEvents(context.Background())
+			return eventsLoadedMsg{events: ev, err: err}
+		}
 		ev, err := m.client.ListEvents(context.Background(), m.app)
 		return eventsLoadedMsg{events: ev, err: err}
 	}
@@ -55,8 +78,7 @@ func (m *eventsModel) setSize(w, h int) {
 func (m eventsModel) Update(msg tea.Msg) (eventsModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case eventsLoadedMsg:
-		m.loading = false
-		m.err = msg.err
+		m.load.finish(msg.err)
 		m.events = msg.events
 		m.vp.SetContent(m.renderBody())
 		return m, nil
@@ -65,6 +87,32 @@ func (m eventsModel) Update(msg tea.Msg) (eventsModel, tea.Cmd) {
 		return m, nil
 	case tea.KeyMsg:
 		// parent handles esc/q
+		if handled, retry := m.load.handleKey(msg); handled {
+			m.vp.SetContent(m.renderBody())
+			if retry {
+				return m, m.initCmd()
+			}
+			return m, nil
+		}
+		if scrollEnds(msg, &m.vp) {
+			return m, nil
+		}
+		switch msg.String() {
+		case "t":
+			m.absTime = !m.absTime
+			m.vp.SetContent(m.renderBody())
+			return m, nil
+		case "w":
+			m.warningsOnly = !m.warningsOnly
+			m.vp.SetContent(m.renderBody())
+			m.vp.GotoTop()
+			return m, nil
+		case "o":
+			m.oldestFirst = !m.oldestFirst
+			m.vp.SetContent(m.renderBody())
+			m.vp.GotoTop()
+			return m, nil
+		}
 		var cmd tea.Cmd
 		m.vp, cmd = m.vp.Update(msg)
 		return m, cmd
@@ -76,44 +124,83 @@ func (m eventsModel) Update(msg tea.Msg) (eventsModel, tea.Cmd) {
 }
 
 func (m eventsModel) View() string {
-	head := fmt.Sprintf("Events: %s  esc=close", m.app)
-	headStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Padding(0, 1)
-	return lipgloss.JoinVertical(lipgloss.Top, headStyle.Width(m.width).Render(head), m.vp.View())
+	title := m.app
+	if m.allApps {
+		title = "all apps"
+	}
+	head := fmt.Sprintf("Events: %s%s  [w=%s]  [o=%s]  [t=%s]  esc=close",
+		title,
+		m.load.headerTag(),
+		map[bool]string{false: "all", true: "warnings only"}[m.warningsOnly],
+		map[bool]string{false: "newest first", true: "oldest first"}[m.oldestFirst],
+		map[bool]string{false: "relative", true: "absolute"}[m.absTime],
+	)
+	return lipgloss.JoinVertical(lipgloss.Top, m.styles.OverlayHeader.Width(m.width).Render(head+scrollIndicator(m.vp)), m.vp.View())
 }
 
 func (m eventsModel) renderBody() string {
-	if m.loading {
-		return "Loading…"
-	}
-	if m.err != nil {
-		return "Error:\n\n" + m.err.Error()
+	if body, ok := m.load.body(); ok {
+		return body
 	}
 	if len(m.events) == 0 {
 		return "(no events)"
 	}
-	lines := make([]string, 0, len(m.events))
-	for _, e := range m.events {
-		ts := strings.TrimSpace(e.Timestamp)
-		if ts == "" {
-			ts = "—"
-		}
-		typ := strings.TrimSpace(e.Type)
-		if typ == "" {
-			typ = "—"
-		}
-		reason := strings.TrimSpace(e.Reason)
-		msg := strings.TrimSpace(e.Message)
+	events := m.visibleEvents()
+	if len(events) == 0 {
+		return fmt.Sprintf("(no warning events; %d normal hidden — press w to show all)", len(m.events))
+	}
+	// Two lines per event: time/type/reason/message, then the involved
+	// object on its own line so long messages can't push it off-screen.
+	now := time.Now()
+	tsW := 8
+	if m.absTime {
+		tsW = 19
+	}
+	w := max(20, m.width)
+	lines := make([]string, 0, len(events)*2)
+	for _, e := range events {
+		ts := formatTimestamp(e.Timestamp, now, m.absTime)
+		typ := blankIfEmpty(strings.TrimSpace(e.Type), "—")
+		reason := truncate(strings.TrimSpace(e.Reason), 18)
+		msg := strings.Join(strings.Fields(e.Message), " ")
 		obj := strings.TrimSpace(e.InvolvedObject)
-		line := fmt.Sprintf("%s  %-7s %-18s %s", ts, typ, reason, msg)
-		if obj != "" {
-			line += " (" + obj + ")"
+		if m.allApps && e.App != "" {
+			obj = strings.TrimSpace(e.App + ": " + obj)
 		}
+		line := truncate(fmt.Sprintf("%-*s  %-7s %-18s %s", tsW, ts, typ, reason, msg), w)
 
 		style := m.styles.StatusValue
 		if strings.EqualFold(typ, "warning") {
 			style = m.styles.StatusWarn
 		}
 		lines = append(lines, style.Render(line))
+		if obj != "" {
+			lines = append(lines, truncate(strings.Repeat(" ", tsW+2)+"↳ "+obj, w))
+		}
 	}
 	return strings.Join(lines, "\n")
 }
+
+// visibleEvents applies the warning filter and time ordering to the raw events.
+// Events without a parseable timestamp sort last in either direction.
+func (m eventsModel) visibleEvents() []argocd.Event {
+	out := make([]argocd.Event, 0, len(m.events))
+	for _, e := range m.events {
+		if m.warningsOnly && !strings.EqualFold(strings.TrimSpace(e.Type), "warning") {
+			continue
+		}
+		out = append(out, e)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		ti, okI := parseTimestamp(out[i].Timestamp)
+		tj, okJ := parseTimestamp(out[j].Timestamp)
+		if okI != okJ {
+			return okI
+		}
+		if m.oldestFirst {
+			return ti.Before(tj)
+		}
+		return ti.After(tj)
+	})
+	return out
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"lazyargo/internal/argocd"
+	"lazyargo/internal/config"
+)
+
+func TestEventsModel_visibleEvents(t *testing.T) {
+	m := newEventsModel(newStyles(config.Theme{}), nil, "a")
+	m.events = []argocd.Event{
+		{Type: "Normal", Reason: "old", Timestamp: "2024-05-01T10:00:00Z"},
+		{Type: "Warning", Reason: "undated"},
+		{Type: "Warning", Reason: "new", Timestamp: "2024-05-01T12:00:00Z"},
+		{Type: "Warning", Reason: "mid", Timestamp: "2024-05-01T11:00:00Z"},
+	}
+
+	reasons := func() []string {
+		var out []string
+		for _, e := range m.visibleEvents() {
+			out = append(out, e.Reason)
+		}
+		return out
+	}
+	assert := func(want ...string) {
+		t.Helper()
+		got := reasons()
+		if len(got) != len(want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+		}
+	}
+
+	assert("new", "mid", "old", "undated")
+	m.oldestFirst = true
+	assert("old", "mid", "new", "undated")
+	m.warningsOnly = true
+	assert("mid", "new", "undated")
+}
+
+func TestLogsEventsModel_splitAndFocus(t *testing.T) {
+	m := newLogsEventsModel(newStyles(config.Theme{}), nil, "app", "pod-1")
+	m.setSize(80, 41)
+	if got := m.events.height + m.logs.height; got != 40 {
+		t.Fatalf("expected panes to share 40 rows, got %d", got)
+	}
+	before := m.events.height
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	if m.events.height <= before {
+		t.Fatalf("expected + to grow the events pane, got %d (was %d)", m.events.height, before)
+	}
+	for i := 0; i < 10; i++ {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+	}
+	if m.split != logsEventsSplitMin {
+		t.Fatalf("expected split clamped to %d, got %d", logsEventsSplitMin, m.split)
+	}
+
+	// Keys reach the focused pane only.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if !m.events.warningsOnly || m.logs.wrap {
+		t.Fatalf("expected w to toggle the events filter only")
+	}
+}
+
+// followClient streams pod logs that never end on their own, like a
+// followed pod, and hands each stream's context to the test.
+type followClient struct {
+	*fakeClient
+	streams chan context.Context
+}
+
+func (f followClient) PodLogs(ctx context.Context, appName, podName, container string, follow bool) (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		<-ctx.Done()
+		pw.CloseWithError(ctx.Err())
+	}()
+	f.streams <- ctx
+	return pr, nil
+}
+
+func TestModel_closingLogsEventsStopsStream(t *testing.T) {
+	c := followClient{fakeClient: &fakeClient{}, streams: make(chan context.Context, 1)}
+	m := NewModel(config.Default(), c)
+	le := newLogsEventsModel(m.styles, c, "app", "pod-1")
+	le.logs.startStreamCmd()()
+	m.logsEventsView = &le
+
+	var ctx context.Context
+	select {
+	case ctx = <-c.streams:
+	case <-time.After(time.Second):
+		t.Fatal("expected the log stream to start")
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.logsEventsView != nil {
+		t.Fatal("expected esc to close logs+events")
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected closing the view to cancel the log stream")
+	}
+}
+
+func TestEventsModel_retryAfterError(t *testing.T) {
+	m := newEventsModel(newStyles(config.Theme{}), nil, "a")
+	m, _ = m.Update(eventsLoadedMsg{err: errors.New("boom")})
+	if !strings.Contains(m.renderBody(), "r to retry") {
+		t.Fatalf("expected a retry hint, got %q", m.renderBody())
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if cmd == nil || !m.load.loading() || m.load.err != nil {
+		t.Fatalf("expected r to re-issue the load")
+	}
+	if got := m.renderBody(); got != "Retrying…" {
+		t.Fatalf("expected retrying body, got %q", got)
+	}
+
+	m, _ = m.Update(eventsLoadedMsg{events: []argocd.Event{{Type: "Normal", Reason: "ok"}}})
+	if m.load.retrying || m.load.loading() || strings.Contains(m.renderBody(), "Retrying") {
+		t.Fatalf("expected the retry to settle, got %q", m.renderBody())
+	}
+}
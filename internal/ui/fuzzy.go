@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Fuzzy score tiers: any exact substring match outranks every subsequence
+// match, and an exact name outranks both.
+const (
+	fuzzyExactScore     = 20000
+	fuzzySubstringScore = 10000
+)
+
+// fuzzyScore matches query against target fzf-style: every query rune must
+// appear in target in order (case-insensitive). Higher is better. Substring
+// matches score by position and word boundary; subsequence matches reward
+// runs of consecutive runes and runes at word starts, and pay for gaps.
+func fuzzyScore(query, target string) (int, bool) {
+	score, _, ok := fuzzyMatch(query, target)
+	return score, ok
+}
+
+// fuzzyMatch is fuzzyScore that also returns the rune indices of target that
+// matched, for highlighting.
+func fuzzyMatch(query, target string) (int, []int, bool) {
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(target))
+	if len(q) == 0 {
+		return 0, nil, true
+	}
+	if len(q) > len(t) {
+		return 0, nil, false
+	}
+
+	if string(q) == string(t) {
+		return fuzzyExactScore, runeSpan(0, len(t)), true
+	}
+	if i := strings.Index(string(t), string(q)); i >= 0 {
+		pos := utf8.RuneCountInString(string(t)[:i])
+		score := fuzzySubstringScore + max(0, 1000-pos-(len(t)-len(q)))
+		if isWordStart(t, pos) {
+			score += 100
+		}
+		return score, runeSpan(pos, len(q)), true
+	}
+
+	score := 0
+	prev := -1
+	qi := 0
+	positions := make([]int, 0, len(q))
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+		score += 10
+		switch {
+		case prev >= 0 && ti == prev+1:
+			score += 15
+		case isWordStart(t, ti):
+			score += 10
+		}
+		if prev >= 0 {
+			score -= min(ti-prev-1, 5)
+		}
+		prev = ti
+		positions = append(positions, ti)
+		qi++
+	}
+	if qi < len(q) {
+		return 0, nil, false
+	}
+	return min(score, fuzzySubstringScore-1), positions, true
+}
+
+func runeSpan(start, n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = start + i
+	}
+	return out
+}
+
+// highlightRunes renders s with base, switching to hl for the runes at the
+// given (sorted) indices.
+func highlightRunes(s string, positions []int, base, hl lipgloss.Style) string {
+	if len(positions) == 0 {
+		return base.Render(s)
+	}
+	var b strings.Builder
+	var run []rune
+	inMatch := false
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		if inMatch {
+			b.WriteString(hl.Render(string(run)))
+		} else {
+			b.WriteString(base.Render(string(run)))
+		}
+		run = run[:0]
+	}
+	pi := 0
+	for i, r := range []rune(s) {
+		match := pi < len(positions) && positions[pi] == i
+		if match {
+			pi++
+		}
+		if match != inMatch {
+			flush()
+			inMatch = match
+		}
+		run = append(run, r)
+	}
+	flush()
+	return b.String()
+}
+
+// isWordStart reports whether t[i] begins a word: the first rune, or one
+// following a separator common in app names.
+func isWordStart(t []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	switch t[i-1] {
+	case '-', '_', '.', '/', ' ':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestFuzzyScore_matches(t *testing.T) {
+	tests := []struct {
+		query, target string
+		want          bool
+	}{
+		{"", "anything", true},
+		{"pmapi", "payments-api", true},
+		{"PMAPI", "payments-api", true},
+		{"api", "payments-api", true},
+		{"ipa", "payments-api", false},
+		{"payments-api-v2", "payments-api", false},
+		{"xyz", "payments-api", false},
+	}
+	for _, tt := range tests {
+		if _, ok := fuzzyScore(tt.query, tt.target); ok != tt.want {
+			t.Errorf("fuzzyScore(%q, %q) matched=%v, want %v", tt.query, tt.target, ok, tt.want)
+		}
+	}
+}
+
+func TestFuzzyScore_ranking(t *testing.T) {
+	// Each pair: the first target should outrank the second for the query.
+	tests := []struct {
+		query         string
+		better, worse string
+	}{
+		{"api", "api", "payments-api"},                       // exact beats substring
+		{"api", "payments-api", "a-p-i"},                     // substring beats subsequence
+		{"pay", "payments", "repayments"},                    // earlier substring wins
+		{"web", "web-frontend", "cobweb"},                    // word-start substring wins
+		{"pmapi", "payments-api", "pxmxaxpxi-things"},        // consecutive runs beat scattered runes
+		{"oa", "orders-api", "xoxxxxxxxxxxxxxxxxxxxxxxxa"},   // fewer gaps win
+		{"ow", "orders-worker", "foxwood"},                   // word starts win
+		{"api", "a-very-long-name-with-api-inside", "a-p-i"}, // substring tier holds for long names
+	}
+	for _, tt := range tests {
+		b, okB := fuzzyScore(tt.query, tt.better)
+		w, okW := fuzzyScore(tt.query, tt.worse)
+		if !okB || !okW {
+			t.Fatalf("%q: expected both %q and %q to match", tt.query, tt.better, tt.worse)
+		}
+		if b <= w {
+			t.Errorf("%q: %q (%d) should outrank %q (%d)", tt.query, tt.better, b, tt.worse, w)
+		}
+	}
+}
+
+func TestFuzzyMatch_positions(t *testing.T) {
+	tests := []struct {
+		query, target string
+		want          []int
+	}{
+		{"api", "payments-api", []int{9, 10, 11}},
+		{"pmapi", "payments-api", []int{0, 3, 9, 10, 11}},
+		{"web", "WEB", []int{0, 1, 2}},
+		{"", "web", nil},
+	}
+	for _, tt := range tests {
+		_, got, ok := fuzzyMatch(tt.query, tt.target)
+		if !ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("fuzzyMatch(%q, %q) = %v (ok=%v), want %v", tt.query, tt.target, got, ok, tt.want)
+		}
+	}
+}
+
+func TestHighlightRunes_plain(t *testing.T) {
+	plain := lipgloss.NewStyle()
+	if got := highlightRunes("payments-api", []int{0, 9, 10, 11}, plain, plain); got != "payments-api" {
+		t.Fatalf("expected unstyled segments to reassemble the name, got %q", got)
+	}
+}
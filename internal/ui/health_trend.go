@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// healthTrendSize is how many list refreshes of health each app keeps.
+const healthTrendSize = 24
+
+// healthSample is an app's health as of one list refresh.
+type healthSample struct {
+	at     time.Time
+	health string
+}
+
+// healthTrend keeps the last healthTrendSize samples per app for the
+// detail panel's trend line. It lives only as long as the session.
+type healthTrend map[string][]healthSample
+
+// record adds a sample, dropping the oldest once the app has a full ring.
+func (t healthTrend) record(app, health string, at time.Time) {
+	s := append(t[app], healthSample{at: at, health: health})
+	if len(s) > healthTrendSize {
+		// Copy down rather than reslice so the backing array stays bounded.
+		s = append(s[:0], s[len(s)-healthTrendSize:]...)
+	}
+	t[app] = s
+}
+
+// prune forgets apps that are no longer listed.
+func (t healthTrend) prune(listed map[string]string) {
+	for app := range t {
+		if _, ok := listed[app]; !ok {
+			delete(t, app)
+		}
+	}
+}
+
+// healthLevel maps a health status to a bar height: healthy is full, broken
+// is the floor.
+func healthLevel(h string) rune {
+	switch h {
+	case "Healthy":
+		return '█'
+	case "Progressing":
+		return '▅'
+	case "Suspended":
+		return '▃'
+	case "Degraded", "Missing":
+		return '▁'
+	}
+	return '·'
+}
+
+// render draws the app's samples oldest first, followed by how often its
+// health changed, so flapping apps stand out. It returns "" until there are
+// two samples.
+func (t healthTrend) render(app string, now time.Time, st styles) string {
+	s := t[app]
+	if len(s) < 2 {
+		return ""
+	}
+	var b strings.Builder
+	changes := 0
+	for i, smp := range s {
+		if i > 0 && smp.health != s[i-1].health {
+			changes++
+		}
+		bar := string(healthLevel(smp.health))
+		switch smp.health {
+		case "Healthy":
+			bar = st.Success.Render(bar)
+		case "Degraded", "Missing":
+			bar = st.Error.Render(bar)
+		}
+		b.WriteString(bar)
+	}
+	summary := "steady"
+	switch {
+	case changes == 1:
+		summary = "1 change"
+	case changes > 1:
+		summary = fmt.Sprintf("%d changes", changes)
+	}
+	return fmt.Sprintf("%s  %s, %d refreshes, first %s", b.String(), summary, len(s), relativeTime(s[0].at, now))
+}
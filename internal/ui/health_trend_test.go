@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"lazyargo/internal/config"
+)
+
+func TestHealthTrend_boundedPerApp(t *testing.T) {
+	tr := healthTrend{}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < healthTrendSize+10; i++ {
+		tr.record("a", "Healthy", start.Add(time.Duration(i)*time.Minute))
+	}
+	if got := len(tr["a"]); got != healthTrendSize {
+		t.Fatalf("kept %d samples, want %d", got, healthTrendSize)
+	}
+	if cap(tr["a"]) > 2*healthTrendSize {
+		t.Fatalf("backing array grew to %d", cap(tr["a"]))
+	}
+	if first := tr["a"][0].at; !first.Equal(start.Add(10 * time.Minute)) {
+		t.Fatalf("expected the oldest samples to be dropped, first is %v", first)
+	}
+
+	tr.record("gone", "Healthy", start)
+	tr.prune(map[string]string{"a": "Healthy"})
+	if _, ok := tr["gone"]; ok {
+		t.Fatalf("expected apps no longer listed to be pruned")
+	}
+}
+
+func TestHealthTrend_render(t *testing.T) {
+	st := newStyles(config.Default().UI.Theme)
+	tr := healthTrend{}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if got := tr.render("a", start, st); got != "" {
+		t.Fatalf("expected no trend without samples, got %q", got)
+	}
+	for i, h := range []string{"Healthy", "Degraded", "Healthy", "Progressing"} {
+		tr.record("a", h, start.Add(time.Duration(i)*time.Minute))
+	}
+	got := tr.render("a", start.Add(5*time.Minute), st)
+	for _, want := range []string{"█", "▁", "▅", "3 changes, 4 refreshes, first 5m ago"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("render = %q, want it to contain %q", got, want)
+		}
+	}
+}
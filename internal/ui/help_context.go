@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// helpContext is what the keyboard is currently talking to; the footer
+// shows the keys that work there.
+type helpContext int
+
+const (
+	helpContextList helpContext = iota
+	helpContextResources
+	helpContextInput
+	helpContextOverlay
+	helpContextCheatsheet
+	helpContextSyncModal
+	helpContextConfirm
+	helpContextWizard
+	helpContextRawCreate
+)
+
+// helpContext mirrors the order in which Update hands keys out: the
+// cheatsheet, then overlays, then modals, then inputs.
+func (m Model) helpContext() helpContext {
+	switch {
+	case m.cheatsheet != nil:
+		return helpContextCheatsheet
+	case m.resourceDetails != nil, m.eventsView != nil, m.logsView != nil, m.logsEventsView != nil,
+		m.debugView != nil, m.diffView != nil, m.driftDiffs != nil, m.revisionDiff != nil, m.revisionView != nil,
+		m.historyView != nil, m.actionsView != nil, m.dashboardView != nil, m.compareView != nil,
+		m.actionOutput != nil:
+		return helpContextOverlay
+	case m.deleteModal, m.resourceDeleteModal, m.terminateModal, m.rollbackModal:
+		return helpContextConfirm
+	case m.editModal, m.createModal:
+		return helpContextWizard
+	case m.rawCreateModal:
+		return helpContextRawCreate
+	case m.syncModal:
+		return helpContextSyncModal
+	case m.filterActive, m.gotoActive, m.resourceFilterActive:
+		return helpContextInput
+	case m.focusResources:
+		return helpContextResources
+	default:
+		return helpContextList
+	}
+}
+
+// hint is a help-only binding for keys handled outside the keyMap.
+func hint(keys, desc string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys), key.WithHelp(keys, desc))
+}
+
+// bindings is a fixed help.KeyMap for one context.
+type bindings []key.Binding
+
+func (b bindings) ShortHelp() []key.Binding  { return b }
+func (b bindings) FullHelp() [][]key.Binding { return [][]key.Binding{b} }
+
+// footerHelp returns the keys to show in the footer for the current context.
+func (m Model) footerHelp() help.KeyMap {
+	switch m.helpContext() {
+	case helpContextResources:
+		return bindings{hint("tab", "apps"), m.keys.Up, m.keys.Down, hint("space", "collapse"), hint("z", "zoom"),
+			hint("/", "filter"), m.keys.Sort, hint("enter", "view"), hint("l", "logs"), hint("a", "actions"), m.keys.Cheatsheet}
+	case helpContextInput:
+		return bindings{hint("enter", "apply"), hint("esc", "clear")}
+	case helpContextOverlay:
+		return bindings{hint("esc", "close"), hint("g/G", "top/bottom")}
+	case helpContextCheatsheet:
+		return bindings{hint("F1/esc", "close"), hint("g/G", "top/bottom")}
+	case helpContextSyncModal:
+		if m.syncWatch != nil {
+			return bindings{hint("esc", "close")}
+		}
+		return bindings{hint("y", "sync"), hint("w", "sync+watch"), hint("n/esc", "cancel")}
+	case helpContextConfirm:
+		if m.deleteModal || m.resourceDeleteModal {
+			return bindings{hint("enter", "delete"), hint("esc", "cancel")}
+		}
+		return bindings{hint("enter", "choose"), hint("y", "confirm"), hint("n/esc", "cancel")}
+	case helpContextWizard:
+		return bindings{hint("enter", "next"), hint("←", "back"), hint("esc", "cancel")}
+	case helpContextRawCreate:
+		return bindings{hint("ctrl+s", "submit"), hint("esc", "cancel")}
+	default:
+		return m.keys
+	}
+}
@@ -0,0 +1,208 @@
+package ui
+
+import "strings"
+
+// highlightMaxBytes disables highlighting for very large manifests; tokenizing
+// and styling every line would make the viewer sluggish.
+const highlightMaxBytes = 256 * 1024
+
+// highlightYAML applies basic syntax coloring to a YAML document.
+// It works line by line and never fails: anything it doesn't recognize is left as-is.
+func highlightYAML(s string, hl syntaxStyles) string {
+	if len(s) > highlightMaxBytes {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = highlightYAMLLine(l, hl)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func highlightYAMLLine(l string, hl syntaxStyles) string {
+	trimmed := strings.TrimLeft(l, " ")
+	indent := l[:len(l)-len(trimmed)]
+	if trimmed == "" {
+		return l
+	}
+	if strings.HasPrefix(trimmed, "#") {
+		return indent + hl.Comment.Render(trimmed)
+	}
+	if trimmed == "---" || trimmed == "..." {
+		return indent + hl.Punct.Render(trimmed)
+	}
+
+	// Sequence markers ("- ", possibly nested).
+	var b strings.Builder
+	b.WriteString(indent)
+	for strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+		b.WriteString(hl.Punct.Render("-"))
+		if trimmed == "-" {
+			return b.String()
+		}
+		b.WriteString(" ")
+		trimmed = trimmed[2:]
+	}
+
+	body, comment := splitYAMLComment(trimmed)
+	if k, rest, ok := splitYAMLKey(body); ok {
+		b.WriteString(hl.Key.Render(k))
+		b.WriteString(hl.Punct.Render(":"))
+		if rest != "" {
+			v := strings.TrimLeft(rest, " ")
+			b.WriteString(rest[:len(rest)-len(v)])
+			b.WriteString(highlightYAMLScalar(v, hl))
+		}
+	} else {
+		b.WriteString(highlightYAMLScalar(body, hl))
+	}
+	if comment != "" {
+		b.WriteString(hl.Comment.Render(comment))
+	}
+	return b.String()
+}
+
+// splitYAMLKey splits "key: value" (or "key:") outside of quotes.
+func splitYAMLKey(s string) (key, rest string, ok bool) {
+	if s == "" || s[0] == '{' || s[0] == '[' {
+		return "", "", false
+	}
+	if q := s[0]; q == '"' || q == '\'' {
+		end := strings.IndexByte(s[1:], q)
+		if end < 0 {
+			return "", "", false
+		}
+		after := s[end+2:]
+		if after == ":" || strings.HasPrefix(after, ": ") {
+			return s[:end+2], after[1:], true
+		}
+		return "", "", false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] != ':' {
+			continue
+		}
+		if i == len(s)-1 || s[i+1] == ' ' {
+			return s[:i], s[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// splitYAMLComment separates a trailing " # comment" that isn't inside quotes.
+func splitYAMLComment(s string) (body, comment string) {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && i > 0 && s[i-1] == ' ':
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
+
+func highlightYAMLScalar(v string, hl syntaxStyles) string {
+	t := strings.TrimRight(v, " ")
+	switch {
+	case t == "":
+		return v
+	case t == "|" || t == ">" || strings.HasPrefix(t, "|-") || strings.HasPrefix(t, ">-") || t == "{}" || t == "[]":
+		return hl.Punct.Render(v)
+	case t[0] == '"' || t[0] == '\'':
+		return hl.String.Render(v)
+	case isYAMLBoolOrNull(t):
+		return hl.Bool.Render(v)
+	case isNumber(t):
+		return hl.Number.Render(v)
+	default:
+		return hl.String.Render(v)
+	}
+}
+
+func isYAMLBoolOrNull(s string) bool {
+	switch strings.ToLower(s) {
+	case "true", "false", "null", "~", "yes", "no", "on", "off":
+		return true
+	}
+	return false
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	digits := 0
+	for i, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			digits++
+		case (c == '-' || c == '+') && i == 0:
+		case c == '.' || c == 'e' || c == 'E':
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
+
+// highlightJSON applies basic syntax coloring to (pretty-printed) JSON.
+func highlightJSON(s string, hl syntaxStyles) string {
+	if len(s) > highlightMaxBytes {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(s))
+			tok := s[i:end]
+			// A string followed by ':' is an object key.
+			j := end
+			for j < len(s) && (s[j] == ' ' || s[j] == '\t') {
+				j++
+			}
+			if j < len(s) && s[j] == ':' {
+				b.WriteString(hl.Key.Render(tok))
+			} else {
+				b.WriteString(hl.String.Render(tok))
+			}
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(s) && strings.IndexByte("0123456789.eE+-", s[end]) >= 0 {
+				end++
+			}
+			b.WriteString(hl.Number.Render(s[i:end]))
+			i = end
+		case strings.HasPrefix(s[i:], "true"), strings.HasPrefix(s[i:], "null"):
+			b.WriteString(hl.Bool.Render(s[i : i+4]))
+			i += 4
+		case strings.HasPrefix(s[i:], "false"):
+			b.WriteString(hl.Bool.Render(s[i : i+5]))
+			i += 5
+		case strings.IndexByte("{}[]:,", c) >= 0:
+			b.WriteString(hl.Punct.Render(string(c)))
+			i++
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"testing"
+
+	"lazyargo/internal/config"
+)
+
+func TestSplitYAMLKey(t *testing.T) {
+	tests := []struct {
+		in       string
+		wantKey  string
+		wantRest string
+		wantOK   bool
+	}{
+		{in: "name: web", wantKey: "name", wantRest: " web", wantOK: true},
+		{in: "metadata:", wantKey: "metadata", wantRest: "", wantOK: true},
+		{in: `"a:b": c`, wantKey: `"a:b"`, wantRest: " c", wantOK: true},
+		{in: "image: nginx:1.25", wantKey: "image", wantRest: " nginx:1.25", wantOK: true},
+		{in: "http://example.com", wantOK: false},
+		{in: "{a: b}", wantOK: false},
+	}
+	for _, tt := range tests {
+		k, rest, ok := splitYAMLKey(tt.in)
+		if ok != tt.wantOK || k != tt.wantKey || rest != tt.wantRest {
+			t.Fatalf("splitYAMLKey(%q) = (%q, %q, %v), want (%q, %q, %v)", tt.in, k, rest, ok, tt.wantKey, tt.wantRest, tt.wantOK)
+		}
+	}
+}
+
+func TestSplitYAMLComment(t *testing.T) {
+	body, comment := splitYAMLComment(`msg: "a # b" # trailing`)
+	if body != `msg: "a # b" ` || comment != "# trailing" {
+		t.Fatalf("got body=%q comment=%q", body, comment)
+	}
+}
+
+func TestHighlightSkipsLargeInput(t *testing.T) {
+	big := make([]byte, highlightMaxBytes+1)
+	for i := range big {
+		big[i] = 'a'
+	}
+	if got := highlightYAML(string(big), newStyles(config.Theme{}).Syntax); got != string(big) {
+		t.Fatalf("expected large input to be returned unchanged")
+	}
+}
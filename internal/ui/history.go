@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -13,30 +15,139 @@ import (
 
 type historyModel struct {
 	styles styles
+	client argocd.Client
+	gen    int
+	ctx    context.Context
 
-	app argocd.Application
+	app  argocd.Application
+	load loadState
+	// messages are the commit messages of the history's revisions, fetched
+	// once the history itself is loaded; msgs is that fetch's state.
+	messages map[string]string
+	msgs     loadState
 
 	width  int
 	height int
 	vp     viewport.Model
 
 	selected int
+	// marked is the entry marked with space as one side of a diff (d); -1
+	// when none is.
+	marked int
+}
+
+// historyEntryLines is how many lines renderBody spends per entry: the
+// summary, the message, the author and a blank separator.
+const historyEntryLines = 4
+
+type historyLoadedMsg struct {
+	gen int
+	app argocd.Application
+	err error
+}
+
+// historyMessagesMsg carries the commit messages that could be fetched; err
+// reports the ones that could not.
+type historyMessagesMsg struct {
+	gen      int
+	messages map[string]string
+	err      error
 }
 
-func newHistoryModel(st styles, app argocd.Application) historyModel {
+// newHistoryModel shows app's history. When loaded is false (the list entry
+// carries no history), the app is fetched by initCmd first.
+func newHistoryModel(st styles, c argocd.Client, app argocd.Application, loaded bool) historyModel {
 	vp := viewport.New(0, 0)
 	vp.MouseWheelEnabled = false
-	m := historyModel{styles: st, app: app, vp: vp}
+	m := historyModel{styles: st, client: c, app: app, vp: vp, marked: -1}
+	if loaded {
+		m.load.finish(nil)
+		m.startMessages()
+	} else {
+		m.load.start()
+	}
 	m.vp.SetContent(m.renderBody())
 	return m
 }
 
+func (m historyModel) initCmd() tea.Cmd {
+	if !m.load.loading() {
+		return m.messagesCmd()
+	}
+	name, gen, ctx := m.app.Name, m.gen, m.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return func() tea.Msg {
+		app, err := m.client.GetApplication(ctx, name)
+		return historyLoadedMsg{gen: gen, app: app, err: err}
+	}
+}
+
+// missingMessages lists the revisions whose commit message is still to be
+// fetched.
+func (m historyModel) missingMessages() []string {
+	var revs []string
+	for _, h := range m.app.History {
+		rev := strings.TrimSpace(h.Revision)
+		if rev == "" || h.Message != "" || slices.Contains(revs, rev) {
+			continue
+		}
+		if _, ok := m.messages[rev]; !ok {
+			revs = append(revs, rev)
+		}
+	}
+	return revs
+}
+
+// startMessages marks the commit message fetch as in flight, when there is
+// anything to fetch.
+func (m *historyModel) startMessages() {
+	if len(m.missingMessages()) > 0 {
+		m.msgs.start()
+	}
+}
+
+// messagesCmd fetches the commit messages startMessages found missing. A
+// failed revision doesn't stop the rest; the first error is reported.
+func (m historyModel) messagesCmd() tea.Cmd {
+	if !m.msgs.loading() {
+		return nil
+	}
+	revs, name, gen, ctx, c := m.missingMessages(), m.app.Name, m.gen, m.ctx, m.client
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return func() tea.Msg {
+		out := make(map[string]string, len(revs))
+		var firstErr error
+		failed := 0
+		for _, rev := range revs {
+			meta, err := c.RevisionMetadata(ctx, name, rev)
+			if err != nil {
+				failed++
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			out[rev] = meta.Message
+		}
+		var err error
+		if firstErr != nil {
+			err = fmt.Errorf("%d of %d commit messages: %w", failed, len(revs), firstErr)
+		}
+		return historyMessagesMsg{gen: gen, messages: out, err: err}
+	}
+}
+
 func (m *historyModel) setSize(w, h int) {
 	m.width = w
 	m.height = h
 	m.vp.Width = max(1, w)
 	m.vp.Height = max(1, h-2)
 	m.vp.SetContent(m.renderBody())
+	m.ensureVisible()
 }
 
 func (m historyModel) Update(msg tea.Msg) (historyModel, tea.Cmd) {
@@ -44,7 +155,48 @@ func (m historyModel) Update(msg tea.Msg) (historyModel, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.setSize(msg.Width, msg.Height)
 		return m, nil
+	case historyLoadedMsg:
+		if msg.gen != m.gen {
+			return m, nil
+		}
+		m.load.finish(msg.err)
+		if msg.err != nil {
+			m.vp.SetContent(m.renderBody())
+			return m, nil
+		}
+		m.app = msg.app
+		m.selected = 0
+		m.marked = -1
+		m.startMessages()
+		m.vp.SetContent(m.renderBody())
+		return m, m.messagesCmd()
+	case historyMessagesMsg:
+		if msg.gen != m.gen {
+			return m, nil
+		}
+		m.msgs.finish(msg.err)
+		if m.messages == nil {
+			m.messages = map[string]string{}
+		}
+		for rev, text := range msg.messages {
+			m.messages[rev] = text
+		}
+		m.vp.SetContent(m.renderBody())
+		return m, nil
 	case tea.KeyMsg:
+		if handled, retry := m.load.handleKey(msg); handled {
+			m.vp.SetContent(m.renderBody())
+			if retry {
+				return m, m.initCmd()
+			}
+			return m, nil
+		}
+		if m.msgs.failed() && msg.String() == "r" {
+			m.msgs.start()
+			m.msgs.retrying = true
+			m.vp.SetContent(m.renderBody())
+			return m, m.messagesCmd()
+		}
 		switch msg.String() {
 		case "up", "k":
 			if m.selected > 0 {
@@ -53,6 +205,14 @@ func (m historyModel) Update(msg tea.Msg) (historyModel, tea.Cmd) {
 				m.ensureVisible()
 			}
 			return m, nil
+		case " ":
+			if m.marked == m.selected {
+				m.marked = -1
+			} else if m.selected < len(m.app.History) {
+				m.marked = m.selected
+			}
+			m.vp.SetContent(m.renderBody())
+			return m, nil
 		case "down", "j":
 			if m.selected < len(m.app.History)-1 {
 				m.selected++
@@ -60,6 +220,15 @@ func (m historyModel) Update(msg tea.Msg) (historyModel, tea.Cmd) {
 				m.ensureVisible()
 			}
 			return m, nil
+		case "g", "G":
+			// The selection drives the scroll here, so move it to the ends.
+			m.selected = 0
+			if msg.String() == "G" {
+				m.selected = max(0, len(m.app.History)-1)
+			}
+			m.vp.SetContent(m.renderBody())
+			m.ensureVisible()
+			return m, nil
 		}
 		var cmd tea.Cmd
 		m.vp, cmd = m.vp.Update(msg)
@@ -72,12 +241,26 @@ func (m historyModel) Update(msg tea.Msg) (historyModel, tea.Cmd) {
 }
 
 func (m historyModel) View() string {
-	head := fmt.Sprintf("History: %s  enter=details  esc=close", m.app.Name)
-	headStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Padding(0, 1)
-	return lipgloss.JoinVertical(lipgloss.Top, headStyle.Width(m.width).Render(head), m.vp.View())
+	head := fmt.Sprintf("History: %s%s%s  enter=details  space=mark  d=diff  esc=close", m.app.Name, m.load.headerTag(), m.messagesTag())
+	return lipgloss.JoinVertical(lipgloss.Top, m.styles.OverlayHeader.Width(m.width).Render(head+scrollIndicator(m.vp)), m.vp.View())
+}
+
+// messagesTag reports the commit message fetch in the header.
+func (m historyModel) messagesTag() string {
+	switch {
+	case m.msgs.loading():
+		return "  [loading commit messages…]"
+	case m.msgs.failed():
+		line, _, _ := strings.Cut(errorText(m.msgs.err, false), "\n")
+		return "  [" + line + "; r=retry]"
+	}
+	return ""
 }
 
 func (m historyModel) renderBody() string {
+	if body, ok := m.load.body(); ok {
+		return body
+	}
 	if len(m.app.History) == 0 {
 		lines := []string{"(no history in application status)"}
 		if m.app.OperationState != nil {
@@ -94,35 +277,75 @@ func (m historyModel) renderBody() string {
 	for i, h := range m.app.History {
 		prefix := "  "
 		st := m.styles.StatusValue
+		if i == m.marked {
+			prefix = "◆ "
+		}
 		if i == m.selected {
 			prefix = "▶ "
 			st = m.styles.SidebarSelected
 		}
 		when := blankIfEmpty(h.DeployedAt, "—")
 		status := blankIfEmpty(h.Status, "—")
-		msg := strings.TrimSpace(h.Message)
-		if msg == "" {
-			msg = "—"
+		text := h.Message
+		if text == "" {
+			text = m.messages[strings.TrimSpace(h.Revision)]
 		}
+		// Commit messages can run long; the first line is the summary.
+		msg, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+		msg = blankIfEmpty(msg, "—")
 		rev := blankIfEmpty(h.Revision, "—")
 		who := blankIfEmpty(h.Source, "—")
-		lines = append(lines, st.Render(fmt.Sprintf("%s%s  %s  %s", prefix, when, status, rev)))
-		lines = append(lines, "    "+msg)
+		lines = append(lines, st.Render(fmt.Sprintf("%s%s  %s  %s", prefix, when, rev, status)))
+		lines = append(lines, "    "+truncate(msg, max(20, m.width-4)))
 		lines = append(lines, "    by: "+who, "")
 	}
 	return strings.Join(lines, "\n")
 }
 
+// ensureVisible scrolls just enough to keep the selected entry (its three
+// lines) on screen.
 func (m *historyModel) ensureVisible() {
-	// crude: keep the selected line near top.
 	if m.selected < 0 {
 		m.selected = 0
 	}
-	m.vp.SetYOffset(max(0, m.selected*4-2))
+	top := m.selected * historyEntryLines
+	if m.app.OperationState != nil {
+		// The operation banner and its blank line sit above the entries.
+		top += 2
+	}
+	bottom := top + historyEntryLines - 2
+	switch {
+	case top < m.vp.YOffset:
+		m.vp.SetYOffset(top)
+	case bottom >= m.vp.YOffset+m.vp.Height:
+		m.vp.SetYOffset(max(top, bottom-m.vp.Height+1))
+	}
 }
 
-func (m historyModel) SelectedRevision() string {
-	if len(m.app.History) == 0 {
+// diffRevisions returns the revisions to compare, older first: the marked
+// entry and the selected one, or without a mark the selected entry and the
+// one deployed before it.
+func (m historyModel) diffRevisions() (from, to string, ok bool) {
+	if m.load.phase != loadLoaded || len(m.app.History) == 0 {
+		return "", "", false
+	}
+	sel := clamp(m.selected, 0, len(m.app.History)-1)
+	other := sel - 1
+	if m.marked >= 0 && m.marked < len(m.app.History) && m.marked != sel {
+		other = m.marked
+	}
+	if other < 0 {
+		return "", "", false
+	}
+	// History is oldest first.
+	older, newer := min(sel, other), max(sel, other)
+	from = strings.TrimSpace(m.app.History[older].Revision)
+	to = strings.TrimSpace(m.app.History[newer].Revision)
+	return from, to, from != "" && to != ""
+}
+
+func (m historyModel) selectedRevision() string {
+	if m.load.phase != loadLoaded || len(m.app.History) == 0 {
 		return ""
 	}
 	idx := clamp(m.selected, 0, len(m.app.History)-1)
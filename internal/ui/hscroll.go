@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// hScrollStep is how many columns one left/right key press scrolls.
+const hScrollStep = 8
+
+// hScroll adds horizontal scrolling to a viewport-based viewer. The
+// viewport wraps lines wider than itself, so the viewer renders its content
+// through apply, which cuts every line to the visible column window.
+type hScroll struct {
+	x int
+}
+
+// update handles h/l, the arrow keys and 0 (back to column 0). It reports
+// whether the key was consumed; the caller should re-render.
+func (h *hScroll) update(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "h", "left":
+		h.x = max(0, h.x-hScrollStep)
+	case "l", "right":
+		h.x += hScrollStep
+	case "0":
+		h.x = 0
+	default:
+		return false
+	}
+	return true
+}
+
+// fit clamps the offset so the widest line of s still reaches the right edge
+// of a width-column window.
+func (h *hScroll) fit(s string, width int) {
+	widest := 0
+	for _, l := range strings.Split(s, "\n") {
+		widest = max(widest, lipgloss.Width(l))
+	}
+	h.x = clamp(h.x, 0, max(0, widest-width))
+}
+
+// apply cuts every line of s to the columns [x, x+width).
+func (h hScroll) apply(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = sliceColumns(l, h.x, width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// hint is the header fragment for the scroll keys.
+func (h hScroll) hint() string {
+	if h.x > 0 {
+		return fmt.Sprintf("←/→ col %d", h.x+1)
+	}
+	return "←/→=scroll"
+}
+
+// sliceColumns returns the cells [off, off+width) of a styled line. Escape
+// sequences are kept wherever they fall so colors stay intact around the cut.
+func sliceColumns(s string, off, width int) string {
+	if off == 0 && lipgloss.Width(s) <= width {
+		return s
+	}
+	var b strings.Builder
+	col := 0
+	rs := []rune(s)
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		if r == '\x1b' {
+			// Copy a CSI sequence (ESC [ ... final byte) verbatim.
+			j := i + 1
+			if j < len(rs) && rs[j] == '[' {
+				j++
+				for j < len(rs) && (rs[j] < 0x40 || rs[j] > 0x7e) {
+					j++
+				}
+			}
+			end := min(j+1, len(rs))
+			b.WriteString(string(rs[i:end]))
+			i = end - 1
+			continue
+		}
+		w := lipgloss.Width(string(r))
+		if col >= off && col+w <= off+width {
+			b.WriteRune(r)
+		}
+		col += w
+	}
+	return b.String()
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSliceColumns(t *testing.T) {
+	tests := []struct {
+		in         string
+		off, width int
+		want       string
+	}{
+		{"abcdef", 0, 10, "abcdef"},
+		{"abcdef", 2, 3, "cde"},
+		{"abcdef", 10, 3, ""},
+		// Escape sequences survive the cut, so the color still applies.
+		{"\x1b[31mabcdef\x1b[0m", 2, 2, "\x1b[31mcd\x1b[0m"},
+	}
+	for _, tt := range tests {
+		if got := sliceColumns(tt.in, tt.off, tt.width); got != tt.want {
+			t.Errorf("sliceColumns(%q, %d, %d) = %q, want %q", tt.in, tt.off, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestHScroll_fitClampsToWidestLine(t *testing.T) {
+	var h hScroll
+	for i := 0; i < 10; i++ {
+		h.update(tea.KeyMsg{Type: tea.KeyRight})
+	}
+	h.fit("short\n0123456789012345", 10)
+	if h.x != 6 {
+		t.Fatalf("expected offset clamped to 6, got %d", h.x)
+	}
+	if got := h.apply("short\n0123456789012345", 10); got != "\n6789012345" {
+		t.Fatalf("unexpected window %q", got)
+	}
+}
@@ -9,32 +9,49 @@ type keyMap struct {
 	RefreshDetail key.Binding
 	RefreshHard   key.Binding
 	Diff          key.Binding
+	RefreshDiff   key.Binding
+	DiffDrifted   key.Binding
 	History       key.Binding
 	ToggleDrift   key.Binding
+	Pin           key.Binding
+	PinnedOnly    key.Binding
 	SyncBatch     key.Binding
 	SyncApp       key.Binding
 	Rollback      key.Binding
 	TerminateOp   key.Binding
 	DeleteApp     key.Binding
 	CreateApp     key.Binding
+	CreateAppRaw  key.Binding
 	EditApp       key.Binding
+	EditInEditor  key.Binding
+	Dashboard     key.Binding
+	ExportCSV     key.Binding
+	MarkCompare   key.Binding
+	Compare       key.Binding
+	OpenBrowser   key.Binding
 	Filter        key.Binding
+	GoTo          key.Binding
 	Sort          key.Binding
+	Group         key.Binding
+	SidebarNarrow key.Binding
+	SidebarWiden  key.Binding
 	Clear         key.Binding
 	Help          key.Binding
+	Cheatsheet    key.Binding
 	Quit          key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Up, k.Down, k.Refresh, k.RefreshDetail, k.RefreshHard, k.Diff, k.History, k.ToggleDrift, k.SyncBatch, k.SyncApp, k.Rollback, k.TerminateOp, k.DeleteApp, k.CreateApp, k.EditApp, k.Filter, k.Sort, k.Help, k.Quit}
+	return []key.Binding{k.Up, k.Down, k.Refresh, k.RefreshDetail, k.RefreshHard, k.Diff, k.RefreshDiff, k.DiffDrifted, k.History, k.ToggleDrift, k.Pin, k.PinnedOnly, k.SyncBatch, k.SyncApp, k.Rollback, k.TerminateOp, k.DeleteApp, k.CreateApp, k.CreateAppRaw, k.EditApp, k.EditInEditor, k.Dashboard, k.ExportCSV, k.MarkCompare, k.Compare, k.OpenBrowser, k.Filter, k.GoTo, k.Sort, k.Group, k.SidebarNarrow, k.SidebarWiden, k.Help, k.Cheatsheet, k.Quit}
 }
 
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down},
-		{k.Refresh, k.RefreshDetail, k.RefreshHard, k.Diff, k.History},
-		{k.ToggleDrift, k.SyncBatch, k.SyncApp, k.Rollback, k.TerminateOp, k.DeleteApp, k.CreateApp, k.EditApp, k.Filter, k.Sort, k.Clear, k.Diff, k.History},
-		{k.Help, k.Quit},
+		{k.Up, k.Down, k.GoTo},
+		{k.Refresh, k.RefreshDetail, k.RefreshHard, k.Diff, k.RefreshDiff, k.DiffDrifted, k.History, k.Dashboard, k.ExportCSV, k.MarkCompare, k.Compare, k.OpenBrowser},
+		{k.ToggleDrift, k.Pin, k.PinnedOnly, k.SyncBatch, k.SyncApp, k.Rollback, k.TerminateOp, k.DeleteApp, k.CreateApp, k.CreateAppRaw, k.EditApp, k.EditInEditor, k.Filter, k.Sort, k.Group, k.Clear, k.Diff, k.History},
+		{k.SidebarNarrow, k.SidebarWiden},
+		{k.Help, k.Cheatsheet, k.Quit},
 	}
 }
 
@@ -68,17 +85,34 @@ func newKeyMap() keyMap {
 			key.WithKeys("R"),
 			key.WithHelp("R", "hard refresh"),
 		),
+		RefreshDiff: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "hard refresh + diff"),
+		),
+		DiffDrifted: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "diff all drifted"),
+		),
 		ToggleDrift: key.NewBinding(
 			key.WithKeys("D"),
 			key.WithHelp("D", "drift only"),
 		),
+		Pin: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "pin app"),
+		),
+		PinnedOnly: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "pinned only"),
+		),
 		SyncBatch: key.NewBinding(
 			key.WithKeys("s"),
 			key.WithHelp("s", "sync drifted"),
 		),
 		SyncApp: key.NewBinding(
-			key.WithKeys("y"),
-			key.WithHelp("y", "sync app"),
+			// Not "y": that key is reserved for confirming inside modals.
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "sync app"),
 		),
 		Rollback: key.NewBinding(
 			key.WithKeys("b"),
@@ -96,18 +130,62 @@ func newKeyMap() keyMap {
 			key.WithKeys("c"),
 			key.WithHelp("c", "create app"),
 		),
+		CreateAppRaw: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "create from YAML"),
+		),
+		Dashboard: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "overview"),
+		),
+		ExportCSV: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "export CSV"),
+		),
+		MarkCompare: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "mark for compare"),
+		),
+		Compare: key.NewBinding(
+			key.WithKeys("="),
+			key.WithHelp("=", "compare marked"),
+		),
+		OpenBrowser: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open in browser"),
+		),
 		EditApp: key.NewBinding(
 			key.WithKeys("e"),
 			key.WithHelp("e", "edit app"),
 		),
+		EditInEditor: key.NewBinding(
+			key.WithKeys("ctrl+e"),
+			key.WithHelp("ctrl+e", "edit in $EDITOR"),
+		),
 		Filter: key.NewBinding(
 			key.WithKeys("/"),
 			key.WithHelp("/", "filter"),
 		),
+		GoTo: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "go to app"),
+		),
 		Sort: key.NewBinding(
 			key.WithKeys("S"),
 			key.WithHelp("S", "sort"),
 		),
+		Group: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "group by"),
+		),
+		SidebarNarrow: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "narrow sidebar"),
+		),
+		SidebarWiden: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "widen sidebar"),
+		),
 		Clear: key.NewBinding(
 			key.WithKeys("esc"),
 			key.WithHelp("esc", "clear filter"),
@@ -116,6 +194,10 @@ func newKeyMap() keyMap {
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
 		),
+		Cheatsheet: key.NewBinding(
+			key.WithKeys("f1"),
+			key.WithHelp("F1", "all keybindings"),
+		),
 		Quit: key.NewBinding(
 			key.WithKeys("q", "ctrl+c"),
 			key.WithHelp("q", "quit"),
@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"lazyargo/internal/config"
+)
+
+const (
+	// minPaneWidth is the narrowest the sidebar or the main pane may get.
+	minPaneWidth = 20
+	// sidebarResizeStep is how many columns one </> press moves the split.
+	sidebarResizeStep = 2
+)
+
+// paneWidths splits the terminal between the sidebar and the main pane,
+// keeping both at least minPaneWidth wide.
+func (m Model) paneWidths() (sidebar, main int) {
+	sidebar = max(m.sidebarWidth, minPaneWidth)
+	main = m.width - sidebar
+	if main < minPaneWidth {
+		main = minPaneWidth
+		sidebar = max(minPaneWidth, m.width-main)
+	}
+	return sidebar, main
+}
+
+// bodyHeight is the height left for the sidebar and main pane between the
+// header and the footer.
+func (m Model) bodyHeight() int {
+	return max(0, m.height-lipgloss.Height(m.renderHeader())-lipgloss.Height(m.renderFooter(m.width)))
+}
+
+// sidebarListRows is how many app rows renderSidebar has room for: the body
+// minus the sidebar border, its title and rule, and the error line if any.
+func (m Model) sidebarListRows() int {
+	rows := m.bodyHeight() - m.styles.Sidebar.GetVerticalFrameSize() - 2
+	if m.err != nil {
+		rows--
+	}
+	return max(0, rows)
+}
+
+// overlaySize is the space an overlay gets inside the main pane: the pane
+// minus its border and padding, and the body minus the border.
+func (m Model) overlaySize() (w, h int) {
+	_, main := m.paneWidths()
+	return max(1, main-4), max(1, m.bodyHeight()-m.styles.Main.GetVerticalFrameSize())
+}
+
+// resizeOverlays re-lays out whichever overlays are open after the terminal
+// or the sidebar changed size.
+func (m *Model) resizeOverlays() {
+	w, h := m.overlaySize()
+	if m.resourceDetails != nil {
+		rd := *m.resourceDetails
+		rd.setSize(w, h)
+		m.resourceDetails = &rd
+	}
+	if m.eventsView != nil {
+		ev := *m.eventsView
+		ev.setSize(w, h)
+		m.eventsView = &ev
+	}
+	if m.logsView != nil {
+		lv := *m.logsView
+		lv.setSize(w, h)
+		m.logsView = &lv
+	}
+	if m.logsEventsView != nil {
+		le := *m.logsEventsView
+		le.setSize(w, h)
+		m.logsEventsView = &le
+	}
+	if m.debugView != nil {
+		dbg := *m.debugView
+		dbg.setSize(w, h)
+		m.debugView = &dbg
+	}
+	if m.actionOutput != nil {
+		ao := *m.actionOutput
+		ao.setSize(w, h)
+		m.actionOutput = &ao
+	}
+	if m.diffView != nil {
+		dv := *m.diffView
+		dv.setSize(w, h)
+		m.diffView = &dv
+	}
+	if m.driftDiffs != nil {
+		dd := *m.driftDiffs
+		dd.setSize(w, h)
+		m.driftDiffs = &dd
+	}
+	if m.historyView != nil {
+		hv := *m.historyView
+		hv.setSize(w, h)
+		m.historyView = &hv
+	}
+	if m.revisionView != nil {
+		rv := *m.revisionView
+		rv.setSize(w, h)
+		m.revisionView = &rv
+	}
+	if m.revisionDiff != nil {
+		rd := *m.revisionDiff
+		rd.setSize(w, h)
+		m.revisionDiff = &rd
+	}
+	if m.actionsView != nil {
+		av := *m.actionsView
+		av.setSize(w, h)
+		m.actionsView = &av
+	}
+	if m.dashboardView != nil {
+		dv := *m.dashboardView
+		dv.setSize(w, h)
+		m.dashboardView = &dv
+	}
+	if m.compareView != nil {
+		cv := *m.compareView
+		cv.setSize(w, h)
+		m.compareView = &cv
+	}
+	if m.cheatsheet != nil {
+		cs := *m.cheatsheet
+		cs.setSize(w, h)
+		m.cheatsheet = &cs
+	}
+}
+
+// resizeSidebar widens (delta > 0) or narrows the sidebar and persists the
+// new width to the state file.
+func (m *Model) resizeSidebar(delta int) tea.Cmd {
+	w := max(m.sidebarWidth+delta, minPaneWidth)
+	if m.width > 0 {
+		w = min(w, max(minPaneWidth, m.width-minPaneWidth))
+	}
+	if w == m.sidebarWidth {
+		m.statusLine = fmt.Sprintf("sidebar width %d (limit)", w)
+		return nil
+	}
+	m.sidebarWidth = w
+	m.state.SidebarWidth = w
+	m.resizeOverlays()
+	m.statusLine = fmt.Sprintf("sidebar width %d", w)
+	return m.saveStateCmd()
+}
+
+type stateSavedMsg struct{ err error }
+
+func (m Model) saveStateCmd() tea.Cmd {
+	path, st := m.cfg.StateFile, m.state
+	return func() tea.Msg {
+		return stateSavedMsg{err: config.SaveState(path, st)}
+	}
+}
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// lineDiffMaxCells bounds the LCS table; past it the changed middle of the
+// two texts is shown as one removal and one addition.
+const lineDiffMaxCells = 4_000_000
+
+// diffOp is one step of an edit script: ' ' keeps a line, '-' removes it
+// from the old text and '+' adds it from the new one.
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// lineDiff returns a unified diff of a and b with context lines around each
+// change, or "" when they are equal. The output renders with
+// renderUnifiedDiff.
+func lineDiff(a, b, fromName, toName string, context int) string {
+	if a == b {
+		return ""
+	}
+	al := strings.Split(strings.TrimSuffix(a, "\n"), "\n")
+	bl := strings.Split(strings.TrimSuffix(b, "\n"), "\n")
+
+	ops := make([]diffOp, 0, len(al)+len(bl))
+
+	pre := 0
+	for pre < len(al) && pre < len(bl) && al[pre] == bl[pre] {
+		ops = append(ops, diffOp{' ', al[pre]})
+		pre++
+	}
+	suf := 0
+	for suf < len(al)-pre && suf < len(bl)-pre && al[len(al)-1-suf] == bl[len(bl)-1-suf] {
+		suf++
+	}
+	am, bm := al[pre:len(al)-suf], bl[pre:len(bl)-suf]
+
+	if len(am)*len(bm) > lineDiffMaxCells {
+		for _, l := range am {
+			ops = append(ops, diffOp{'-', l})
+		}
+		for _, l := range bm {
+			ops = append(ops, diffOp{'+', l})
+		}
+	} else {
+		// lcs[i][j] is the LCS length of am[i:] and bm[j:].
+		lcs := make([][]int, len(am)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(bm)+1)
+		}
+		for i := len(am) - 1; i >= 0; i-- {
+			for j := len(bm) - 1; j >= 0; j-- {
+				if am[i] == bm[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < len(am) || j < len(bm) {
+			switch {
+			case i < len(am) && j < len(bm) && am[i] == bm[j]:
+				ops = append(ops, diffOp{' ', am[i]})
+				i++
+				j++
+			case i < len(am) && (j == len(bm) || lcs[i+1][j] >= lcs[i][j+1]):
+				ops = append(ops, diffOp{'-', am[i]})
+				i++
+			default:
+				ops = append(ops, diffOp{'+', bm[j]})
+				j++
+			}
+		}
+	}
+	for _, l := range al[len(al)-suf:] {
+		ops = append(ops, diffOp{' ', l})
+	}
+
+	out := []string{"--- " + fromName, "+++ " + toName}
+	for start := 0; start < len(ops); {
+		// Find the next change and the run of changes near it.
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		last := first
+		for k := first; k < len(ops); k++ {
+			if ops[k].kind != ' ' {
+				last = k
+			} else if k-last > 2*context {
+				break
+			}
+		}
+		from, to := max(start, first-context), min(len(ops), last+context+1)
+		line := 1
+		for _, o := range ops[:from] {
+			if o.kind != '+' {
+				line++
+			}
+		}
+		out = append(out, fmt.Sprintf("@@ line %d @@", line))
+		for _, o := range ops[from:to] {
+			out = append(out, string(o.kind)+o.line)
+		}
+		start = to
+	}
+	return strings.Join(out, "\n")
+}
+
+// diffLineCounts counts the added and removed lines of a lineDiff result.
+func diffLineCounts(d string) (added, removed int) {
+	for _, l := range strings.Split(d, "\n") {
+		switch {
+		case strings.HasPrefix(l, "+++ "), strings.HasPrefix(l, "--- "):
+		case strings.HasPrefix(l, "+"):
+			added++
+		case strings.HasPrefix(l, "-"):
+			removed++
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,22 @@
+package ui
+
+import "testing"
+
+func TestLineDiff(t *testing.T) {
+	a := "a\nb\nc\nd\ne\nf\ng\nh\n"
+	b := "a\nb\nc\nD\ne\nf\ng\nh\ni\n"
+	want := "--- old\n+++ new\n@@ line 3 @@\n c\n-d\n+D\n e\n@@ line 8 @@\n h\n+i"
+	if got := lineDiff(a, b, "old", "new", 1); got != want {
+		t.Fatalf("unexpected diff:\n%s\nwant:\n%s", got, want)
+	}
+	if got := lineDiff(a, a, "old", "new", 1); got != "" {
+		t.Fatalf("expected no diff for equal input, got %q", got)
+	}
+}
+
+func TestDiffLineCounts(t *testing.T) {
+	d := lineDiff("a\nb\nc\n", "a\nB\nc\nd\n", "old", "new", 1)
+	if added, removed := diffLineCounts(d); added != 2 || removed != 1 {
+		t.Fatalf("diffLineCounts = +%d -%d, want +2 -1\n%s", added, removed, d)
+	}
+}
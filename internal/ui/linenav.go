@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// lineNav adds optional line numbers and a ":<n>" go-to-line prompt to a
+// viewport-based viewer. Content lines map 1:1 to viewport offsets (the
+// viewport doesn't wrap), so line n lives at YOffset n-1.
+type lineNav struct {
+	gutter  lipgloss.Style
+	numbers bool
+	prompt  bool
+	input   textinput.Model
+}
+
+func newLineNav(st styles) lineNav {
+	ti := textinput.New()
+	ti.Placeholder = "line"
+	ti.Prompt = ":"
+	ti.CharLimit = 9
+	ti.Width = 10
+	return lineNav{gutter: st.Gutter, input: ti}
+}
+
+// decorate prefixes each line of s with a right-aligned line number gutter
+// when line numbers are enabled.
+func (n lineNav) decorate(s string) string {
+	if !n.numbers {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	w := len(strconv.Itoa(len(lines)))
+	for i, l := range lines {
+		lines[i] = n.gutter.Render(fmt.Sprintf("%*d │ ", w, i+1)) + l
+	}
+	return strings.Join(lines, "\n")
+}
+
+// gutterWidth is how many columns decorate adds in front of each line of s.
+func (n lineNav) gutterWidth(s string) int {
+	if !n.numbers {
+		return 0
+	}
+	return len(strconv.Itoa(strings.Count(s, "\n")+1)) + len(" │ ")
+}
+
+// update handles the line-number toggle ('#') and the go-to-line prompt (':').
+// It reports whether the key was consumed; when redraw is true the caller
+// should re-render its content (the gutter changed).
+func (n *lineNav) update(msg tea.KeyMsg, vp *viewport.Model) (handled, redraw bool, cmd tea.Cmd) {
+	if n.prompt {
+		switch msg.String() {
+		case "esc":
+			n.closePrompt()
+			return true, false, nil
+		case "enter":
+			if line, err := strconv.Atoi(strings.TrimSpace(n.input.Value())); err == nil {
+				vp.SetYOffset(clamp(line, 1, max(1, vp.TotalLineCount())) - 1)
+			}
+			n.closePrompt()
+			return true, false, nil
+		}
+		n.input, cmd = n.input.Update(msg)
+		return true, false, cmd
+	}
+
+	switch msg.String() {
+	case "#":
+		n.numbers = !n.numbers
+		return true, true, nil
+	case ":":
+		n.prompt = true
+		n.input.SetValue("")
+		n.input.Focus()
+		return true, false, nil
+	}
+	return false, false, nil
+}
+
+// scrollEnds handles g (top) and G (bottom) the same way in every viewport
+// overlay. It reports whether the key was consumed.
+func scrollEnds(msg tea.KeyMsg, vp *viewport.Model) bool {
+	switch msg.String() {
+	case "g":
+		vp.GotoTop()
+	case "G":
+		vp.GotoBottom()
+	default:
+		return false
+	}
+	return true
+}
+
+// scrollIndicator is the header fragment locating the viewport in its
+// content, e.g. "  L12-40/350 8%"; it is empty when everything fits.
+func scrollIndicator(vp viewport.Model) string {
+	total := vp.TotalLineCount()
+	if total <= vp.Height {
+		return ""
+	}
+	last := min(total, vp.YOffset+vp.Height)
+	return fmt.Sprintf("  L%d-%d/%d %d%%", vp.YOffset+1, last, total, int(vp.ScrollPercent()*100))
+}
+
+func (n *lineNav) closePrompt() {
+	n.prompt = false
+	n.input.Blur()
+	n.input.SetValue("")
+}
+
+// capturingInput reports whether keystrokes should go to the prompt.
+func (n lineNav) capturingInput() bool { return n.prompt }
+
+// hint is the header fragment describing the line navigation keys, or the
+// prompt itself while it is open.
+func (n lineNav) hint() string {
+	if n.prompt {
+		return "go to " + n.input.View()
+	}
+	return "#=lines  :=goto"
+}
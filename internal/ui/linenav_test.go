@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"lazyargo/internal/config"
+)
+
+func TestLineNav_gotoLine(t *testing.T) {
+	vp := viewport.New(20, 3)
+	lines := make([]string, 50)
+	for i := range lines {
+		lines[i] = "line"
+	}
+	vp.SetContent(strings.Join(lines, "\n"))
+
+	n := newLineNav(newStyles(config.Theme{}))
+	keys := []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{':'}},
+		{Type: tea.KeyRunes, Runes: []rune{'1'}},
+		{Type: tea.KeyRunes, Runes: []rune{'2'}},
+		{Type: tea.KeyEnter},
+	}
+	for _, k := range keys {
+		if handled, _, _ := n.update(k, &vp); !handled {
+			t.Fatalf("expected key %q to be handled", k.String())
+		}
+	}
+	if vp.YOffset != 11 {
+		t.Fatalf("expected offset 11 for line 12, got %d", vp.YOffset)
+	}
+	if n.capturingInput() {
+		t.Fatalf("expected prompt to close after enter")
+	}
+}
+
+func TestLineNav_decorate(t *testing.T) {
+	n := newLineNav(newStyles(config.Theme{}))
+	if got := n.decorate("a\nb"); got != "a\nb" {
+		t.Fatalf("expected no gutter when disabled, got %q", got)
+	}
+	n.numbers = true
+	got := strings.Split(n.decorate(strings.Repeat("x\n", 9)+"x"), "\n")
+	if !strings.HasPrefix(got[0], " 1 │ ") || !strings.HasPrefix(got[9], "10 │ ") {
+		t.Fatalf("unexpected gutter: %q / %q", got[0], got[9])
+	}
+}
+
+func TestScrollEnds(t *testing.T) {
+	vp := viewport.New(20, 3)
+	vp.SetContent(strings.Repeat("line\n", 49) + "line")
+
+	if !scrollEnds(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}}, &vp) || !vp.AtBottom() {
+		t.Fatalf("expected G to go to the bottom, offset %d", vp.YOffset)
+	}
+	if !scrollEnds(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}}, &vp) || vp.YOffset != 0 {
+		t.Fatalf("expected g to go to the top, offset %d", vp.YOffset)
+	}
+	if scrollEnds(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}, &vp) {
+		t.Fatalf("expected other keys to pass through")
+	}
+}
+
+func TestScrollIndicator(t *testing.T) {
+	vp := viewport.New(20, 10)
+	vp.SetContent(strings.Repeat("line\n", 4) + "line")
+	if got := scrollIndicator(vp); got != "" {
+		t.Fatalf("expected nothing when the content fits, got %q", got)
+	}
+
+	vp.SetContent(strings.Repeat("line\n", 349) + "line")
+	if got := scrollIndicator(vp); got != "  L1-10/350 0%" {
+		t.Fatalf("top: got %q", got)
+	}
+	vp.SetYOffset(11)
+	if got := scrollIndicator(vp); got != "  L12-21/350 3%" {
+		t.Fatalf("middle: got %q", got)
+	}
+	vp.GotoBottom()
+	if got := scrollIndicator(vp); got != "  L341-350/350 100%" {
+		t.Fatalf("bottom: got %q", got)
+	}
+}
@@ -30,7 +30,8 @@ type logsModel struct {
 	vp     viewport.Model
 
 	lines []string
-	err   error
+	// load is loading until the stream yields its first line (or ends).
+	load loadState
 
 	searchMode bool
 	searchIn   textinput.Model
@@ -57,7 +58,7 @@ func newLogsModel(st styles, c argocd.Client, appName, podName string) logsModel
 	ti.CharLimit = 128
 	ti.Width = 40
 
-	return logsModel{
+	m := logsModel{
 		styles:   st,
 		client:   c,
 		appName:  appName,
@@ -68,6 +69,8 @@ func newLogsModel(st styles, c argocd.Client, appName, podName string) logsModel
 		searchIn: ti,
 		lines:    nil,
 	}
+	m.load.start()
+	return m
 }
 
 func (m logsModel) initCmd() tea.Cmd {
@@ -79,7 +82,11 @@ func (m *logsModel) setSize(w, h int) {
 	m.height = h
 	m.vp.Width = max(1, w)
 	m.vp.Height = max(1, h-2)
+	// Wrapped lines depend on the width, so re-render before following.
 	m.vp.SetContent(m.renderBody())
+	if m.follow {
+		m.vp.GotoBottom()
+	}
 }
 
 func (m logsModel) Update(msg tea.Msg) (logsModel, tea.Cmd) {
@@ -88,6 +95,9 @@ func (m logsModel) Update(msg tea.Msg) (logsModel, tea.Cmd) {
 		m.setSize(msg.Width, msg.Height)
 		return m, nil
 	case logLineMsg:
+		if m.load.loading() {
+			m.load.finish(nil)
+		}
 		m.lines = append(m.lines, msg.line)
 		m.vp.SetContent(m.renderBody())
 		if m.follow {
@@ -95,11 +105,15 @@ func (m logsModel) Update(msg tea.Msg) (logsModel, tea.Cmd) {
 		}
 		return m, m.waitStreamMsgCmd()
 	case logErrMsg:
-		m.err = msg.err
+		m.load.finish(msg.err)
 		m.vp.SetContent(m.renderBody())
 		return m, nil
 	case logDoneMsg:
+		if m.load.loading() {
+			m.load.finish(nil)
+		}
 		m.streamOn = false
+		m.vp.SetContent(m.renderBody())
 		return m, nil
 	case tea.KeyMsg:
 		if m.searchMode {
@@ -123,7 +137,25 @@ func (m logsModel) Update(msg tea.Msg) (logsModel, tea.Cmd) {
 			return m, cmd
 		}
 
+		if handled, retry := m.load.handleKey(msg); handled {
+			var cmd tea.Cmd
+			if retry {
+				start := m.startStreamCmd()
+				cmd = tea.Batch(start, m.waitStreamMsgCmd())
+			}
+			m.vp.SetContent(m.renderBody())
+			return m, cmd
+		}
+
 		switch msg.String() {
+		case "g":
+			// Reading from the top: stop new lines pulling the view down.
+			m.follow = false
+			m.vp.GotoTop()
+			return m, nil
+		case "G":
+			m.vp.GotoBottom()
+			return m, nil
 		case "f":
 			m.follow = !m.follow
 			m.vp.SetContent(m.renderBody())
@@ -154,15 +186,16 @@ func (m logsModel) Update(msg tea.Msg) (logsModel, tea.Cmd) {
 }
 
 func (m logsModel) View() string {
-	head := fmt.Sprintf("Logs: %s/%s  [container:%s]  [follow:%v]  [wrap:%v]  f=follow  w=wrap  /=search  n=next  esc=close",
-		m.appName, m.podName, blankIfEmpty(m.container, "default"), m.follow, m.wrap)
-	headStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Padding(0, 1)
-	return lipgloss.JoinVertical(lipgloss.Top, headStyle.Width(m.width).Render(head), m.vp.View())
+	head := fmt.Sprintf("Logs: %s/%s%s  [container:%s]  [follow:%v]  [wrap:%v]  f=follow  w=wrap  /=search  n=next  esc=close",
+		m.appName, m.podName, m.load.headerTag(), blankIfEmpty(m.container, "default"), m.follow, m.wrap)
+	return lipgloss.JoinVertical(lipgloss.Top, m.styles.OverlayHeader.Width(m.width).Render(head+scrollIndicator(m.vp)), m.vp.View())
 }
 
 func (m logsModel) renderBody() string {
-	if m.err != nil {
-		return "Error:\n\n" + m.err.Error()
+	// A quiet pod may not log for a while, so plain loading keeps the
+	// "(no log lines yet)" body and shows only in the header.
+	if body, ok := m.load.body(); ok && (m.load.failed() || m.load.retrying) {
+		return body
 	}
 
 	head := ""
@@ -210,15 +243,16 @@ func (m *logsModel) jumpToMatch(fromTop bool) {
 	}
 }
 
+// close stops the stream; the overlay is going away.
+func (m *logsModel) close() {
+	stopLoad(&m.streamCancel)
+	m.streamOn = false
+}
+
 func (m *logsModel) startStreamCmd() tea.Cmd {
-	// Stop any existing stream.
-	if m.streamCancel != nil {
-		m.streamCancel()
-		m.streamCancel = nil
-	}
+	// Starting a stream stops any existing one.
 	m.streamCh = make(chan tea.Msg, 100)
-	ctx, cancel := context.WithCancel(context.Background())
-	m.streamCancel = cancel
+	ctx := newLoadContext(&m.streamCancel)
 	m.streamOn = true
 
 	app := m.appName
@@ -230,30 +264,37 @@ func (m *logsModel) startStreamCmd() tea.Cmd {
 
 	return func() tea.Msg {
 		go func() {
+			defer close(ch)
+			// Once stopped nobody reads ch; don't block on a full buffer.
+			send := func(msg tea.Msg) bool {
+				select {
+				case ch <- msg:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}
 			rc, err := c.PodLogs(ctx, app, pod, container, follow)
 			if err != nil {
-				ch <- logErrMsg{err: err}
-				close(ch)
+				send(logErrMsg{err: err})
 				return
 			}
 			defer rc.Close()
 
 			s := bufio.NewScanner(rc)
 			for s.Scan() {
-				select {
-				case <-ctx.Done():
-					close(ch)
+				if !send(logLineMsg{line: s.Text()}) {
 					return
-				default:
 				}
-				ch <- logLineMsg{line: s.Text()}
+			}
+			if ctx.Err() != nil {
+				return
 			}
 			if err := s.Err(); err != nil {
-				ch <- logErrMsg{err: err}
+				send(logErrMsg{err: err})
 			} else {
-				ch <- logDoneMsg{}
+				send(logDoneMsg{})
 			}
-			close(ch)
 		}()
 		return nil
 	}
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"lazyargo/internal/argocd"
+)
+
+const (
+	// logsEventsSplitStep is how much one +/- press moves the split, in
+	// percent of the height; the events pane stays within the min/max.
+	logsEventsSplitStep = 10
+	logsEventsSplitMin  = 20
+	logsEventsSplitMax  = 80
+)
+
+// logsEventsModel shows an app's events above a pod's streaming logs. It
+// composes the standalone events and logs overlays; keys go to whichever
+// pane has focus.
+type logsEventsModel struct {
+	styles styles
+
+	events eventsModel
+	logs   logsModel
+
+	// focusLogs sends keys to the logs pane instead of the events pane.
+	focusLogs bool
+	// split is the events pane's share of the height, in percent.
+	split int
+
+	width  int
+	height int
+}
+
+func newLogsEventsModel(st styles, c argocd.Client, appName, podName string) logsEventsModel {
+	return logsEventsModel{
+		styles:    st,
+		events:    newEventsModel(st, c, appName),
+		logs:      newLogsModel(st, c, appName, podName),
+		focusLogs: true,
+		split:     40,
+	}
+}
+
+func (m logsEventsModel) initCmd() tea.Cmd {
+	return tea.Batch(m.events.initCmd(), m.logs.initCmd())
+}
+
+// close stops the log stream; the view is going away.
+func (m *logsEventsModel) close() {
+	m.logs.close()
+}
+
+func (m *logsEventsModel) setSize(w, h int) {
+	m.width = w
+	m.height = h
+	body := max(2, h-1)
+	eh := max(1, body*m.split/100)
+	m.events.setSize(w, eh)
+	m.logs.setSize(w, max(1, body-eh))
+}
+
+// capturingInput reports whether the logs search prompt is reading keys, so
+// the parent must not treat esc/q as close.
+func (m logsEventsModel) capturingInput() bool {
+	return m.focusLogs && m.logs.searchMode
+}
+
+func (m logsEventsModel) Update(msg tea.Msg) (logsEventsModel, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.setSize(msg.Width, msg.Height)
+		return m, nil
+	case eventsLoadedMsg:
+		m.events, cmd = m.events.Update(msg)
+		return m, cmd
+	case logLineMsg, logErrMsg, logDoneMsg:
+		m.logs, cmd = m.logs.Update(msg)
+		return m, cmd
+	case tea.KeyMsg:
+		if !m.capturingInput() {
+			switch msg.String() {
+			case "tab":
+				m.focusLogs = !m.focusLogs
+				return m, nil
+			case "+":
+				m.split = min(logsEventsSplitMax, m.split+logsEventsSplitStep)
+				m.setSize(m.width, m.height)
+				return m, nil
+			case "-":
+				m.split = max(logsEventsSplitMin, m.split-logsEventsSplitStep)
+				m.setSize(m.width, m.height)
+				return m, nil
+			}
+		}
+		if m.focusLogs {
+			m.logs, cmd = m.logs.Update(msg)
+		} else {
+			m.events, cmd = m.events.Update(msg)
+		}
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m logsEventsModel) View() string {
+	focus := "events"
+	if m.focusLogs {
+		focus = "logs"
+	}
+	head := fmt.Sprintf("Logs+events: %s/%s  [focus:%s]  tab=switch  +/-=resize  esc=close",
+		m.logs.appName, m.logs.podName, focus)
+	return lipgloss.JoinVertical(lipgloss.Top,
+		m.styles.OverlayHeader.Width(m.width).Render(head),
+		m.events.View(),
+		m.logs.View(),
+	)
+}
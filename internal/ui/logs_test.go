@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"lazyargo/internal/config"
+)
+
+func TestLogs_gStopsFollowing(t *testing.T) {
+	m := newLogsModel(newStyles(config.Theme{}), &fakeClient{}, "guestbook", "web-0")
+	m.setSize(40, 5)
+	for i := 0; i < 20; i++ {
+		m, _ = m.Update(logLineMsg{line: "line"})
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	if m.follow || m.vp.YOffset != 0 {
+		t.Fatalf("expected g to stop following at the top (follow %v, offset %d)", m.follow, m.vp.YOffset)
+	}
+	m, _ = m.Update(logLineMsg{line: "late"})
+	if m.vp.YOffset != 0 {
+		t.Fatalf("a new line pulled the view away from the top")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	if !m.vp.AtBottom() {
+		t.Fatalf("expected G to go to the bottom")
+	}
+}
@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type pathStepKind int
+
+const (
+	stepKey pathStepKind = iota
+	stepIndex
+	// stepAll ("[*]") fans out over every list item or object value.
+	stepAll
+)
+
+type pathStep struct {
+	kind  pathStepKind
+	key   string
+	index int
+}
+
+func (s pathStep) String() string {
+	switch s.kind {
+	case stepIndex:
+		return fmt.Sprintf("[%d]", s.index)
+	case stepAll:
+		return "[*]"
+	}
+	if strings.ContainsAny(s.key, ".[]") {
+		return "[" + strconv.Quote(s.key) + "]"
+	}
+	return "." + s.key
+}
+
+// parsePath splits a dotted path such as "spec.containers[*].image" into
+// steps. Keys containing dots can be quoted: metadata.labels["app.kubernetes.io/name"].
+// A leading dot is optional.
+func parsePath(path string) ([]pathStep, error) {
+	s := strings.TrimPrefix(strings.TrimSpace(path), ".")
+	var steps []pathStep
+	for i := 0; i < len(s); {
+		if s[i] == '[' {
+			var step pathStep
+			if strings.HasPrefix(s[i+1:], `"`) {
+				j := strings.Index(s[i+2:], `"]`)
+				if j < 0 {
+					return nil, fmt.Errorf("unterminated quoted key at column %d", i+1)
+				}
+				key, err := strconv.Unquote(s[i+1 : i+j+3])
+				if err != nil {
+					return nil, fmt.Errorf("bad quoted key at column %d", i+1)
+				}
+				step = pathStep{kind: stepKey, key: key}
+				i += j + 4
+			} else {
+				j := strings.IndexByte(s[i:], ']')
+				if j < 0 {
+					return nil, fmt.Errorf("missing ] at column %d", i+1)
+				}
+				inner := strings.TrimSpace(s[i+1 : i+j])
+				if inner == "*" {
+					step = pathStep{kind: stepAll}
+				} else if n, err := strconv.Atoi(inner); err == nil && n >= 0 {
+					step = pathStep{kind: stepIndex, index: n}
+				} else {
+					return nil, fmt.Errorf("bad index %q: use a number, * or a quoted key", inner)
+				}
+				i += j + 1
+			}
+			steps = append(steps, step)
+			if i < len(s) && s[i] != '.' && s[i] != '[' {
+				return nil, fmt.Errorf("expected . or [ at column %d", i+1)
+			}
+			continue
+		}
+		if s[i] == '.' {
+			i++
+		}
+		j := strings.IndexAny(s[i:], ".[")
+		if j < 0 {
+			j = len(s) - i
+		}
+		if j == 0 {
+			return nil, fmt.Errorf("empty field name at column %d", i+1)
+		}
+		key := s[i : i+j]
+		if key == "*" {
+			steps = append(steps, pathStep{kind: stepAll})
+		} else {
+			steps = append(steps, pathStep{kind: stepKey, key: key})
+		}
+		i += j
+	}
+	return steps, nil
+}
+
+// queryManifest walks obj (an unmarshaled manifest) along path and returns
+// the value found there. Once a [*] step has fanned out, the result is the
+// list of every match and branches that lack the rest of the path are
+// skipped; before that, a missing field or index is an error naming where
+// the walk stopped.
+func queryManifest(obj any, path string) (any, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	cur := []any{obj}
+	multi := false
+	at := ""
+	for _, st := range steps {
+		where := blankIfEmpty(at, ".")
+		var next []any
+		for _, v := range cur {
+			switch st.kind {
+			case stepKey:
+				obj, ok := v.(map[string]any)
+				if !ok {
+					if multi {
+						continue
+					}
+					return nil, fmt.Errorf("%s is %s, not an object", where, describeValue(v))
+				}
+				child, ok := obj[st.key]
+				if !ok {
+					if multi {
+						continue
+					}
+					return nil, fmt.Errorf("no field %q at %s (fields: %s)", st.key, where, strings.Join(sortedKeys(obj), ", "))
+				}
+				next = append(next, child)
+			case stepIndex:
+				list, ok := v.([]any)
+				if !ok {
+					if multi {
+						continue
+					}
+					return nil, fmt.Errorf("%s is %s, not a list", where, describeValue(v))
+				}
+				if st.index >= len(list) {
+					if multi {
+						continue
+					}
+					return nil, fmt.Errorf("index %d out of range at %s (%d items)", st.index, where, len(list))
+				}
+				next = append(next, list[st.index])
+			case stepAll:
+				switch c := v.(type) {
+				case []any:
+					next = append(next, c...)
+				case map[string]any:
+					for _, k := range sortedKeys(c) {
+						next = append(next, c[k])
+					}
+				default:
+					if multi {
+						continue
+					}
+					return nil, fmt.Errorf("%s is %s; [*] needs a list or an object", where, describeValue(v))
+				}
+			}
+		}
+		if st.kind == stepAll {
+			multi = true
+		}
+		at += st.String()
+		cur = next
+	}
+	if !multi {
+		return cur[0], nil
+	}
+	if len(cur) == 0 {
+		return nil, fmt.Errorf("nothing matches %s", at)
+	}
+	return cur, nil
+}
+
+func sortedKeys(obj map[string]any) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func describeValue(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "a list"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	default:
+		return "a number"
+	}
+}
+
+// pathQuery is the resource viewer's "." prompt: it narrows the manifest to
+// the value at a dotted path.
+type pathQuery struct {
+	path   string
+	prompt bool
+	input  textinput.Model
+}
+
+func newPathQuery() pathQuery {
+	ti := textinput.New()
+	ti.Placeholder = "spec.template.spec.containers[*].image"
+	ti.Prompt = "."
+	ti.Width = 40
+	return pathQuery{input: ti}
+}
+
+// update handles '.' (open the prompt, pre-filled with the current path) and
+// the prompt's keys. Submitting an empty path goes back to the full
+// manifest. When redraw is true the caller should re-render its content.
+func (q *pathQuery) update(msg tea.KeyMsg) (handled, redraw bool, cmd tea.Cmd) {
+	if q.prompt {
+		switch msg.String() {
+		case "esc":
+			q.closePrompt()
+			return true, false, nil
+		case "enter":
+			q.path = strings.TrimPrefix(strings.TrimSpace(q.input.Value()), ".")
+			q.closePrompt()
+			return true, true, nil
+		}
+		q.input, cmd = q.input.Update(msg)
+		return true, false, cmd
+	}
+
+	if msg.String() == "." {
+		q.prompt = true
+		q.input.SetValue(q.path)
+		q.input.CursorEnd()
+		q.input.Focus()
+		return true, false, nil
+	}
+	return false, false, nil
+}
+
+func (q *pathQuery) closePrompt() {
+	q.prompt = false
+	q.input.Blur()
+}
+
+// capturingInput reports whether keystrokes should go to the prompt.
+func (q pathQuery) capturingInput() bool { return q.prompt }
+
+// hint is the header fragment for the path query, or the prompt itself while
+// it is open.
+func (q pathQuery) hint() string {
+	switch {
+	case q.prompt:
+		return "path " + q.input.View()
+	case q.path != "":
+		return "[." + q.path + "] .=path"
+	}
+	return ".=path"
+}
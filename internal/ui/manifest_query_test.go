@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestQueryManifest(t *testing.T) {
+	var obj any
+	manifest := `
+metadata:
+  labels:
+    app.kubernetes.io/name: web
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: web:1.2
+      - name: sidecar
+        image: proxy:3
+        ports:
+        - containerPort: 80
+`
+	if err := yaml.Unmarshal([]byte(manifest), &obj); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		path string
+		want any
+		err  string
+	}{
+		{path: "spec.template.spec.containers[*].image", want: []any{"web:1.2", "proxy:3"}},
+		{path: ".spec.template.spec.containers[1].name", want: "sidecar"},
+		{path: `metadata.labels["app.kubernetes.io/name"]`, want: "web"},
+		{path: "spec.template.spec.containers[*].ports[0].containerPort", want: []any{float64(80)}},
+		{path: "spec.template.spec.containers[*].args", err: "nothing matches .spec.template.spec.containers[*].args"},
+		{path: "spec.tmpl", err: `no field "tmpl" at .spec (fields: template)`},
+		{path: "spec.template.spec.containers[2]", err: "index 2 out of range at .spec.template.spec.containers (2 items)"},
+		{path: "metadata.labels[0]", err: "metadata.labels is an object, not a list"},
+		{path: "spec.template.spec.containers[x]", err: `bad index "x"`},
+		{path: "spec..template", err: "empty field name"},
+	}
+	for _, c := range cases {
+		got, err := queryManifest(obj, c.path)
+		if c.err != "" {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("%s: expected error %q, got %v", c.path, c.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", c.path, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %#v, want %#v", c.path, got, c.want)
+		}
+	}
+}
@@ -1,8 +1,13 @@
 package ui
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -10,12 +15,14 @@ import (
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"lazyargo/internal/argocd"
 	"lazyargo/internal/config"
+	"lazyargo/internal/notify"
 )
 
 type Model struct {
@@ -28,20 +35,53 @@ type Model struct {
 
 	width  int
 	height int
+	// sidebarWidth is the requested sidebar width, seeded from the state
+	// file or ui.sidebarWidth and changed with </>; see paneWidths.
+	sidebarWidth int
+	state        config.State
 
 	appsAll       []argocd.Application
 	apps          []argocd.Application
 	selected      int
 	sidebarOffset int
+	// driftCount is the number of non-synced apps in appsAll, kept with it so
+	// the footer doesn't rescan large lists on every render.
+	driftCount int
+	// filterMatches holds, per app name, the rune indices that matched the
+	// current filter query, for highlighting in the sidebar.
+	filterMatches map[string][]int
+
+	// Sidebar grouping (flat by default). sidebarRows/appRow are rebuilt by
+	// applyFilter and are nil in flat mode.
+	groupMode      groupMode
+	groupCollapsed map[string]bool
+	sidebarRows    []sidebarRow
+	appRow         []int
 
 	filterInput  textinput.Model
 	filterActive bool
 	driftOnly    bool
 
+	// pinned apps sort to the top of the sidebar; pinnedOnly hides the rest.
+	// Seeded from and saved to the state file.
+	pinned     map[string]bool
+	pinnedOnly bool
+
+	// Go-to prompt: jumps the selection to an app by name or prefix without
+	// touching the filter.
+	gotoInput  textinput.Model
+	gotoActive bool
+
 	deleteModal   bool
 	deleteApp     string
 	deleteCascade bool
-	deleteInput   textinput.Model
+	deleteConfirm confirmText
+
+	resourceDeleteModal   bool
+	resourceDeleteApp     string
+	resourceDeleteRef     argocd.ResourceRef
+	resourceDeleteForce   bool
+	resourceDeleteConfirm confirmText
 
 	createModal      bool
 	createStep       createStep
@@ -51,14 +91,32 @@ type Model struct {
 	createRevInput   textinput.Model
 	createList       list.Model
 	createProjects   []string
-	createRepos      []string
-	createClusters   []string
+	createRepos      []argocd.Repository
+	createClusters   []argocd.Cluster
 	createProject    string
 	createRepo       string
 	createCluster    string
 	createSyncPolicy string
+	createPreset     string
 	createErr        error
 	createCreating   bool
+	// The confirm step validates the app against the server first;
+	// createValidateGen drops the result of a validation that went stale
+	// when the user stepped back.
+	createValidating  bool
+	createValidateErr error
+	createValidateGen int
+	// createNamespaces are the namespace step's suggestions: the ones apps
+	// already use on the chosen cluster. createNSPick is the highlighted
+	// one, -1 for none.
+	createNamespaces []string
+	createNSPick     int
+
+	// Create from a raw Application manifest (pasted, or "@path" to a file).
+	rawCreateModal bool
+	rawCreateInput textarea.Model
+	rawCreateErr   error
+	rawCreating    bool
 
 	editModal      bool
 	editStep       createStep
@@ -71,17 +129,71 @@ type Model struct {
 	editSyncPolicy string
 	editErr        error
 	editSaving     bool
+	// editOrigSyncPolicy is the policy the app had when the edit began;
+	// switching manual → auto must be acknowledged (editAutoAck) on confirm.
+	editOrigSyncPolicy string
+	editAutoAck        bool
 
 	sortMode sortMode
 
 	serverLabel string
 	lastRefresh time.Time
+	// prevHealth is the health of each app at the previous list refresh, used
+	// to spot regressions to Degraded/Missing. nil until the first load.
+	prevHealth map[string]string
+	alert      string
+	// ringing puts a BEL in the frame so the renderer rings the bell on the
+	// program's output; bellGen drops the stop ticks of older rings.
+	ringing bool
+	bellGen int
+	// clipboard is an OSC 52 copy request carried in the frame the same
+	// way; see copyToClipboard.
+	clipboard    string
+	clipboardGen int
+	// healthTrend is each app's health over recent list refreshes.
+	healthTrend healthTrend
+
+	// footerTickGen identifies the current "(12s ago)" footer tick loop; each
+	// refresh starts a new loop and ticks from older ones are dropped.
+	footerTickGen int
+	// errExpanded shows full API error responses for the list and detail
+	// load errors (!).
+	errExpanded bool
+	// webUIHintDismissed hides the "this is the web UI, not the API" hint
+	// on the list error page (esc) for the rest of the session.
+	webUIHintDismissed bool
+
+	// detailDebounceGen identifies the latest pending debounced detail load.
+	detailDebounceGen int
+	// Request generations: each load bumps its counter and results carrying
+	// an older value are stale and dropped. See loadDetailCmd.
+	detailGen    int
+	revisionsGen int
+	diffGen      int
+	// driftDiffGen tags the drifted-apps diff view's loads.
+	driftDiffGen int
+	historyGen   int
+	// Cancel the in-flight rollback revisions and diff loads when their
+	// overlay closes; see newLoadContext. Writes are never cancelled.
+	rollbackCancel  context.CancelFunc
+	diffCancel      context.CancelFunc
+	driftDiffCancel context.CancelFunc
+	historyCancel   context.CancelFunc
+	// pendingDiff names the app whose diff opens once its hard refresh
+	// (RefreshDiff) lands.
+	pendingDiff string
 
 	syncModal          bool
 	syncTargets        []string
 	syncPreview        map[string][]argocd.Resource // drifted resources snapshot
 	syncDryRunComplete bool
 	syncDryRunResults  []syncResult
+	// syncRevision pins the sync to a revision (chosen in history) instead
+	// of the apps' target revision.
+	syncRevision string
+	// syncWatch is set when the sync runs with w: the modal stays open and
+	// follows each target's operation until it finishes.
+	syncWatch map[string]*syncWatchState
 
 	rollbackModal    bool
 	rollbackApp      string
@@ -90,6 +202,12 @@ type Model struct {
 	rollbackRevs     []argocd.Revision
 	rollbackSelected int
 	rollbackConfirm  bool
+	// The rollback preview compares the deployed revision with the selected
+	// one once it's picked for confirmation.
+	rollbackPreview        *argocd.RevisionComparison
+	rollbackPreviewErr     error
+	rollbackPreviewLoading bool
+	rollbackPreviewGen     int
 
 	terminateModal   bool
 	terminateApp     string
@@ -97,23 +215,52 @@ type Model struct {
 	terminateErr     error
 	terminateConfirm bool
 
+	// destructiveConfirm asks for the app name before terminate and
+	// rollback run, when UI.ConfirmDestructive is set.
+	destructiveConfirm confirmText
+
 	focusResources    bool
 	resourceSel       int // index into visible resource tree
 	resourceCollapsed map[string]bool
 	resourceZoom      string
+	// resourceSortMode orders resources within each kind group, or regroups
+	// them by sync wave (S while the resources are focused).
+	resourceSortMode sortMode
 
-	resourceSearchActive bool
-	resourceSearchInput  textinput.Model
+	resourceFilterActive bool
+	resourceFilterInput  textinput.Model
+	resourceProblemsOnly bool
+	resourceHideHooks    bool
+
+	// navStack records where we drilled in from (app-of-apps); backspace pops it.
+	// Any other change of the selected app (moving, goto, a filter, group
+	// or reload that moves the selection) clears it.
+	navStack []navEntry
 
 	resourceDetails *resourceDetailsModel
 	eventsView      *eventsModel
 	logsView        *logsModel
+	logsEventsView  *logsEventsModel
+	debugView       *debugModel
+	cheatsheet      *cheatsheetModel
 	diffView        *diffModel
+	driftDiffs      *driftDiffsModel
 	historyView     *historyModel
 	revisionView    *revisionDetailsModel
+	revisionDiff    *revisionDiffModel
+	actionsView     *resourceActionsModel
+	dashboardView   *dashboardModel
+	compareView     *compareModel
+	actionOutput    *actionOutputModel
+	// compareMarks are the apps marked for comparison, oldest first; at
+	// most two.
+	compareMarks []string
 
 	syncWindows    map[string][]argocd.SyncWindow
 	syncWindowsErr map[string]error
+	// syncWindowsAt is when each app's windows were fetched; detail loads
+	// within syncWindowsTTL reuse them.
+	syncWindowsAt map[string]time.Time
 
 	detail     *argocd.Application
 	detailErr  error
@@ -129,10 +276,15 @@ const (
 	sortByName sortMode = iota
 	sortByHealth
 	sortBySync
+	// sortByWave orders resources by sync wave; the app list doesn't cycle
+	// to it.
+	sortByWave
 )
 
 const (
-	createStepName createStep = iota
+	// createStepPreset comes first only when config has createPresets.
+	createStepPreset createStep = iota
+	createStepName
 	createStepProject
 	createStepRepo
 	createStepPath
@@ -149,6 +301,8 @@ func (s sortMode) String() string {
 		return "health"
 	case sortBySync:
 		return "sync"
+	case sortByWave:
+		return "wave"
 	default:
 		return "name"
 	}
@@ -164,18 +318,18 @@ func NewModel(cfg config.Config, client argocd.Client) Model {
 	ti.CharLimit = 128
 	ti.Width = 24
 
+	gti := textinput.New()
+	gti.Placeholder = "app name or prefix…"
+	gti.Prompt = "go to: "
+	gti.CharLimit = 128
+	gti.Width = 24
+
 	rti := textinput.New()
-	rti.Placeholder = "search resources…"
+	rti.Placeholder = "filter resources…"
 	rti.Prompt = "/ "
 	rti.CharLimit = 128
 	rti.Width = 32
 
-	del := textinput.New()
-	del.Placeholder = "type app name to confirm"
-	del.Prompt = "> "
-	del.CharLimit = 256
-	del.Width = 32
-
 	nameIn := textinput.New()
 	nameIn.Placeholder = "app name"
 	nameIn.Prompt = "name> "
@@ -200,6 +354,12 @@ func NewModel(cfg config.Config, client argocd.Client) Model {
 	revIn.CharLimit = 128
 	revIn.Width = 32
 
+	raw := textarea.New()
+	raw.Placeholder = "paste an Application manifest, or @path/to/app.yaml"
+	raw.ShowLineNumbers = true
+	raw.CharLimit = 0
+	raw.MaxHeight = 0
+
 	l := list.New(nil, list.NewDefaultDelegate(), 0, 0)
 	l.SetShowHelp(true)
 	l.SetFilteringEnabled(true)
@@ -240,37 +400,179 @@ func NewModel(cfg config.Config, client argocd.Client) Model {
 		serverLabel = "mock"
 	}
 
+	st, err := config.LoadState(cfg.StateFile)
+	if err != nil {
+		slog.Warn("ignoring unreadable state file", "err", err)
+	}
+	sidebarWidth := cfg.UI.SidebarWidth
+	if st.SidebarWidth > 0 {
+		sidebarWidth = st.SidebarWidth
+	}
+	pinned := make(map[string]bool, len(st.Pinned))
+	for _, n := range st.Pinned {
+		pinned[n] = true
+	}
+
 	m := Model{
-		cfg:                 cfg,
-		client:              client,
-		styles:              newStyles(),
-		keys:                newKeyMap(),
-		help:                h,
-		filterInput:         ti,
-		resourceSearchInput: rti,
-		resourceCollapsed:   map[string]bool{},
-		deleteInput:         del,
-		createNameInput:     nameIn,
-		createPathInput:     repoPath,
-		createNSInput:       nsIn,
-		createRevInput:      revIn,
-		createList:          l,
-		editRepoInput:       edRepo,
-		editPathInput:       edPath,
-		editRevInput:        edRev,
-		editClusterIn:       edCluster,
-		editNSInput:         edNS,
-		sortMode:            sortByName,
-		serverLabel:         serverLabel,
-		syncWindows:         map[string][]argocd.SyncWindow{},
-		syncWindowsErr:      map[string]error{},
+		cfg:                   cfg,
+		sidebarWidth:          sidebarWidth,
+		pinned:                pinned,
+		state:                 st,
+		client:                client,
+		styles:                newStyles(cfg.UI.Theme),
+		keys:                  newKeyMap(),
+		help:                  h,
+		filterInput:           ti,
+		gotoInput:             gti,
+		resourceFilterInput:   rti,
+		resourceCollapsed:     map[string]bool{},
+		deleteConfirm:         newConfirmText("type app name to confirm", 256),
+		resourceDeleteConfirm: newConfirmText("type resource name to confirm", 253),
+		destructiveConfirm:    newConfirmText("type app name to confirm", 256),
+		createNameInput:       nameIn,
+		createPathInput:       repoPath,
+		createNSInput:         nsIn,
+		createRevInput:        revIn,
+		createList:            l,
+		rawCreateInput:        raw,
+		editRepoInput:         edRepo,
+		editPathInput:         edPath,
+		editRevInput:          edRev,
+		editClusterIn:         edCluster,
+		editNSInput:           edNS,
+		sortMode:              sortByName,
+		serverLabel:           serverLabel,
+		syncWindows:           map[string][]argocd.SyncWindow{},
+		syncWindowsErr:        map[string]error{},
+		syncWindowsAt:         map[string]time.Time{},
+		healthTrend:           healthTrend{},
 	}
 	return m
 }
 
 func (m Model) Init() tea.Cmd {
 	// Initial data load.
-	return tea.Batch(m.refreshCmd())
+	return tea.Batch(m.refreshCmd(), m.autoRefreshCmd())
+}
+
+// Operation watch: after a real sync or a rollback, poll the app until its
+// operation reaches a terminal phase, then report (and optionally notify).
+const (
+	opWatchInterval = 2 * time.Second
+	opWatchMaxPolls = 300 // ~10 minutes
+)
+
+type opWatch struct {
+	app string
+	// action names the operation in messages; empty means "sync".
+	action      string
+	started     time.Time
+	seenRunning bool
+	polls       int
+}
+
+// syncWatchState is one target's operation as shown in the sync modal.
+type syncWatchState struct {
+	phase   string
+	message string
+	done    bool
+}
+
+type opWatchMsg struct {
+	watch opWatch
+	op    *argocd.OperationState
+	err   error
+}
+
+func (m Model) watchOperationCmd(w opWatch) tea.Cmd {
+	return tea.Tick(opWatchInterval, func(time.Time) tea.Msg {
+		w.polls++
+		a, err := m.client.GetApplication(context.Background(), w.app)
+		if err != nil {
+			return opWatchMsg{watch: w, err: err}
+		}
+		return opWatchMsg{watch: w, op: a.OperationState}
+	})
+}
+
+func (w opWatch) actionName() string {
+	if w.action == "" {
+		return "sync"
+	}
+	return w.action
+}
+
+func (m Model) notifyCmd(title, body string, urgent bool) tea.Cmd {
+	if !m.cfg.UI.Notifications {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := notify.Send(title, body, urgent); err != nil {
+			slog.Debug("desktop notification failed", "err", err)
+		}
+		return nil
+	}
+}
+
+// autoRefreshMsg triggers a periodic list refresh (ui.refreshInterval).
+type autoRefreshMsg struct{}
+
+func (m Model) autoRefreshCmd() tea.Cmd {
+	if m.cfg.UI.RefreshInterval <= 0 {
+		return nil
+	}
+	return tea.Tick(m.cfg.UI.RefreshInterval, func(time.Time) tea.Msg { return autoRefreshMsg{} })
+}
+
+// bellHold is how long a ring's BEL stays in the frame: long enough for the
+// renderer to flush at least one frame holding it.
+const bellHold = 100 * time.Millisecond
+
+// bellDoneMsg takes the BEL back out of the frame.
+type bellDoneMsg struct{ gen int }
+
+// ringBell rings the terminal bell with the next frame, rather than writing
+// to the terminal behind the renderer's back.
+func (m *Model) ringBell() tea.Cmd {
+	m.ringing = true
+	m.bellGen++
+	gen := m.bellGen
+	return tea.Tick(bellHold, func(time.Time) tea.Msg { return bellDoneMsg{gen: gen} })
+}
+
+// healthRegressions lists apps that became Degraded or Missing since prev.
+// Apps that weren't present before don't count.
+func healthRegressions(prev map[string]string, apps []argocd.Application) []string {
+	bad := func(h string) bool { return h == "Degraded" || h == "Missing" }
+	out := make([]string, 0)
+	for _, a := range apps {
+		was, ok := prev[a.Name]
+		if ok && bad(a.Health) && !bad(was) {
+			out = append(out, a.Name)
+		}
+	}
+	return out
+}
+
+// navEntry is a position to return to with the back key.
+type navEntry struct {
+	app            string
+	focusResources bool
+	resourceSel    int
+}
+
+// footerTickMsg re-renders the footer so the relative refresh age stays current.
+type footerTickMsg struct{ gen int }
+
+// footerTickCmd schedules the next footer tick for when the "ago" text will
+// actually change (every second for the first minute, then every minute, ...),
+// so an idle UI isn't redrawn needlessly.
+func (m Model) footerTickCmd() tea.Cmd {
+	if m.lastRefresh.IsZero() {
+		return nil
+	}
+	gen := m.footerTickGen
+	return tea.Tick(agoTickInterval(time.Since(m.lastRefresh)), func(time.Time) tea.Msg { return footerTickMsg{gen: gen} })
 }
 
 type appsMsg struct {
@@ -279,10 +581,18 @@ type appsMsg struct {
 }
 
 type detailMsg struct {
+	gen int
 	app argocd.Application
 	err error
 }
 
+// detailDebounceMsg fires once the selection has rested on name for the
+// debounce delay; gen identifies the selection change that scheduled it.
+type detailDebounceMsg struct {
+	gen  int
+	name string
+}
+
 type syncWindowsMsg struct {
 	appName string
 	items   []argocd.SyncWindow
@@ -300,11 +610,18 @@ type syncBatchMsg struct {
 }
 
 type revisionsMsg struct {
+	gen       int
 	appName   string
 	revisions []argocd.Revision
 	err       error
 }
 
+type rollbackPreviewMsg struct {
+	gen int
+	cmp argocd.RevisionComparison
+	err error
+}
+
 type rollbackMsg struct {
 	appName string
 	err     error
@@ -320,18 +637,24 @@ type deleteMsg struct {
 	err     error
 }
 
+type resourceDeleteMsg struct {
+	appName string
+	ref     argocd.ResourceRef
+	err     error
+}
+
 type projectsMsg struct {
 	items []string
 	err   error
 }
 
 type reposMsg struct {
-	items []string
+	items []argocd.Repository
 	err   error
 }
 
 type clustersMsg struct {
-	items []string
+	items []argocd.Cluster
 	err   error
 }
 
@@ -340,6 +663,17 @@ type createMsg struct {
 	err     error
 }
 
+type createValidateMsg struct {
+	gen int
+	err error
+}
+
+type exportMsg struct {
+	path  string
+	count int
+	err   error
+}
+
 type updateMsg struct {
 	appName string
 	err     error
@@ -352,13 +686,56 @@ func (m Model) refreshCmd() tea.Cmd {
 	}
 }
 
-func (m Model) loadDetailCmd(name string, hard bool) tea.Cmd {
+// loadDetailCmd starts a detail load and makes it the only one whose result
+// is applied: responses from earlier loads still in flight are dropped.
+func (m *Model) loadDetailCmd(name string, hard bool) tea.Cmd {
+	m.detailGen++
+	gen, c := m.detailGen, m.client
+	return func() tea.Msg {
+		app, err := c.RefreshApplication(context.Background(), name, hard)
+		return detailMsg{gen: gen, app: app, err: err}
+	}
+}
+
+// debouncedDetailCmd loads the selected app's details after
+// ui.detailDebounce. Moving again before then supersedes the pending load.
+func (m *Model) debouncedDetailCmd() tea.Cmd {
+	name := m.apps[m.selected].Name
+	d := m.cfg.UI.DetailDebounce
+	if d <= 0 {
+		return m.loadDetailCmd(name, false)
+	}
+	m.detailDebounceGen++
+	gen := m.detailDebounceGen
+	return tea.Tick(d, func(time.Time) tea.Msg { return detailDebounceMsg{gen: gen, name: name} })
+}
+
+// exportCSVPath is where X writes the application list, relative to the
+// working directory.
+const exportCSVPath = "applications.csv"
+
+// exportCSVCmd writes apps to exportCSVPath from the data already loaded;
+// it makes no API calls.
+func exportCSVCmd(apps []argocd.Application) tea.Cmd {
 	return func() tea.Msg {
-		app, err := m.client.RefreshApplication(context.Background(), name, hard)
-		return detailMsg{app: app, err: err}
+		path, err := filepath.Abs(exportCSVPath)
+		if err != nil {
+			return exportMsg{err: err}
+		}
+		var b bytes.Buffer
+		if err := argocd.WriteApplicationsCSV(&b, apps); err != nil {
+			return exportMsg{err: err}
+		}
+		if err := os.WriteFile(path, b.Bytes(), 0o644); err != nil {
+			return exportMsg{err: err}
+		}
+		return exportMsg{path: path, count: len(apps)}
 	}
 }
 
+// syncWindowsTTL is how long an app's fetched sync windows are reused.
+const syncWindowsTTL = 30 * time.Second
+
 func (m Model) loadSyncWindowsCmd(name string) tea.Cmd {
 	return func() tea.Msg {
 		items, err := m.client.GetSyncWindows(context.Background(), name)
@@ -367,20 +744,108 @@ func (m Model) loadSyncWindowsCmd(name string) tea.Cmd {
 }
 
 func (m Model) syncBatchCmd(targets []string, dryRun bool) tea.Cmd {
+	rev := m.syncRevision
 	return func() tea.Msg {
 		results := make([]syncResult, 0, len(targets))
 		for _, name := range targets {
-			err := m.client.SyncApplication(context.Background(), name, dryRun)
+			err := m.client.SyncApplication(context.Background(), name, argocd.SyncOptions{DryRun: dryRun, Revision: rev})
 			results = append(results, syncResult{name: name, err: err})
 		}
 		return syncBatchMsg{dryRun: dryRun, results: results}
 	}
 }
 
-func (m Model) loadRevisionsCmd(appName string) tea.Cmd {
+// openDiff opens the diff overlay for an app, limited to filter when set.
+func (m *Model) openDiff(name string, filter *argocd.ResourceRef) tea.Cmd {
+	m.diffGen++
+	dv := newDiffModel(m.styles, m.client, name, filter)
+	dv.gen = m.diffGen
+	dv.ctx = newLoadContext(&m.diffCancel)
+	dv.setSize(m.overlaySize())
+	m.diffView = &dv
+	m.statusLine = "loading diff…"
+	return dv.initCmd()
+}
+
+// openHistory opens the history overlay for app, fetching it first unless
+// loaded.
+func (m *Model) openHistory(app argocd.Application, loaded bool) tea.Cmd {
+	m.historyGen++
+	hv := newHistoryModel(m.styles, m.client, app, loaded)
+	hv.gen = m.historyGen
+	hv.ctx = newLoadContext(&m.historyCancel)
+	hv.setSize(m.overlaySize())
+	m.historyView = &hv
+	m.statusLine = "history"
+	return hv.initCmd()
+}
+
+// driftedApps names every loaded app that is not Synced, ignoring the
+// filter; the batch sync and the drifted-apps diff both act on them.
+func (m Model) driftedApps() []string {
+	targets := make([]string, 0)
+	for _, a := range m.appsAll {
+		if a.Sync != "Synced" {
+			targets = append(targets, a.Name)
+		}
+	}
+	return targets
+}
+
+// openDriftDiffs opens the diff view of every drifted app.
+func (m *Model) openDriftDiffs(apps []string) tea.Cmd {
+	m.driftDiffGen++
+	dd := newDriftDiffsModel(m.styles, m.client, apps)
+	dd.gen = m.driftDiffGen
+	dd.ctx = newLoadContext(&m.driftDiffCancel)
+	dd.setSize(m.overlaySize())
+	m.driftDiffs = &dd
+	m.statusLine = fmt.Sprintf("loading diffs of %d drifted apps…", len(apps))
+	return dd.initCmd()
+}
+
+// newLoadContext returns the context for a request owned by an overlay,
+// storing its cancel func in *cancel (and cancelling the request it
+// replaces) so closing the overlay can stop it with stopLoad.
+func newLoadContext(cancel *context.CancelFunc) context.Context {
+	stopLoad(cancel)
+	ctx, c := context.WithCancel(context.Background())
+	*cancel = c
+	return ctx
+}
+
+func stopLoad(cancel *context.CancelFunc) {
+	if *cancel != nil {
+		(*cancel)()
+		*cancel = nil
+	}
+}
+
+func (m *Model) loadRevisionsCmd(appName string) tea.Cmd {
+	m.revisionsGen++
+	gen, c := m.revisionsGen, m.client
+	ctx := newLoadContext(&m.rollbackCancel)
+	return func() tea.Msg {
+		revs, err := c.ListRevisions(ctx, appName)
+		return revisionsMsg{gen: gen, appName: appName, revisions: revs, err: err}
+	}
+}
+
+// rollbackPreviewCmd compares the deployed revision (the newest history
+// entry) with the selected rollback target. It returns nil when they are the
+// same revision.
+func (m *Model) rollbackPreviewCmd() tea.Cmd {
+	m.clearRollbackPreview()
+	from, to := m.rollbackRevs[0].Revision, m.rollbackRevs[m.rollbackSelected].Revision
+	if from == to {
+		return nil
+	}
+	m.rollbackPreviewLoading = true
+	gen, c, app := m.rollbackPreviewGen, m.client, m.rollbackApp
+	ctx := newLoadContext(&m.rollbackCancel)
 	return func() tea.Msg {
-		revs, err := m.client.ListRevisions(context.Background(), appName)
-		return revisionsMsg{appName: appName, revisions: revs, err: err}
+		cmp, err := c.RevisionsDiff(ctx, app, from, to)
+		return rollbackPreviewMsg{gen: gen, cmp: cmp, err: err}
 	}
 }
 
@@ -405,6 +870,13 @@ func (m Model) deleteCmd(appName string, cascade bool) tea.Cmd {
 	}
 }
 
+func (m Model) resourceDeleteCmd(appName string, ref argocd.ResourceRef, force bool) tea.Cmd {
+	return func() tea.Msg {
+		err := m.client.DeleteResource(context.Background(), appName, ref, force)
+		return resourceDeleteMsg{appName: appName, ref: ref, err: err}
+	}
+}
+
 func (m Model) loadProjectsCmd() tea.Cmd {
 	return func() tea.Msg {
 		items, err := m.client.ListProjects(context.Background())
@@ -426,6 +898,13 @@ func (m Model) loadClustersCmd() tea.Cmd {
 	}
 }
 
+func (m Model) validateAppCmd(app argocd.Application, gen int) tea.Cmd {
+	return func() tea.Msg {
+		err := m.client.ValidateApplication(context.Background(), app)
+		return createValidateMsg{gen: gen, err: err}
+	}
+}
+
 func (m Model) createAppCmd(app argocd.Application) tea.Cmd {
 	return func() tea.Msg {
 		err := m.client.CreateApplication(context.Background(), app)
@@ -446,80 +925,149 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.ensureSidebarSelectionVisible()
-		if m.resourceDetails != nil {
-			rd := *m.resourceDetails
-			rd.setSize(msg.Width-2, msg.Height-2)
-			m.resourceDetails = &rd
-		}
-		if m.eventsView != nil {
-			ev := *m.eventsView
-			ev.setSize(msg.Width-2, msg.Height-2)
-			m.eventsView = &ev
-		}
-		if m.logsView != nil {
-			lv := *m.logsView
-			lv.setSize(msg.Width-2, msg.Height-2)
-			m.logsView = &lv
-		}
-		if m.diffView != nil {
-			dv := *m.diffView
-			dv.setSize(msg.Width-2, msg.Height-2)
-			m.diffView = &dv
-		}
-		if m.historyView != nil {
-			hv := *m.historyView
-			hv.setSize(msg.Width-2, msg.Height-2)
-			m.historyView = &hv
+		m.resizeOverlays()
+		m.sizeRawCreateInput()
+		return m, nil
+	case autoRefreshMsg:
+		return m, tea.Batch(m.refreshCmd(), m.autoRefreshCmd())
+	case bellDoneMsg:
+		if msg.gen == m.bellGen {
+			m.ringing = false
 		}
-		if m.revisionView != nil {
-			rv := *m.revisionView
-			rv.setSize(msg.Width-2, msg.Height-2)
-			m.revisionView = &rv
+		return m, nil
+	case clipboardDoneMsg:
+		if msg.gen == m.clipboardGen {
+			m.clipboard = ""
 		}
 		return m, nil
+	case footerTickMsg:
+		if msg.gen != m.footerTickGen {
+			return m, nil
+		}
+		return m, m.footerTickCmd()
 	case appsMsg:
 		m.err = msg.err
-		m.detail = nil
 		m.detailErr = nil
 		if msg.err == nil {
 			m.appsAll = msg.apps
+			m.driftCount = countDrifted(msg.apps)
 			m.lastRefresh = time.Now().UTC()
+			var bell tea.Cmd
+			if m.prevHealth != nil && m.cfg.UI.Alerts.Enabled {
+				if reg := healthRegressions(m.prevHealth, msg.apps); len(reg) > 0 {
+					m.alert = "degraded: " + strings.Join(reg, ", ")
+					if m.cfg.UI.Alerts.Bell {
+						bell = m.ringBell()
+					}
+				}
+			}
+			m.prevHealth = make(map[string]string, len(msg.apps))
+			for _, a := range msg.apps {
+				m.prevHealth[a.Name] = a.Health
+				m.healthTrend.record(a.Name, a.Health, m.lastRefresh)
+			}
+			m.healthTrend.prune(m.prevHealth)
+			m.footerTickGen++
+			tick := tea.Batch(m.footerTickCmd(), bell)
 			m.applyFilter(false)
 			m.ensureSidebarSelectionVisible()
 			m.statusLine = fmt.Sprintf("loaded %d apps", len(m.appsAll))
 			if len(m.apps) > 0 {
+				// Keep the selected app's details, and with them the
+				// highlighted resource, until the reload below replaces them.
+				if m.detail != nil && m.detail.Name != m.apps[m.selected].Name {
+					m.detail = nil
+				}
 				// Auto-load details for the selected app.
-				return m, m.loadDetailCmd(m.apps[m.selected].Name, false)
+				load := m.loadDetailCmd(m.apps[m.selected].Name, false)
+				return m, tea.Batch(tick, load)
 			}
+			m.detail = nil
+			return m, tick
 		} else {
+			m.detail = nil
 			m.statusLine = "failed to load apps"
 		}
 		return m, nil
 	case detailMsg:
+		if msg.gen != m.detailGen {
+			// A newer load superseded this one (the selection moved on).
+			return m, nil
+		}
 		m.detailErr = msg.err
+		diffAfter := m.pendingDiff != "" && m.pendingDiff == msg.app.Name
+		m.pendingDiff = ""
 		if msg.err == nil {
+			// A reload of the same app keeps the highlight on the resource
+			// it was on, wherever the refreshed tree puts it.
+			key := ""
+			if m.detail != nil && m.detail.Name == msg.app.Name {
+				key = m.selectedResourceKey()
+			}
 			m.detail = &msg.app
 			m.statusLine = "loaded details"
-			// Clamp resource selection.
-			n := len(m.visibleResourceNodesFor(msg.app))
-			if m.resourceSel >= n {
-				m.resourceSel = max(0, n-1)
-			}
+			m.reselectResource(key)
+			m.syncListEntry(msg.app)
 			// Load sync windows info.
-			return m, m.loadSyncWindowsCmd(msg.app.Name)
+			var cmds []tea.Cmd
+			if at, ok := m.syncWindowsAt[msg.app.Name]; !ok || time.Since(at) >= syncWindowsTTL {
+				cmds = append(cmds, m.loadSyncWindowsCmd(msg.app.Name))
+			}
+			if diffAfter {
+				cmds = append(cmds, m.openDiff(msg.app.Name, nil))
+			}
+			return m, tea.Batch(cmds...)
 		} else {
 			m.detail = nil
 			m.statusLine = "failed to load details"
 		}
 		return m, nil
+	case detailDebounceMsg:
+		if msg.gen != m.detailDebounceGen {
+			return m, nil
+		}
+		cmd := m.loadDetailCmd(msg.name, false)
+		return m, cmd
 	case syncWindowsMsg:
 		if msg.err != nil {
 			m.syncWindowsErr[msg.appName] = msg.err
 		} else {
 			m.syncWindows[msg.appName] = msg.items
+			m.syncWindowsAt[msg.appName] = time.Now()
 			delete(m.syncWindowsErr, msg.appName)
 		}
 		return m, nil
+	case opWatchMsg:
+		w := msg.watch
+		if msg.err != nil {
+			m.statusLine = fmt.Sprintf("stopped watching %s: %v", w.app, msg.err)
+			m.updateSyncWatch(w.app, "Unknown", "stopped watching: "+msg.err.Error(), true)
+			return m, nil
+		}
+		if msg.op != nil && msg.op.Phase == "Running" {
+			w.seenRunning = true
+			m.updateSyncWatch(w.app, msg.op.Phase, msg.op.Message, false)
+		}
+		if !msg.op.Finished(w.started, w.seenRunning) {
+			if w.polls >= opWatchMaxPolls {
+				m.statusLine = "gave up watching " + w.app
+				m.updateSyncWatch(w.app, "Unknown", "gave up watching", true)
+				return m, nil
+			}
+			return m, m.watchOperationCmd(w)
+		}
+		phase, detail := "Succeeded", ""
+		if msg.op != nil {
+			phase, detail = msg.op.Phase, msg.op.Message
+		}
+		failed := phase != "Succeeded"
+		title := "lazyargo: " + w.actionName() + " succeeded"
+		if failed {
+			title = "lazyargo: " + strings.ToUpper(w.actionName()) + " FAILED"
+		}
+		m.statusLine = fmt.Sprintf("%s %s: %s", w.actionName(), w.app, phase)
+		m.updateSyncWatch(w.app, phase, detail, true)
+		return m, tea.Batch(m.notifyCmd(title, strings.TrimSpace(w.app+"\n"+detail), failed), m.refreshCmd())
 	case syncBatchMsg:
 		if msg.dryRun {
 			m.syncDryRunComplete = true
@@ -528,15 +1076,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		// Real sync finished: clear modal and refresh list.
-		m.syncModal = false
-		m.syncTargets = nil
-		m.syncPreview = nil
-		m.syncDryRunComplete = false
-		m.syncDryRunResults = nil
-		m.statusLine = "sync finished"
-		return m, m.refreshCmd()
+		// Real sync started: refresh the list and watch the operations,
+		// in the modal when w chose to.
+		watching := m.syncModal && m.syncWatch != nil
+		if !watching {
+			m.closeSyncModal()
+		}
+		cmds := []tea.Cmd{m.refreshCmd()}
+		now := time.Now()
+		failed := 0
+		for _, r := range msg.results {
+			if r.err != nil {
+				failed++
+				if watching {
+					m.syncWatch[r.name] = &syncWatchState{phase: "Error", message: r.err.Error(), done: true}
+				}
+				continue
+			}
+			cmds = append(cmds, m.watchOperationCmd(opWatch{app: r.name, started: now}))
+		}
+		m.statusLine = "sync started; watching operation…"
+		if failed > 0 {
+			m.statusLine = fmt.Sprintf("sync failed to start for %d of %d app(s)", failed, len(msg.results))
+		}
+		return m, tea.Batch(cmds...)
 	case revisionsMsg:
+		if msg.gen != m.revisionsGen {
+			return m, nil
+		}
 		m.rollbackLoading = false
 		m.rollbackErr = msg.err
 		if msg.err == nil {
@@ -549,20 +1116,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusLine = "failed to load revisions"
 		}
 		return m, nil
+	case exportMsg:
+		if msg.err != nil {
+			m.statusLine = "export failed: " + msg.err.Error()
+			return m, nil
+		}
+		m.statusLine = fmt.Sprintf("exported %d apps to %s", msg.count, msg.path)
+		return m, nil
+	case rollbackPreviewMsg:
+		if msg.gen != m.rollbackPreviewGen {
+			return m, nil
+		}
+		m.rollbackPreviewLoading = false
+		m.rollbackPreviewErr = msg.err
+		if msg.err == nil {
+			m.rollbackPreview = &msg.cmp
+		}
+		return m, nil
 	case rollbackMsg:
 		if msg.err != nil {
 			m.rollbackErr = msg.err
 			m.statusLine = "rollback failed"
 			return m, nil
 		}
-		m.rollbackModal = false
-		m.rollbackApp = ""
-		m.rollbackLoading = false
-		m.rollbackErr = nil
-		m.rollbackRevs = nil
-		m.rollbackConfirm = false
-		m.statusLine = "rollback started"
-		return m, tea.Batch(m.refreshCmd())
+		m.closeRollback()
+		m.statusLine = "rollback started; watching operation…"
+		return m, tea.Batch(m.refreshCmd(), m.watchOperationCmd(opWatch{app: msg.appName, action: "rollback", started: time.Now()}))
 	case terminateMsg:
 		m.terminateLoading = false
 		m.terminateErr = msg.err
@@ -573,6 +1152,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.terminateModal = false
 		m.terminateApp = ""
 		m.terminateConfirm = false
+		m.destructiveConfirm.close()
 		m.statusLine = "operation terminated"
 		return m, tea.Batch(m.refreshCmd())
 	case deleteMsg:
@@ -584,10 +1164,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.deleteModal = false
 		m.deleteApp = ""
 		m.deleteCascade = false
-		m.deleteInput.SetValue("")
-		m.deleteInput.Blur()
+		m.deleteConfirm.close()
 		m.statusLine = "application deleted"
 		return m, tea.Batch(m.refreshCmd())
+	case resourceDeleteMsg:
+		if msg.err != nil {
+			m.statusLine = "resource delete failed"
+			m.err = msg.err
+			return m, nil
+		}
+		m.closeResourceDelete()
+		m.statusLine = fmt.Sprintf("deleted %s/%s", msg.ref.Kind, msg.ref.Name)
+		cmd := m.loadDetailCmd(msg.appName, false)
+		return m, cmd
 	case projectsMsg:
 		m.createErr = msg.err
 		if msg.err == nil {
@@ -606,6 +1195,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.createClusters = msg.items
 		}
 		return m, nil
+	case createValidateMsg:
+		if msg.gen != m.createValidateGen {
+			return m, nil
+		}
+		m.createValidating = false
+		m.createValidateErr = msg.err
+		return m, nil
 	case createMsg:
 		m.createCreating = false
 		if msg.err != nil {
@@ -616,6 +1212,41 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m = m.resetCreateWizard()
 		m.statusLine = "application created"
 		return m, tea.Batch(m.refreshCmd())
+	case editorPrepMsg:
+		if msg.err != nil {
+			m.statusLine = fmt.Sprintf("edit failed: %v", msg.err)
+			return m, nil
+		}
+		argv := editorCommand()
+		if len(argv) == 0 {
+			os.Remove(msg.path)
+			m.statusLine = "set $EDITOR (or $VISUAL) to edit in an external editor"
+			return m, nil
+		}
+		return m, execEditorCmd(argv, msg)
+	case editorDoneMsg:
+		return m.handleEditorDone(msg)
+	case customActionDoneMsg:
+		return m.handleCustomActionDone(msg)
+	case browserOpenedMsg:
+		return m.handleBrowserOpened(msg)
+	case editorUpdateMsg:
+		if msg.err != nil {
+			m.statusLine = fmt.Sprintf("update of %s rejected: %v", msg.appName, msg.err)
+			return m, nil
+		}
+		m.statusLine = fmt.Sprintf("updated %s: %s", msg.appName, strings.Join(msg.changed, ", "))
+		return m, m.refreshCmd()
+	case rawCreateMsg:
+		m.rawCreating = false
+		if msg.err != nil {
+			m.rawCreateErr = msg.err
+			m.statusLine = "create failed"
+			return m, nil
+		}
+		m = m.resetRawCreate()
+		m.statusLine = fmt.Sprintf("application created: %s", msg.appName)
+		return m, m.refreshCmd()
 	case updateMsg:
 		m.editSaving = false
 		if msg.err != nil {
@@ -626,16 +1257,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m = m.resetEditWizard()
 		m.statusLine = "application updated"
 		return m, tea.Batch(m.refreshCmd())
+	case stateSavedMsg:
+		if msg.err != nil {
+			m.statusLine = "save state: " + msg.err.Error()
+		}
+		return m, nil
 	case tea.KeyMsg:
-		if m.resourceDetails != nil {
-			// Close handled here.
-			switch msg.String() {
-			case "esc", "q":
-				m.resourceDetails = nil
-				m.statusLine = "closed resource view"
+		// Any key acknowledges a pending health alert.
+		m.alert = ""
+		if m.cheatsheet != nil {
+			switch {
+			case msg.String() == "esc", msg.String() == "q", key.Matches(msg, m.keys.Cheatsheet):
+				m.cheatsheet = nil
 				return m, nil
 			}
 			var cmd tea.Cmd
+			cs := *m.cheatsheet
+			cs, cmd = cs.Update(msg)
+			m.cheatsheet = &cs
+			return m, cmd
+		}
+		if m.resourceDetails != nil {
+			// Close handled here, unless the overlay is reading input.
+			if !m.resourceDetails.capturingInput() {
+				switch msg.String() {
+				case "esc", "q":
+					m.resourceDetails = nil
+					m.statusLine = "closed resource view"
+					return m, nil
+				}
+			}
+			var cmd tea.Cmd
 			rd := *m.resourceDetails
 			rd, cmd = rd.Update(msg)
 			m.resourceDetails = &rd
@@ -657,6 +1309,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.logsView != nil {
 			switch msg.String() {
 			case "esc", "q":
+				m.logsView.close()
 				m.logsView = nil
 				m.statusLine = "closed logs"
 				return m, nil
@@ -667,49 +1320,95 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.logsView = &lv
 			return m, cmd
 		}
-		if m.diffView != nil {
+		if m.debugView != nil {
 			switch msg.String() {
-			case "esc", "q":
-				m.diffView = nil
-				m.statusLine = "closed diff"
+			case "esc", "q", "ctrl+g":
+				m.debugView = nil
+				m.statusLine = "closed request log"
 				return m, nil
 			}
 			var cmd tea.Cmd
-			dv := *m.diffView
-			dv, cmd = dv.Update(msg)
-			m.diffView = &dv
+			dbg := *m.debugView
+			dbg, cmd = dbg.Update(msg)
+			m.debugView = &dbg
 			return m, cmd
 		}
-		if m.historyView != nil {
+		if m.actionOutput != nil {
 			switch msg.String() {
 			case "esc", "q":
-				m.historyView = nil
-				m.statusLine = "closed history"
+				m.actionOutput = nil
+				m.statusLine = "closed action output"
 				return m, nil
-			case "enter":
-				appName := m.historyView.app.Name
-				rev := m.historyView.SelectedRevision()
-				if rev == "" {
-					m.statusLine = "no revision selected"
+			}
+			var cmd tea.Cmd
+			ao := *m.actionOutput
+			ao, cmd = ao.Update(msg)
+			m.actionOutput = &ao
+			return m, cmd
+		}
+		if m.logsEventsView != nil {
+			if !m.logsEventsView.capturingInput() {
+				switch msg.String() {
+				case "esc", "q":
+					m.logsEventsView.close()
+					m.logsEventsView = nil
+					m.statusLine = "closed logs+events"
 					return m, nil
 				}
-				rv := newRevisionDetailsModel(m.styles, m.client, appName, rev)
-				rv.setSize(m.width-4, m.height-4)
-				m.revisionView = &rv
-				m.statusLine = "loading revision details…"
-				return m, rv.initCmd()
 			}
 			var cmd tea.Cmd
-			hv := *m.historyView
-			hv, cmd = hv.Update(msg)
-			m.historyView = &hv
+			le := *m.logsEventsView
+			le, cmd = le.Update(msg)
+			m.logsEventsView = &le
 			return m, cmd
 		}
-		if m.revisionView != nil {
+		if m.diffView != nil {
 			switch msg.String() {
 			case "esc", "q":
-				m.revisionView = nil
-				m.statusLine = "closed revision details"
+				m.diffView = nil
+				stopLoad(&m.diffCancel)
+				m.statusLine = "closed diff"
+				return m, nil
+			}
+			var cmd tea.Cmd
+			dv := *m.diffView
+			dv, cmd = dv.Update(msg)
+			m.diffView = &dv
+			return m, cmd
+		}
+		if m.driftDiffs != nil {
+			switch msg.String() {
+			case "esc", "q":
+				m.driftDiffs = nil
+				stopLoad(&m.driftDiffCancel)
+				m.statusLine = "closed diff"
+				return m, nil
+			}
+			var cmd tea.Cmd
+			dd := *m.driftDiffs
+			dd, cmd = dd.Update(msg)
+			m.driftDiffs = &dd
+			return m, cmd
+		}
+		// Revision details and diffs open on top of history; esc returns to it.
+		if m.revisionDiff != nil {
+			switch msg.String() {
+			case "esc", "q":
+				m.revisionDiff = nil
+				m.statusLine = "closed revision diff"
+				return m, nil
+			}
+			var cmd tea.Cmd
+			rd := *m.revisionDiff
+			rd, cmd = rd.Update(msg)
+			m.revisionDiff = &rd
+			return m, cmd
+		}
+		if m.revisionView != nil {
+			switch msg.String() {
+			case "esc", "q":
+				m.revisionView = nil
+				m.statusLine = "closed revision details"
 				return m, nil
 			}
 			var cmd tea.Cmd
@@ -718,6 +1417,106 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.revisionView = &rv
 			return m, cmd
 		}
+		if m.historyView != nil {
+			switch msg.String() {
+			case "esc", "q":
+				stopLoad(&m.historyCancel)
+				m.historyView = nil
+				m.statusLine = "closed history"
+				return m, nil
+			case "enter":
+				appName := m.historyView.app.Name
+				rev := m.historyView.selectedRevision()
+				if rev == "" {
+					m.statusLine = "no revision selected"
+					return m, nil
+				}
+				rv := newRevisionDetailsModel(m.styles, m.client, appName, rev)
+				rv.setSize(m.overlaySize())
+				m.revisionView = &rv
+				m.statusLine = "loading revision details…"
+				return m, rv.initCmd()
+			case "d":
+				from, to, ok := m.historyView.diffRevisions()
+				if !ok {
+					m.statusLine = "select two revisions to diff (space marks one)"
+					return m, nil
+				}
+				rd := newRevisionDiffModel(m.styles, m.client, m.historyView.app.Name, from, to)
+				rd.setSize(m.overlaySize())
+				m.revisionDiff = &rd
+				m.statusLine = "loading revision diff…"
+				return m, rd.initCmd()
+			case "Y":
+				rev := m.historyView.selectedRevision()
+				if rev == "" {
+					m.statusLine = "no revision selected"
+					return m, nil
+				}
+				targets := []string{m.historyView.app.Name}
+				stopLoad(&m.historyCancel)
+				m.historyView = nil
+				m.syncModal = true
+				m.syncTargets = targets
+				m.syncRevision = rev
+				m.syncPreview = m.buildSyncPreview(targets)
+				m.syncDryRunComplete = false
+				m.syncDryRunResults = nil
+				m.statusLine = "running dry-run…"
+				return m, m.syncBatchCmd(targets, true)
+			}
+			var cmd tea.Cmd
+			hv := *m.historyView
+			hv, cmd = hv.Update(msg)
+			m.historyView = &hv
+			return m, cmd
+		}
+		if m.actionsView != nil {
+			if !m.actionsView.capturingInput() {
+				switch msg.String() {
+				case "esc", "q":
+					m.actionsView = nil
+					m.statusLine = "closed actions"
+					// An action may have changed resource state.
+					if m.detail != nil {
+						cmd := m.loadDetailCmd(m.detail.Name, false)
+						return m, cmd
+					}
+					return m, nil
+				}
+			}
+			var cmd tea.Cmd
+			av := *m.actionsView
+			av, cmd = av.Update(msg)
+			m.actionsView = &av
+			return m, cmd
+		}
+		if m.dashboardView != nil {
+			switch msg.String() {
+			case "esc", "q":
+				m.dashboardView = nil
+				m.statusLine = "closed dashboard"
+				return m, nil
+			}
+			var cmd tea.Cmd
+			dv := *m.dashboardView
+			dv, cmd = dv.Update(msg)
+			m.dashboardView = &dv
+			return m, cmd
+		}
+		if m.compareView != nil {
+			switch msg.String() {
+			case "esc", "q":
+				m.compareView = nil
+				m.statusLine = "closed comparison"
+				return m, nil
+			}
+			var cmd tea.Cmd
+			cv := *m.compareView
+			cv, cmd = cv.Update(msg)
+			m.compareView = &cv
+			return m, cmd
+		}
 
 		if m.deleteModal {
 			switch msg.String() {
@@ -725,15 +1524,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.deleteModal = false
 				m.deleteApp = ""
 				m.deleteCascade = false
-				m.deleteInput.SetValue("")
-				m.deleteInput.Blur()
+				m.deleteConfirm.close()
 				m.statusLine = "delete cancelled"
 				return m, nil
 			case "c":
 				m.deleteCascade = !m.deleteCascade
 				return m, nil
 			case "enter":
-				if strings.TrimSpace(m.deleteInput.Value()) != m.deleteApp {
+				if !m.deleteConfirm.confirmed() {
 					m.statusLine = "type the exact app name to confirm"
 					return m, nil
 				}
@@ -742,7 +1540,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			var cmd tea.Cmd
-			m.deleteInput, cmd = m.deleteInput.Update(msg)
+			m.deleteConfirm, cmd = m.deleteConfirm.Update(msg)
+			return m, cmd
+		}
+
+		if m.resourceDeleteModal {
+			switch msg.String() {
+			case "esc":
+				m.closeResourceDelete()
+				m.statusLine = "resource delete cancelled"
+				return m, nil
+			case "tab":
+				m.resourceDeleteForce = !m.resourceDeleteForce
+				return m, nil
+			case "enter":
+				if !m.resourceDeleteConfirm.confirmed() {
+					m.statusLine = "type the exact resource name to confirm"
+					return m, nil
+				}
+				m.statusLine = "deleting resource…"
+				return m, m.resourceDeleteCmd(m.resourceDeleteApp, m.resourceDeleteRef, m.resourceDeleteForce)
+			}
+
+			var cmd tea.Cmd
+			m.resourceDeleteConfirm, cmd = m.resourceDeleteConfirm.Update(msg)
 			return m, cmd
 		}
 
@@ -752,21 +1573,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.createModal {
 			return m.updateCreateWizard(msg)
 		}
+		if m.rawCreateModal {
+			return m.updateRawCreate(msg)
+		}
 
 		if m.syncModal {
 			switch msg.String() {
 			case "esc", "n":
-				m.syncModal = false
-				m.syncTargets = nil
-				m.syncPreview = nil
-				m.syncDryRunComplete = false
-				m.syncDryRunResults = nil
-				m.statusLine = "sync cancelled"
+				// A watched sync carries on; its result lands in the status line.
+				watching := m.syncWatch != nil
+				m.closeSyncModal()
+				if !watching {
+					m.statusLine = "sync cancelled"
+				}
 				return m, nil
-			case "y":
-				if !m.syncDryRunComplete {
+			case "y", "w":
+				if !m.syncDryRunComplete || m.syncWatch != nil {
 					return m, nil
 				}
+				if msg.String() == "w" {
+					m.syncWatch = make(map[string]*syncWatchState, len(m.syncTargets))
+					for _, name := range m.syncTargets {
+						m.syncWatch[name] = &syncWatchState{phase: "Starting"}
+					}
+				}
 				m.statusLine = "syncing…"
 				return m, m.syncBatchCmd(m.syncTargets, false)
 			}
@@ -774,13 +1604,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		if m.terminateModal {
+			if m.destructiveConfirm.active() && !m.terminateLoading {
+				return m.updateDestructiveConfirm(msg, func(m Model) (tea.Model, tea.Cmd) {
+					m.terminateLoading = true
+					m.statusLine = "terminating operation…"
+					return m, m.terminateCmd(m.terminateApp)
+				})
+			}
 			switch msg.String() {
 			case "esc", "n":
-				m.terminateModal = false
-				m.terminateApp = ""
-				m.terminateLoading = false
-				m.terminateErr = nil
-				m.terminateConfirm = false
+				// The terminate is a write: once sent, wait for its
+				// result rather than pretend it was cancelled.
+				if m.terminateLoading {
+					return m, nil
+				}
+				m.closeTerminate()
 				m.statusLine = "terminate cancelled"
 				return m, nil
 			case "enter":
@@ -788,10 +1626,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, nil
 				}
 				m.terminateConfirm = true
+				if m.cfg.UI.ConfirmDestructive {
+					m.destructiveConfirm.open(m.terminateApp)
+					m.statusLine = "type the app name to confirm terminate"
+					return m, nil
+				}
 				m.statusLine = "confirm terminate with y"
 				return m, nil
 			case "y":
-				if !m.terminateConfirm || m.terminateLoading {
+				if !m.terminateConfirm || m.terminateLoading || m.cfg.UI.ConfirmDestructive {
 					return m, nil
 				}
 				m.terminateLoading = true
@@ -802,26 +1645,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		if m.rollbackModal {
+			if m.destructiveConfirm.active() && !m.rollbackLoading {
+				return m.updateDestructiveConfirm(msg, func(m Model) (tea.Model, tea.Cmd) {
+					rev := m.rollbackRevs[m.rollbackSelected]
+					m.rollbackLoading = true
+					m.statusLine = fmt.Sprintf("rolling back to %d…", rev.ID)
+					return m, m.rollbackCmd(m.rollbackApp, rev.ID)
+				})
+			}
 			switch msg.String() {
 			case "esc", "n":
-				m.rollbackModal = false
-				m.rollbackApp = ""
-				m.rollbackLoading = false
-				m.rollbackErr = nil
-				m.rollbackRevs = nil
-				m.rollbackConfirm = false
+				m.closeRollback()
 				m.statusLine = "rollback cancelled"
 				return m, nil
 			case "up", "k":
 				if m.rollbackSelected > 0 {
 					m.rollbackSelected--
 					m.rollbackConfirm = false
+					m.clearRollbackPreview()
 				}
 				return m, nil
 			case "down", "j":
 				if m.rollbackSelected < len(m.rollbackRevs)-1 {
 					m.rollbackSelected++
 					m.rollbackConfirm = false
+					m.clearRollbackPreview()
 				}
 				return m, nil
 			case "enter":
@@ -829,10 +1677,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, nil
 				}
 				m.rollbackConfirm = true
+				cmd := m.rollbackPreviewCmd()
+				if m.cfg.UI.ConfirmDestructive {
+					m.destructiveConfirm.open(m.rollbackApp)
+					m.statusLine = "type the app name to confirm rollback"
+					return m, cmd
+				}
 				m.statusLine = "confirm rollback with y"
-				return m, nil
+				return m, cmd
 			case "y":
-				if !m.rollbackConfirm || len(m.rollbackRevs) == 0 || m.rollbackLoading {
+				if !m.rollbackConfirm || len(m.rollbackRevs) == 0 || m.rollbackLoading || m.cfg.UI.ConfirmDestructive {
 					return m, nil
 				}
 				rev := m.rollbackRevs[m.rollbackSelected]
@@ -862,26 +1716,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
-		if m.resourceSearchActive {
+		if m.gotoActive {
 			switch msg.String() {
 			case "esc":
-				m.resourceSearchActive = false
-				m.resourceSearchInput.SetValue("")
-				m.resourceSearchInput.Blur()
-				m.statusLine = "resource search cancelled"
+				m.closeGoto()
 				return m, nil
 			case "enter":
-				q := strings.ToLower(strings.TrimSpace(m.resourceSearchInput.Value()))
-				m.resourceSearchActive = false
-				m.resourceSearchInput.Blur()
-				if q != "" {
-					m.jumpToResourceMatch(q)
+				q := strings.TrimSpace(m.gotoInput.Value())
+				m.closeGoto()
+				if q == "" {
+					return m, nil
+				}
+				cmd, ok := m.gotoApp(q)
+				if !ok {
+					return m, nil
+				}
+				return m, cmd
+			}
+			var cmd tea.Cmd
+			m.gotoInput, cmd = m.gotoInput.Update(msg)
+			return m, cmd
+		}
+
+		if m.resourceFilterActive {
+			switch msg.String() {
+			case "esc":
+				m.resourceFilterActive = false
+				m.resourceFilterInput.SetValue("")
+				m.resourceFilterInput.Blur()
+				m.clampResourceSel()
+				m.statusLine = "resource filter cleared"
+				return m, nil
+			case "enter":
+				// Keep the filter applied; just leave input mode.
+				m.resourceFilterActive = false
+				m.resourceFilterInput.Blur()
+				if strings.TrimSpace(m.resourceFilterInput.Value()) != "" {
+					m.jumpToResourceMatch()
 				}
-				m.resourceSearchInput.SetValue("")
 				return m, nil
 			}
 			var cmd tea.Cmd
-			m.resourceSearchInput, cmd = m.resourceSearchInput.Update(msg)
+			m.resourceFilterInput, cmd = m.resourceFilterInput.Update(msg)
+			m.clampResourceSel()
 			return m, cmd
 		}
 
@@ -905,19 +1782,100 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if !ok {
 				return m, nil
 			}
+			// App-of-apps: enter on a child Application jumps to it in the sidebar.
+			if msg.String() == "enter" && r.Kind == "Application" {
+				from := navEntry{app: m.detail.Name, focusResources: m.focusResources, resourceSel: m.resourceSel}
+				if cmd, ok := m.selectAppByName(r.Name); ok {
+					m.navStack = append(m.navStack, from)
+					m.statusLine = "drilled into " + r.Name
+					return m, cmd
+				}
+			}
 			ref := argocd.ResourceRef{Group: r.Group, Kind: r.Kind, Name: r.Name, Namespace: r.Namespace, Version: r.Version}
 			rd := newResourceDetailsModel(m.styles, m.client, m.detail.Name, ref)
-			rd.setSize(m.width-4, m.height-4)
+			rd.setSize(m.overlaySize())
 			m.resourceDetails = &rd
 			m.statusLine = "loading resource…"
 			return m, rd.initCmd()
+		case msg.String() == "a" && m.focusResources:
+			r, ok := m.selectedResource()
+			if !ok {
+				return m, nil
+			}
+			ref := argocd.ResourceRef{Group: r.Group, Kind: r.Kind, Name: r.Name, Namespace: r.Namespace, Version: r.Version}
+			av := newResourceActionsModel(m.styles, m.client, m.detail.Name, ref)
+			av.setSize(m.overlaySize())
+			m.actionsView = &av
+			m.statusLine = "loading resource actions…"
+			return m, av.initCmd()
+		case msg.String() == "backspace":
+			if len(m.navStack) == 0 {
+				return m, nil
+			}
+			prev := m.navStack[len(m.navStack)-1]
+			m.navStack = m.navStack[:len(m.navStack)-1]
+			cmd, ok := m.selectAppByName(prev.app)
+			if !ok {
+				m.statusLine = prev.app + " is no longer in the list"
+				return m, nil
+			}
+			m.focusResources = prev.focusResources
+			m.resourceSel = prev.resourceSel
+			m.statusLine = "back to " + prev.app
+			return m, cmd
+		case msg.String() == "H" && m.focusResources:
+			cur := m.selectedResourceKey()
+			m.resourceHideHooks = !m.resourceHideHooks
+			m.reselectResource(cur)
+			if m.resourceHideHooks {
+				m.statusLine = "hiding hook resources"
+			} else {
+				m.statusLine = "showing hook resources"
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.DiffDrifted):
+			apps := m.driftedApps()
+			if len(apps) == 0 {
+				m.statusLine = "no drifted apps to diff"
+				return m, nil
+			}
+			return m, m.openDriftDiffs(apps)
+		case key.Matches(msg, m.keys.OpenBrowser):
+			if len(m.apps) == 0 {
+				return m, nil
+			}
+			if m.serverLabel == "mock" {
+				m.statusLine = "no Argo CD server to open (mock mode)"
+				return m, nil
+			}
+			return m, openBrowserCmd(appWebURL(m.cfg.ArgoCD.Server, m.apps[m.selected]))
+		case key.Matches(msg, m.keys.Cheatsheet):
+			cs := newCheatsheetModel(m.styles, m.keys, m.cfg.CustomActions)
+			cs.setSize(m.overlaySize())
+			m.cheatsheet = &cs
+			return m, nil
+		case msg.String() == "ctrl+g":
+			if !m.cfg.Debug {
+				m.statusLine = "request log is off (start with --debug)"
+				return m, nil
+			}
+			dbg := newDebugModel(m.styles, m.client)
+			dbg.setSize(m.overlaySize())
+			m.debugView = &dbg
+			return m, nil
+		case msg.String() == "A":
+			ev := newAllEventsModel(m.styles, m.client)
+			ev.setSize(m.overlaySize())
+			m.eventsView = &ev
+			m.statusLine = "loading events for all apps…"
+			return m, ev.initCmd()
 		case msg.String() == "E":
 			if len(m.apps) == 0 {
 				return m, nil
 			}
 			name := m.apps[m.selected].Name
 			ev := newEventsModel(m.styles, m.client, name)
-			ev.setSize(m.width-4, m.height-4)
+			ev.setSize(m.overlaySize())
 			m.eventsView = &ev
 			m.statusLine = "loading events…"
 			return m, ev.initCmd()
@@ -931,24 +1889,35 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			lv := newLogsModel(m.styles, m.client, m.detail.Name, r.Name)
-			lv.setSize(m.width-4, m.height-4)
+			lv.setSize(m.overlaySize())
 			m.logsView = &lv
 			m.statusLine = "loading logs…"
 			return m, lv.initCmd()
+		case msg.String() == "L" && m.focusResources:
+			r, ok := m.selectedResource()
+			if !ok {
+				return m, nil
+			}
+			if !strings.EqualFold(r.Kind, "pod") {
+				m.statusLine = "select a Pod to view logs and events"
+				return m, nil
+			}
+			le := newLogsEventsModel(m.styles, m.client, m.detail.Name, r.Name)
+			le.setSize(m.overlaySize())
+			m.logsEventsView = &le
+			m.statusLine = "loading logs and events…"
+			return m, le.initCmd()
 		case key.Matches(msg, m.keys.History):
 			if len(m.apps) == 0 {
 				return m, nil
 			}
-			// Prefer loaded details.
+			// Prefer loaded details; list entries carry no history.
 			app := m.apps[m.selected]
-			if m.detail != nil && m.detail.Name == app.Name {
+			loaded := m.detail != nil && m.detail.Name == app.Name
+			if loaded {
 				app = *m.detail
 			}
-			hv := newHistoryModel(m.styles, app)
-			hv.setSize(m.width-4, m.height-4)
-			m.historyView = &hv
-			m.statusLine = "history"
-			return m, nil
+			return m, m.openHistory(app, loaded)
 		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
 		case key.Matches(msg, m.keys.Diff):
@@ -963,11 +1932,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					filter = &ref
 				}
 			}
-			dv := newDiffModel(m.styles, m.client, name, filter)
-			dv.setSize(m.width-4, m.height-4)
-			m.diffView = &dv
-			m.statusLine = "loading diff…"
-			return m, dv.initCmd()
+			cmd := m.openDiff(name, filter)
+			return m, cmd
+		case key.Matches(msg, m.keys.RefreshDiff):
+			if len(m.apps) == 0 {
+				return m, nil
+			}
+			name := m.apps[m.selected].Name
+			m.statusLine = "hard refreshing, then diffing…"
+			m.detail = nil
+			m.detailErr = nil
+			m.pendingDiff = name
+			cmd := m.loadDetailCmd(name, true)
+			return m, cmd
+		case key.Matches(msg, m.keys.ExportCSV):
+			if len(m.appsAll) == 0 {
+				m.statusLine = "no applications to export"
+				return m, nil
+			}
+			m.statusLine = "exporting…"
+			return m, exportCSVCmd(m.appsAll)
 		case key.Matches(msg, m.keys.Help):
 			m.help.ShowAll = !m.help.ShowAll
 			return m, nil
@@ -981,7 +1965,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusLine = "refreshing details…"
 			m.detail = nil
 			m.detailErr = nil
-			return m, m.loadDetailCmd(m.apps[m.selected].Name, false)
+			cmd := m.loadDetailCmd(m.apps[m.selected].Name, false)
+			return m, cmd
 		case key.Matches(msg, m.keys.RefreshHard):
 			if len(m.apps) == 0 {
 				return m, nil
@@ -989,8 +1974,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusLine = "hard refreshing…"
 			m.detail = nil
 			m.detailErr = nil
-			return m, m.loadDetailCmd(m.apps[m.selected].Name, true)
+			cmd := m.loadDetailCmd(m.apps[m.selected].Name, true)
+			return m, cmd
 		case key.Matches(msg, m.keys.ToggleDrift):
+			if m.focusResources {
+				cur := m.selectedResourceKey()
+				m.resourceProblemsOnly = !m.resourceProblemsOnly
+				m.reselectResource(cur)
+				if m.resourceProblemsOnly {
+					m.statusLine = "showing problem resources only"
+				} else {
+					m.statusLine = "showing all resources"
+				}
+				return m, nil
+			}
 			m.driftOnly = !m.driftOnly
 			m.applyFilter(true)
 			m.ensureSidebarSelectionVisible()
@@ -1000,19 +1997,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.statusLine = "showing all apps"
 			}
 			return m, nil
-		case key.Matches(msg, m.keys.SyncBatch):
-			targets := make([]string, 0)
-			for _, a := range m.appsAll {
-				if a.Sync != "Synced" {
-					targets = append(targets, a.Name)
-				}
+		case key.Matches(msg, m.keys.Pin):
+			if len(m.apps) == 0 {
+				return m, nil
 			}
+			cmd := m.togglePin(m.apps[m.selected].Name)
+			return m, cmd
+		case key.Matches(msg, m.keys.PinnedOnly):
+			m.pinnedOnly = !m.pinnedOnly
+			m.applyFilter(true)
+			m.ensureSidebarSelectionVisible()
+			if m.pinnedOnly {
+				m.statusLine = "showing pinned apps only"
+			} else {
+				m.statusLine = "showing all apps"
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.SyncBatch):
+			targets := m.driftedApps()
 			if len(targets) == 0 {
 				m.statusLine = "no drifted apps to sync"
 				return m, nil
 			}
 			m.syncModal = true
 			m.syncTargets = targets
+			m.syncRevision = ""
 			m.syncPreview = m.buildSyncPreview(targets)
 			m.syncDryRunComplete = false
 			m.syncDryRunResults = nil
@@ -1025,6 +2034,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			targets := []string{m.apps[m.selected].Name}
 			m.syncModal = true
 			m.syncTargets = targets
+			m.syncRevision = ""
 			m.syncPreview = m.buildSyncPreview(targets)
 			m.syncDryRunComplete = false
 			m.syncDryRunResults = nil
@@ -1041,8 +2051,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.rollbackRevs = nil
 			m.rollbackSelected = 0
 			m.rollbackConfirm = false
+			m.clearRollbackPreview()
 			m.statusLine = "loading revisions…"
-			return m, m.loadRevisionsCmd(m.rollbackApp)
+			cmd := m.loadRevisionsCmd(m.rollbackApp)
+			return m, cmd
 		case key.Matches(msg, m.keys.TerminateOp):
 			if len(m.apps) == 0 {
 				return m, nil
@@ -1064,33 +2076,88 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusLine = "terminate operation?"
 			return m, nil
 		case key.Matches(msg, m.keys.DeleteApp):
+			if m.focusResources {
+				r, ok := m.selectedResource()
+				if !ok {
+					return m, nil
+				}
+				m.resourceDeleteModal = true
+				m.resourceDeleteApp = m.detail.Name
+				m.resourceDeleteRef = argocd.ResourceRef{Group: r.Group, Kind: r.Kind, Name: r.Name, Namespace: r.Namespace, Version: r.Version}
+				m.resourceDeleteForce = false
+				m.resourceDeleteConfirm.open(r.Name)
+				m.statusLine = "confirm resource delete"
+				return m, nil
+			}
 			if len(m.apps) == 0 {
 				return m, nil
 			}
 			m.deleteModal = true
 			m.deleteApp = m.apps[m.selected].Name
 			m.deleteCascade = false
-			m.deleteInput.SetValue("")
-			m.deleteInput.Focus()
+			m.deleteConfirm.open(m.deleteApp)
 			m.statusLine = "confirm delete"
 			return m, nil
+		case key.Matches(msg, m.keys.MarkCompare):
+			if len(m.apps) == 0 {
+				return m, nil
+			}
+			m.toggleCompareMark(m.apps[m.selected].Name)
+			m.statusLine = fmt.Sprintf("marked for compare: %s", blankIfEmpty(strings.Join(m.compareMarks, ", "), "none"))
+			return m, nil
+		case key.Matches(msg, m.keys.Compare):
+			a, b, ok := m.compareApps()
+			if !ok {
+				m.statusLine = "mark two apps with M to compare (or one, then select the other)"
+				return m, nil
+			}
+			cv := newCompareModel(m.styles, a, b)
+			cv.setSize(m.overlaySize())
+			m.compareView = &cv
+			m.statusLine = "comparing " + a.Name + " and " + b.Name
+			return m, nil
+		case key.Matches(msg, m.keys.Dashboard):
+			dv := newDashboardModel(m.styles, m.appsAll)
+			dv.setSize(m.overlaySize())
+			m.dashboardView = &dv
+			m.statusLine = "dashboard"
+			return m, nil
 		case key.Matches(msg, m.keys.CreateApp):
 			m.createModal = true
 			m.createStep = createStepName
 			m.createErr = nil
 			m.createCreating = false
-			m.createProject = ""
+			m = m.applyCreatePreset(config.CreatePreset{})
 			m.createRepo = ""
-			m.createCluster = ""
-			m.createSyncPolicy = "manual"
 			m.createNameInput.SetValue("")
 			m.createPathInput.SetValue("")
-			m.createNSInput.SetValue("")
-			m.createRevInput.SetValue("main")
-			m.createNameInput.Focus()
 			m.createList.SetItems(nil)
+			if len(m.cfg.CreatePresets) > 0 {
+				m = m.openCreatePresetStep()
+			} else {
+				m.createNameInput.Focus()
+			}
 			m.statusLine = "create app"
 			return m, tea.Batch(m.loadProjectsCmd(), m.loadReposCmd(), m.loadClustersCmd())
+		case key.Matches(msg, m.keys.CreateAppRaw):
+			m.rawCreateModal = true
+			m.rawCreateErr = nil
+			m.rawCreating = false
+			m.rawCreateInput.Reset()
+			m.sizeRawCreateInput()
+			m.statusLine = "create app from YAML"
+			return m, m.rawCreateInput.Focus()
+		case key.Matches(msg, m.keys.EditInEditor):
+			if len(m.apps) == 0 {
+				return m, nil
+			}
+			if len(editorCommand()) == 0 {
+				m.statusLine = "set $EDITOR (or $VISUAL) to edit in an external editor"
+				return m, nil
+			}
+			name := m.apps[m.selected].Name
+			m.statusLine = fmt.Sprintf("opening %s in editor…", name)
+			return m, m.editorPrepCmd(name)
 		case key.Matches(msg, m.keys.EditApp):
 			if len(m.apps) == 0 {
 				return m, nil
@@ -1115,20 +2182,64 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else {
 				m.editSyncPolicy = "manual"
 			}
+			m.editOrigSyncPolicy = m.editSyncPolicy
+			m.editAutoAck = false
 			m.editRepoInput.Focus()
 			m.statusLine = "edit app"
 			return m, nil
 		case key.Matches(msg, m.keys.Filter):
 			if m.focusResources {
-				m.resourceSearchActive = true
-				m.resourceSearchInput.SetValue("")
-				m.resourceSearchInput.Focus()
-				m.statusLine = "search resources"
+				m.resourceFilterActive = true
+				m.resourceFilterInput.Focus()
+				m.statusLine = "filter resources"
 				return m, nil
 			}
 			m.filterActive = true
 			m.filterInput.Focus()
 			return m, nil
+		case key.Matches(msg, m.keys.GoTo):
+			m.gotoActive = true
+			m.gotoInput.SetValue("")
+			m.gotoInput.Focus()
+			return m, nil
+		case key.Matches(msg, m.keys.Group):
+			m.groupMode = m.nextGroupMode()
+			m.applyFilter(true)
+			m.ensureSidebarSelectionVisible()
+			m.statusLine = "grouped by " + m.groupMode.String()
+			if m.groupMode == groupByLabel {
+				m.statusLine = "grouped by label " + m.cfg.UI.GroupLabel
+			}
+			return m, nil
+		case msg.String() == " " && m.groupMode != groupNone:
+			m.toggleGroupCollapse()
+			return m, nil
+		case msg.String() == "Z" && m.groupMode != groupNone:
+			m.groupCollapsed = nil
+			m.applyFilter(true)
+			m.ensureSidebarSelectionVisible()
+			m.statusLine = "expanded all groups"
+			return m, nil
+		case msg.String() == "!" && (m.err != nil || m.detailErr != nil):
+			m.errExpanded = !m.errExpanded
+			return m, nil
+		case key.Matches(msg, m.keys.SidebarNarrow):
+			cmd := m.resizeSidebar(-sidebarResizeStep)
+			return m, cmd
+		case key.Matches(msg, m.keys.SidebarWiden):
+			cmd := m.resizeSidebar(sidebarResizeStep)
+			return m, cmd
+		case key.Matches(msg, m.keys.Sort) && m.focusResources:
+			cur := m.selectedResourceKey()
+			m.resourceSortMode = (m.resourceSortMode + 1) % 4
+			if m.resourceSortMode == sortByWave || m.resourceSortMode == sortByName {
+				// Wave mode groups the tree differently; a zoom on a
+				// group from the other layout would hide everything.
+				m.resourceZoom = ""
+			}
+			m.reselectResource(cur)
+			m.statusLine = "resources sorted by " + m.resourceSortMode.String()
+			return m, nil
 		case key.Matches(msg, m.keys.Sort):
 			m.sortMode = (m.sortMode + 1) % 3
 			m.applyFilter(true)
@@ -1144,11 +2255,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			if m.selected > 0 {
 				m.selected--
+				m.navStack = nil
 				m.ensureSidebarSelectionVisible()
 				m.detail = nil
 				m.detailErr = nil
 				m.resourceSel = 0
-				return m, m.loadDetailCmd(m.apps[m.selected].Name, false)
+				cmd := m.debouncedDetailCmd()
+				return m, cmd
 			}
 			return m, nil
 		case key.Matches(msg, m.keys.Down):
@@ -1161,15 +2274,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			if m.selected < len(m.apps)-1 {
 				m.selected++
+				m.navStack = nil
 				m.ensureSidebarSelectionVisible()
 				m.detail = nil
 				m.detailErr = nil
 				m.resourceSel = 0
-				return m, m.loadDetailCmd(m.apps[m.selected].Name, false)
+				cmd := m.debouncedDetailCmd()
+				return m, cmd
 			}
 			return m, nil
 		case key.Matches(msg, m.keys.Clear):
+			if m.showWebUIHint() {
+				m.webUIHintDismissed = true
+				return m, nil
+			}
 			// esc outside filter mode clears the filter but keeps focus unchanged.
+			if m.focusResources && m.resourceFilterInput.Value() != "" {
+				m.resourceFilterInput.SetValue("")
+				m.clampResourceSel()
+				m.statusLine = "resource filter cleared"
+				return m, nil
+			}
 			if m.filterInput.Value() != "" {
 				m.filterInput.SetValue("")
 				m.applyFilter(true)
@@ -1177,9 +2302,80 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		}
+		if a, ok := m.customAction(msg.String()); ok {
+			return m.runCustomAction(a)
+		}
+		return m, nil
 	}
 
-	return m, nil
+	return m.updateOverlays(msg)
+}
+
+// updateOverlays forwards messages the parent doesn't handle itself (load
+// results, log lines, ...) to whichever overlays are open.
+func (m Model) updateOverlays(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	if m.resourceDetails != nil {
+		rd, cmd := m.resourceDetails.Update(msg)
+		m.resourceDetails = &rd
+		cmds = append(cmds, cmd)
+	}
+	if m.eventsView != nil {
+		ev, cmd := m.eventsView.Update(msg)
+		m.eventsView = &ev
+		cmds = append(cmds, cmd)
+	}
+	if m.logsView != nil {
+		lv, cmd := m.logsView.Update(msg)
+		m.logsView = &lv
+		cmds = append(cmds, cmd)
+	}
+	if m.logsEventsView != nil {
+		le, cmd := m.logsEventsView.Update(msg)
+		m.logsEventsView = &le
+		cmds = append(cmds, cmd)
+	}
+	if m.diffView != nil {
+		dv, cmd := m.diffView.Update(msg)
+		m.diffView = &dv
+		cmds = append(cmds, cmd)
+	}
+	if m.driftDiffs != nil {
+		dd, cmd := m.driftDiffs.Update(msg)
+		m.driftDiffs = &dd
+		cmds = append(cmds, cmd)
+	}
+	if m.historyView != nil {
+		hv, cmd := m.historyView.Update(msg)
+		m.historyView = &hv
+		cmds = append(cmds, cmd)
+	}
+	if m.revisionView != nil {
+		rv, cmd := m.revisionView.Update(msg)
+		m.revisionView = &rv
+		cmds = append(cmds, cmd)
+	}
+	if m.revisionDiff != nil {
+		rd, cmd := m.revisionDiff.Update(msg)
+		m.revisionDiff = &rd
+		cmds = append(cmds, cmd)
+	}
+	if m.actionsView != nil {
+		av, cmd := m.actionsView.Update(msg)
+		m.actionsView = &av
+		cmds = append(cmds, cmd)
+	}
+	if m.dashboardView != nil {
+		dv, cmd := m.dashboardView.Update(msg)
+		m.dashboardView = &dv
+		cmds = append(cmds, cmd)
+	}
+	if m.compareView != nil {
+		cv, cmd := m.compareView.Update(msg)
+		m.compareView = &cv
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
 }
 
 func (m Model) View() string {
@@ -1187,53 +2383,99 @@ func (m Model) View() string {
 		return ""
 	}
 
+	header := m.renderHeader()
+	footer := m.renderFooter(m.width)
+	bodyHeight := m.bodyHeight()
+
+	sidebarWidth, mainWidth := m.paneWidths()
+	sidebar := m.renderSidebar(sidebarWidth, bodyHeight)
+	main := m.renderMain(mainWidth, max(0, bodyHeight-m.styles.Main.GetVerticalFrameSize()))
+
+	row := lipgloss.JoinHorizontal(lipgloss.Top, sidebar, main)
+
+	if m.ringing {
+		// BEL takes no cell; the renderer writes it out with the header line.
+		header = "\a" + header
+	}
+	header = m.clipboard + header
+	return lipgloss.JoinVertical(lipgloss.Top, header, row, footer)
+}
+
+// renderHeader renders the top bar: the title, the active view tags and any
+// open filter or go-to input.
+func (m Model) renderHeader() string {
 	headerTitle := "lazyArgo"
 	if m.driftOnly {
 		headerTitle += "  [drift]"
 	}
-	headerTitle += "  [sort:" + m.sortMode.String() + "]"
+	if m.pinnedOnly {
+		headerTitle += "  [pinned]"
+	}
+	if m.groupMode == groupByLabel {
+		headerTitle += "  [group:" + m.cfg.UI.GroupLabel + "]"
+	} else if m.groupMode != groupNone {
+		headerTitle += "  [group:" + m.groupMode.String() + "]"
+	}
+	if q, _ := parseAppFilter(m.filterInput.Value()); q != "" {
+		headerTitle += "  [sort:match]"
+	} else {
+		headerTitle += "  [sort:" + m.sortMode.String() + "]"
+	}
+	if crumb := m.breadcrumb(); crumb != "" {
+		headerTitle += "  " + crumb
+	}
 	if m.filterInput.Value() != "" || m.filterActive {
 		headerTitle = headerTitle + "  " + m.filterInput.View()
 	}
-	header := m.styles.Header.Width(m.width).Render(headerTitle)
-
-	footer := m.renderFooter(m.width)
-
-	bodyHeight := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
-	if bodyHeight < 0 {
-		bodyHeight = 0
+	if m.gotoActive {
+		headerTitle = headerTitle + "  " + m.gotoInput.View()
 	}
+	return m.styles.Header.Width(m.width).Render(headerTitle)
+}
 
-	sidebarWidth := m.cfg.UI.SidebarWidth
-	if sidebarWidth < 20 {
-		sidebarWidth = 20
+// syncListEntry copies the status of freshly loaded details onto the app's
+// list entry, so the sidebar and the drift count don't lag behind the detail
+// pane until the next list refresh.
+func (m *Model) syncListEntry(app argocd.Application) {
+	i := slices.IndexFunc(m.appsAll, func(a argocd.Application) bool { return a.Name == app.Name })
+	if i < 0 {
+		return
 	}
-	mainWidth := m.width - sidebarWidth
-	if mainWidth < 20 {
-		mainWidth = 20
-		sidebarWidth = max(20, m.width-mainWidth)
+	cur := m.appsAll[i]
+	sameOp := (cur.OperationState == nil) == (app.OperationState == nil) &&
+		(cur.OperationState == nil || *cur.OperationState == *app.OperationState)
+	if cur.Health == app.Health && cur.Sync == app.Sync && sameOp {
+		return
 	}
-
-	sidebar := m.renderSidebar(sidebarWidth, bodyHeight)
-	main := m.renderMain(mainWidth, bodyHeight)
-
-	row := lipgloss.JoinHorizontal(lipgloss.Top, sidebar, main)
-
-	return lipgloss.JoinVertical(lipgloss.Top, header, row, footer)
+	// The slice is shared with earlier copies of the model; don't write
+	// through it.
+	m.appsAll = slices.Clone(m.appsAll)
+	m.appsAll[i].Health = app.Health
+	m.appsAll[i].Sync = app.Sync
+	m.appsAll[i].OperationState = app.OperationState
+	m.driftCount = countDrifted(m.appsAll)
+	m.applyFilter(true)
+	m.ensureSidebarSelectionVisible()
 }
 
-func (m Model) renderFooter(w int) string {
-	drifted := 0
-	for _, a := range m.appsAll {
+// countDrifted returns how many apps are not Synced.
+func countDrifted(apps []argocd.Application) int {
+	n := 0
+	for _, a := range apps {
 		if a.Sync != "Synced" {
-			drifted++
+			n++
 		}
 	}
+	return n
+}
+
+func (m Model) renderFooter(w int) string {
+	drifted := m.driftCount
 
 	ts := "never"
 	if !m.lastRefresh.IsZero() {
 		// Keep it compact.
-		ts = m.lastRefresh.Format("15:04:05Z")
+		ts = m.lastRefresh.Format("15:04:05Z") + " (" + relativeTime(m.lastRefresh, time.Now()) + ")"
 	}
 
 	label := func(s string) string { return m.styles.StatusLabel.Render(s) }
@@ -1250,14 +2492,17 @@ func (m Model) renderFooter(w int) string {
 		label("apps:") + val(fmt.Sprintf("%d", len(m.appsAll))),
 		label("drift:") + driftStyle.Render(fmt.Sprintf("%d", drifted)),
 	}
-	if strings.TrimSpace(m.statusLine) != "" {
+	if m.alert != "" {
+		leftParts = append(leftParts, m.styles.Error.Bold(true).Render("⚠ "+m.alert))
+	} else if strings.TrimSpace(m.statusLine) != "" {
 		leftParts = append(leftParts, label("msg:")+val(m.statusLine))
 	}
 	left := strings.Join(leftParts, "  ")
 
-	right := m.help.View(m.keys)
+	right := m.help.View(m.footerHelp())
 
-	gap := w - lipgloss.Width(left) - lipgloss.Width(right)
+	// The bar's padding comes out of w.
+	gap := w - m.styles.StatusBar.GetHorizontalFrameSize() - lipgloss.Width(left) - lipgloss.Width(right)
 	if gap < 1 {
 		gap = 1
 	}
@@ -1279,42 +2524,165 @@ func (m Model) renderSidebar(w, h int) string {
 		lines = append(lines, m.styles.Error.Render(m.err.Error()))
 	}
 
-	// Render only the visible window of apps.
-	maxItems := h - len(lines)
-	if maxItems < 0 {
-		maxItems = 0
-	}
-	start := clamp(m.sidebarOffset, 0, max(0, len(m.apps)-1))
-	end := min(len(m.apps), start+maxItems)
+	// Render only the visible window of apps; the border sits outside the
+	// styled height.
+	h = max(0, h-m.styles.Sidebar.GetVerticalFrameSize())
+	maxItems := m.sidebarListRows()
+	_, total := m.sidebarPosition()
+	start := clamp(m.sidebarOffset, 0, max(0, total-1))
+	end := min(total, start+sidebarRowsShown(start, maxItems, total))
 
 	for i := start; i < end; i++ {
-		a := m.apps[i]
-		name := a.Name
-		if a.Sync != "" && a.Sync != "Synced" {
-			name = "! " + name
+		if m.sidebarRows == nil {
+			lines = append(lines, m.renderSidebarApp(i, "", w-2))
+			continue
 		}
-		if i == m.selected {
-			lines = append(lines, m.styles.SidebarSelected.Render("▶ "+name))
+		r := m.sidebarRows[i]
+		if r.header {
+			lines = append(lines, m.renderGroupHeader(r))
 		} else {
-			lines = append(lines, m.styles.SidebarItem.Render("  "+name))
+			lines = append(lines, m.renderSidebarApp(r.app, " ", w-2))
 		}
 	}
 
-	// If there's room, show a small hint when list is truncated.
-	if len(m.apps) > end && maxItems > 0 {
-		lines[len(lines)-1] = lines[len(lines)-1] + m.styles.SidebarItem.Render("  …")
+	if total > end && maxItems > 0 {
+		lines = append(lines, m.styles.SidebarItem.Render(fmt.Sprintf("  ▼ %d more", total-end)))
 	}
 
 	content := strings.Join(lines, "\n")
 	return m.styles.Sidebar.Width(w).Height(h).Render(content)
 }
 
+// renderSidebarApp renders the sidebar line for m.apps[i] in width columns;
+// indent shifts apps under group headers.
+func (m Model) renderSidebarApp(i int, indent string, width int) string {
+	a := m.apps[i]
+	prefix := indent + "  "
+	base := m.styles.SidebarItem
+	if i == m.selected {
+		prefix = indent + "▶ "
+		base = m.styles.SidebarSelected
+	}
+	for _, n := range m.compareMarks {
+		if n == a.Name {
+			prefix += "◆ "
+		}
+	}
+	if m.pinned[a.Name] {
+		prefix += "★ "
+	}
+	switch {
+	case m.cfg.UI.NoColor:
+		// Without color a glyph is the only health/sync cue.
+		prefix += appStateGlyph(a) + " "
+	case a.Sync != "" && a.Sync != "Synced":
+		prefix += "! "
+	}
+
+	// Right-aligned "N▲" out-of-sync resource count; the name is truncated
+	// first so the count survives narrow sidebars.
+	ann := ""
+	if m.cfg.UI.SidebarDriftCounts {
+		if n, ok := m.outOfSyncResources(a); ok && n > 0 {
+			ann = fmt.Sprintf("%d▲", n)
+		}
+	}
+	name := a.Name
+	room := width - lipgloss.Width(prefix)
+	if ann != "" {
+		room -= lipgloss.Width(ann) + 1
+	}
+	if room > 0 && lipgloss.Width(name) > room {
+		name = truncate(name, room)
+	}
+
+	// Matches keep the row's own style (and selected background) and add
+	// the match emphasis on top.
+	hl := base.Inherit(m.styles.FilterMatch)
+	line := base.Render(prefix) + highlightRunes(name, m.filterMatches[a.Name], base, hl)
+	if ann == "" {
+		return line
+	}
+	gap := max(1, width-lipgloss.Width(prefix)-lipgloss.Width(name)-lipgloss.Width(ann))
+	return line + base.Render(strings.Repeat(" ", gap)) + base.Inherit(m.styles.StatusWarn).Render(ann)
+}
+
+// toggleCompareMark marks or unmarks an app for comparison. Marking a third
+// drops the oldest mark.
+func (m *Model) toggleCompareMark(name string) {
+	for i, n := range m.compareMarks {
+		if n == name {
+			m.compareMarks = append(m.compareMarks[:i:i], m.compareMarks[i+1:]...)
+			return
+		}
+	}
+	m.compareMarks = append(m.compareMarks, name)
+	if len(m.compareMarks) > 2 {
+		m.compareMarks = m.compareMarks[len(m.compareMarks)-2:]
+	}
+}
+
+// compareApps returns the apps to compare: the two marked ones, or the one
+// marked and the selected app. It prefers the loaded details over the list
+// entry.
+func (m Model) compareApps() (a, b argocd.Application, ok bool) {
+	names := append([]string{}, m.compareMarks...)
+	if len(names) == 1 && len(m.apps) > 0 && m.apps[m.selected].Name != names[0] {
+		names = append(names, m.apps[m.selected].Name)
+	}
+	if len(names) != 2 {
+		return a, b, false
+	}
+	lookup := func(name string) (argocd.Application, bool) {
+		if m.detail != nil && m.detail.Name == name {
+			return *m.detail, true
+		}
+		for _, app := range m.appsAll {
+			if app.Name == name {
+				return app, true
+			}
+		}
+		return argocd.Application{}, false
+	}
+	a, okA := lookup(names[0])
+	b, okB := lookup(names[1])
+	return a, b, okA && okB
+}
+
+// outOfSyncResources counts a's resources that are not Synced. ok is false
+// when no resource data is loaded for the app (neither in the list nor in
+// the open detail).
+func (m Model) outOfSyncResources(a argocd.Application) (int, bool) {
+	resources := a.Resources
+	if len(resources) == 0 && m.detail != nil && m.detail.Name == a.Name {
+		resources = m.detail.Resources
+	}
+	if len(resources) == 0 {
+		return 0, false
+	}
+	n := 0
+	for _, r := range resources {
+		if strings.TrimSpace(r.Status) != "" && !strings.EqualFold(r.Status, "synced") {
+			n++
+		}
+	}
+	return n, true
+}
+
 func (m Model) renderMain(w, h int) string {
+	if m.cheatsheet != nil {
+		return m.styles.Main.Width(w).Height(h).Render(m.cheatsheet.View())
+	}
 	var content string
 	// If the initial list load failed, show a helpful error page.
 	if m.err != nil {
-		content = "Error loading applications:\n\n" + m.err.Error() + "\n\n" +
-			"Common fixes:\n" +
+		content = "Error loading applications:\n\n" + errorText(m.err, m.errExpanded) + "\n\n"
+		if m.showWebUIHint() {
+			content += m.styles.StatusWarn.Render("This looks like the Argo CD web UI, not the API — ensure you're hitting the argocd-server API port\n"+
+				"(e.g. kubectl -n argocd port-forward svc/argocd-server 8080:443) and that the server URL has no extra path.") +
+				"\n(esc = dismiss)\n\n"
+		}
+		content += "Common fixes:\n" +
 			"  • Ensure ARGOCD_SERVER is reachable (default expects a local port-forward)\n" +
 			"  • Ensure ARGOCD_AUTH_TOKEN is set\n" +
 			"  • If using https://localhost:8080 and you see TLS errors, use --insecure or ARGOCD_INSECURE=true\n\n" +
@@ -1331,12 +2699,36 @@ func (m Model) renderMain(w, h int) string {
 	if m.logsView != nil {
 		return m.styles.Main.Width(w).Height(h).Render(m.logsView.View())
 	}
+	if m.logsEventsView != nil {
+		return m.styles.Main.Width(w).Height(h).Render(m.logsEventsView.View())
+	}
+	if m.debugView != nil {
+		return m.styles.Main.Width(w).Height(h).Render(m.debugView.View())
+	}
+	if m.actionOutput != nil {
+		return m.styles.Main.Width(w).Height(h).Render(m.actionOutput.View())
+	}
 	if m.diffView != nil {
 		return m.styles.Main.Width(w).Height(h).Render(m.diffView.View())
 	}
+	if m.driftDiffs != nil {
+		return m.styles.Main.Width(w).Height(h).Render(m.driftDiffs.View())
+	}
+	if m.revisionDiff != nil {
+		return m.styles.Main.Width(w).Height(h).Render(m.revisionDiff.View())
+	}
 	if m.revisionView != nil {
 		return m.styles.Main.Width(w).Height(h).Render(m.revisionView.View())
 	}
+	if m.actionsView != nil {
+		return m.styles.Main.Width(w).Height(h).Render(m.actionsView.View())
+	}
+	if m.dashboardView != nil {
+		return m.styles.Main.Width(w).Height(h).Render(m.dashboardView.View())
+	}
+	if m.compareView != nil {
+		return m.styles.Main.Width(w).Height(h).Render(m.compareView.View())
+	}
 	if m.historyView != nil {
 		return m.styles.Main.Width(w).Height(h).Render(m.historyView.View())
 	}
@@ -1346,11 +2738,25 @@ func (m Model) renderMain(w, h int) string {
 	if m.createModal {
 		return m.styles.Main.Width(w).Height(h).Render(m.renderCreateWizard())
 	}
+	if m.rawCreateModal {
+		return m.styles.Main.Width(w).Height(h).Render(m.renderRawCreate())
+	}
 	if m.deleteModal {
 		lines := []string{fmt.Sprintf("Delete application: %s", m.deleteApp), ""}
 		lines = append(lines, "This is destructive.")
 		lines = append(lines, fmt.Sprintf("Cascade delete: %v (press 'c' to toggle)", m.deleteCascade))
-		lines = append(lines, "", "Type the application name to confirm:", m.deleteInput.View(), "")
+		lines = append(lines, "", "Type the application name to confirm:", m.deleteConfirm.View(), "")
+		lines = append(lines, "Enter=delete  Esc=cancel")
+		content = strings.Join(lines, "\n")
+		return m.styles.Main.Width(w).Height(h).Render(content)
+	}
+	if m.resourceDeleteModal {
+		ref := m.resourceDeleteRef
+		lines := []string{fmt.Sprintf("Delete resource: %s/%s (%s)", ref.Kind, ref.Name, blankIfEmpty(ref.Namespace, "cluster")), ""}
+		lines = append(lines, m.styles.StatusWarn.Render(fmt.Sprintf("This deletes the live object from the cluster, not just from %s.", m.resourceDeleteApp)))
+		lines = append(lines, "If it is still in Git, the next sync will recreate it.")
+		lines = append(lines, fmt.Sprintf("Force delete: %v (press tab to toggle)", m.resourceDeleteForce))
+		lines = append(lines, "", "Type the resource name to confirm:", m.resourceDeleteConfirm.View(), "")
 		lines = append(lines, "Enter=delete  Esc=cancel")
 		content = strings.Join(lines, "\n")
 		return m.styles.Main.Width(w).Height(h).Render(content)
@@ -1362,6 +2768,8 @@ func (m Model) renderMain(w, h int) string {
 		}
 		if m.terminateLoading {
 			lines = append(lines, "Terminating…")
+		} else if m.destructiveConfirm.active() {
+			lines = append(lines, "Type the application name to confirm:", m.destructiveConfirm.View(), "", "Enter=terminate  Esc=cancel")
 		} else if m.terminateConfirm {
 			lines = append(lines, "Confirm terminate? y=confirm, n/esc=cancel")
 		} else {
@@ -1400,6 +2808,13 @@ func (m Model) renderMain(w, h int) string {
 			}
 			lines = append(lines, "")
 			if m.rollbackConfirm {
+				lines = append(lines, m.rollbackPreviewLines()...)
+				lines = append(lines, "")
+			}
+			if m.destructiveConfirm.active() {
+				rev := m.rollbackRevs[m.rollbackSelected]
+				lines = append(lines, fmt.Sprintf("Rollback to #%d: type the application name to confirm:", rev.ID), m.destructiveConfirm.View(), "", "Enter=rollback  Esc=cancel")
+			} else if m.rollbackConfirm {
 				rev := m.rollbackRevs[m.rollbackSelected]
 				lines = append(lines, fmt.Sprintf("Confirm rollback to #%d? y=confirm, n/esc=cancel", rev.ID))
 			} else {
@@ -1411,6 +2826,10 @@ func (m Model) renderMain(w, h int) string {
 	}
 	if m.syncModal {
 		lines := []string{"Sync (dry-run preview)", ""}
+		if m.syncRevision != "" {
+			lines = []string{"Sync to revision " + m.syncRevision + " (dry-run preview)", ""}
+		}
+		lines = append(lines, syncImpactSummary(m.syncTargets, m.syncPreview), "")
 		lines = append(lines, fmt.Sprintf("Targets: %d", len(m.syncTargets)))
 		for _, name := range m.syncTargets {
 			lines = append(lines, "  - "+name)
@@ -1434,7 +2853,9 @@ func (m Model) renderMain(w, h int) string {
 			}
 		}
 		lines = append(lines, "")
-		if !m.syncDryRunComplete {
+		if m.syncWatch != nil {
+			lines = append(lines, m.syncWatchLines()...)
+		} else if !m.syncDryRunComplete {
 			lines = append(lines, "Running dry-run…")
 		} else {
 			lines = append(lines, "Dry-run results:")
@@ -1450,7 +2871,7 @@ func (m Model) renderMain(w, h int) string {
 					lines = append(lines, fmt.Sprintf("  ✓ %s%s", r.name, suffix))
 				}
 			}
-			lines = append(lines, "", "Press y to run sync, n/esc to cancel.")
+			lines = append(lines, "", "Press y to run sync, w to sync and watch it here, n/esc to cancel.")
 		}
 		content = strings.Join(lines, "\n")
 		return m.styles.Main.Width(w).Height(h).Render(content)
@@ -1471,19 +2892,33 @@ func (m Model) renderMain(w, h int) string {
 
 	detailBlock := ""
 	if m.detailErr != nil {
-		detailBlock = "\n\nError loading details:\n\n" + m.detailErr.Error() + "\n\nPress 'r' to retry."
+		detailBlock = "\n\nError loading details:\n\n" + errorText(m.detailErr, m.errExpanded) + "\n\nPress 'r' to retry."
 	}
 
 	conds := renderConditions(app.Conditions, m.styles)
 	wins := renderSyncWindows(m.syncWindows[app.Name], m.syncWindowsErr[app.Name], m.styles)
+	trendLine := ""
+	if trend := m.healthTrend.render(app.Name, time.Now(), m.styles); trend != "" {
+		trendLine = "\nTrend:     " + trend
+	}
+	windowLine := ""
+	if ws, ok := m.syncWindows[app.Name]; ok && m.syncWindowsErr[app.Name] == nil {
+		windowLine = "\nWindow:    " + syncWindowStatus(ws, time.Now(), m.styles)
+	}
+	if hint := m.staleReconcileHint(app, time.Now()); hint != "" {
+		windowLine += "\n" + m.styles.StatusWarn.Render(hint)
+	}
 
 	content = fmt.Sprintf(
-		"Name:      %s\nNamespace: %s\nProject:   %s\nHealth:    %s\nSync:      %s\nRepo:      %s\nPath:      %s\nRevision:  %s\nCluster:   %s\n\nConditions:\n%s\n\nSync windows:\n%s\n\nResources:\n%s\n\n%s%s",
+		"Name:      %s\nNamespace: %s\nProject:   %s\nLabels:    %s\nHealth:    %s%s\nSync:      %s%s\nRepo:      %s\nPath:      %s\nRevision:  %s\nCluster:   %s\n\nConditions:\n%s\n\nSync windows:\n%s\n\nResources:\n%s\n\n%s%s",
 		app.Name,
 		app.Namespace,
 		app.Project,
+		formatLabels(app.Labels),
 		app.Health,
+		trendLine,
 		app.Sync,
+		windowLine,
 		blankIfEmpty(app.RepoURL, "—"),
 		blankIfEmpty(app.Path, "—"),
 		blankIfEmpty(app.Revision, "—"),
@@ -1498,25 +2933,158 @@ func (m Model) renderMain(w, h int) string {
 	return m.styles.Main.Width(w).Height(h).Render(content)
 }
 
+// staleReconcileHint warns when the controller has not compared app with
+// git for longer than ui.staleReconcileMinutes, which usually means it has
+// stopped reconciling it.
+func (m Model) staleReconcileHint(app argocd.Application, now time.Time) string {
+	limit := time.Duration(m.cfg.UI.StaleReconcileMinutes) * time.Minute
+	at, ok := parseTimestamp(app.ReconciledAt)
+	if limit <= 0 || !ok || now.Sub(at) < limit {
+		return ""
+	}
+	return "⚠ last reconciled " + relativeTime(at, now) + " — press R to refresh"
+}
+
+// showWebUIHint reports whether the list error page should suggest that the
+// server is the web UI rather than the API.
+func (m Model) showWebUIHint() bool {
+	return m.err != nil && !m.webUIHintDismissed && argocd.LooksLikeWebUI(m.err)
+}
+
+// breadcrumb renders the drill-in path ("root > child") while navStack is non-empty.
+func (m Model) breadcrumb() string {
+	if len(m.navStack) == 0 || len(m.apps) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(m.navStack)+1)
+	for _, e := range m.navStack {
+		parts = append(parts, e.app)
+	}
+	parts = append(parts, m.apps[m.selected].Name)
+	return strings.Join(parts, " > ") + "  (backspace=back)"
+}
+
+// selectAppByName moves the sidebar selection to the named app and starts
+// loading its details. It reports false if the app isn't in the current list.
+func (m *Model) selectAppByName(name string) (tea.Cmd, bool) {
+	for i := range m.apps {
+		if m.apps[i].Name != name {
+			continue
+		}
+		m.selected = i
+		m.ensureSidebarSelectionVisible()
+		m.detail = nil
+		m.detailErr = nil
+		m.resourceSel = 0
+		m.resourceZoom = ""
+		return m.loadDetailCmd(name, false), true
+	}
+	return nil, false
+}
+
+// gotoMatch returns the index of the app named q, or else of the first app
+// whose name starts with q (case-insensitively), or -1.
+func gotoMatch(apps []argocd.Application, q string) int {
+	prefix := -1
+	for i, a := range apps {
+		if strings.EqualFold(a.Name, q) {
+			return i
+		}
+		if prefix < 0 && len(a.Name) >= len(q) && strings.EqualFold(a.Name[:len(q)], q) {
+			prefix = i
+		}
+	}
+	return prefix
+}
+
+// gotoApp selects the app matching q, expanding its sidebar group if it is
+// collapsed. Apps hidden by the filter are reported rather than revealed.
+func (m *Model) gotoApp(q string) (tea.Cmd, bool) {
+	i := gotoMatch(m.apps, q)
+	if i < 0 {
+		j := gotoMatch(m.appsAll, q)
+		if j < 0 {
+			m.statusLine = "no app matching " + q
+			return nil, false
+		}
+		if g := m.appGroup(m.appsAll[j]); m.groupMode != groupNone && m.groupCollapsed[g] {
+			delete(m.groupCollapsed, g)
+			m.applyFilter(true)
+			i = gotoMatch(m.apps, q)
+		}
+		if i < 0 {
+			m.statusLine = m.appsAll[j].Name + " is hidden by the filter"
+			return nil, false
+		}
+	}
+	name := m.apps[i].Name
+	m.statusLine = "went to " + name
+	m.navStack = nil
+	return m.selectAppByName(name)
+}
+
+func (m *Model) closeGoto() {
+	m.gotoActive = false
+	m.gotoInput.SetValue("")
+	m.gotoInput.Blur()
+}
+
 func (m *Model) applyFilter(keepSelectionByName bool) {
+	selectedName := ""
+	if len(m.apps) > 0 && m.selected >= 0 && m.selected < len(m.apps) {
+		selectedName = m.apps[m.selected].Name
+	}
+	// The drill-in path only holds while its last app stays selected.
+	defer func() {
+		if len(m.apps) == 0 || m.apps[m.selected].Name != selectedName {
+			m.navStack = nil
+		}
+	}()
 	prevName := ""
-	if keepSelectionByName && len(m.apps) > 0 && m.selected >= 0 && m.selected < len(m.apps) {
-		prevName = m.apps[m.selected].Name
+	if keepSelectionByName {
+		prevName = selectedName
 	}
 
-	q := strings.ToLower(strings.TrimSpace(m.filterInput.Value()))
+	q, selectors := parseAppFilter(m.filterInput.Value())
 	filtered := make([]argocd.Application, 0, len(m.appsAll))
+	scores := map[string]int{}
+	m.filterMatches = nil
+	if q != "" {
+		m.filterMatches = map[string][]int{}
+	}
 	for _, a := range m.appsAll {
-		if q != "" && !strings.Contains(strings.ToLower(a.Name), q) {
+		if m.driftOnly && a.Sync == "Synced" {
 			continue
 		}
-		if m.driftOnly && a.Sync == "Synced" {
+		if m.pinnedOnly && !m.pinned[a.Name] {
+			continue
+		}
+		if !matchesAllLabels(a.Labels, selectors) {
 			continue
 		}
+		if q != "" {
+			score, pos, ok := fuzzyMatch(q, a.Name)
+			if !ok {
+				continue
+			}
+			scores[a.Name] = score
+			m.filterMatches[a.Name] = pos
+		}
 		filtered = append(filtered, a)
 	}
 	m.apps = filtered
 	m.sortApps()
+	// While a query is active, match quality overrides the sort mode; the
+	// sort only breaks ties. Pins still come first.
+	if q != "" {
+		sort.SliceStable(m.apps, func(i, j int) bool {
+			if pi, pj := m.pinned[m.apps[i].Name], m.pinned[m.apps[j].Name]; pi != pj {
+				return pi
+			}
+			return scores[m.apps[i].Name] > scores[m.apps[j].Name]
+		})
+	}
+	m.groupApps()
 
 	if len(m.apps) == 0 {
 		m.selected = 0
@@ -1545,44 +3113,11 @@ func (m *Model) sortApps() {
 		return
 	}
 
-	healthRank := func(s string) int {
-		s = strings.TrimSpace(strings.ToLower(s))
-		switch s {
-		case "degraded":
-			return 0
-		case "missing":
-			return 1
-		case "suspended":
-			return 2
-		case "progressing":
-			return 3
-		case "healthy":
-			return 4
-		case "":
-			return 98
-		default:
-			return 50
-		}
-	}
-
-	syncRank := func(s string) int {
-		s = strings.TrimSpace(strings.ToLower(s))
-		switch s {
-		case "outofsync", "out-of-sync", "out_of_sync":
-			return 0
-		case "unknown":
-			return 1
-		case "synced":
-			return 2
-		case "":
-			return 98
-		default:
-			return 50
-		}
-	}
-
 	sort.SliceStable(m.apps, func(i, j int) bool {
 		a, b := m.apps[i], m.apps[j]
+		if pa, pb := m.pinned[a.Name], m.pinned[b.Name]; pa != pb {
+			return pa
+		}
 		switch m.sortMode {
 		case sortByHealth:
 			ri, rj := healthRank(a.Health), healthRank(b.Health)
@@ -1601,6 +3136,44 @@ func (m *Model) sortApps() {
 	})
 }
 
+// healthRank orders health statuses worst first.
+func healthRank(s string) int {
+	s = strings.TrimSpace(strings.ToLower(s))
+	switch s {
+	case "degraded":
+		return 0
+	case "missing":
+		return 1
+	case "suspended":
+		return 2
+	case "progressing":
+		return 3
+	case "healthy":
+		return 4
+	case "":
+		return 98
+	default:
+		return 50
+	}
+}
+
+// syncRank orders sync statuses out-of-sync first.
+func syncRank(s string) int {
+	s = strings.TrimSpace(strings.ToLower(s))
+	switch s {
+	case "outofsync", "out-of-sync", "out_of_sync":
+		return 0
+	case "unknown":
+		return 1
+	case "synced":
+		return 2
+	case "":
+		return 98
+	default:
+		return 50
+	}
+}
+
 func (m *Model) ensureSidebarSelectionVisible() {
 	if len(m.apps) == 0 {
 		m.sidebarOffset = 0
@@ -1610,27 +3183,52 @@ func (m *Model) ensureSidebarSelectionVisible() {
 		return
 	}
 
-	// Approximate visible rows: header (1) + help (1) + sidebar title+rule (2).
-	bodyHeight := m.height - 2
-	if bodyHeight < 0 {
-		bodyHeight = 0
-	}
-	visible := bodyHeight - 2
-	if visible < 1 {
-		visible = 1
-	}
+	visible := max(1, m.sidebarListRows())
 
-	if m.selected < m.sidebarOffset {
-		m.sidebarOffset = m.selected
+	sel, total := m.sidebarPosition()
+	top := sel
+	if top > 0 && m.sidebarRows != nil && m.sidebarRows[top-1].header {
+		// Keep the group header in view above its first app.
+		top--
+	}
+	if top < m.sidebarOffset {
+		m.sidebarOffset = top
 	}
-	if m.selected >= m.sidebarOffset+visible {
-		m.sidebarOffset = m.selected - visible + 1
+	if sel >= m.sidebarOffset+sidebarRowsShown(m.sidebarOffset, visible, total) {
+		// Scrolling down: the selection becomes the last row above the
+		// "▼ N more" line, or the last row once the list end is in view.
+		m.sidebarOffset = sel - sidebarRowsShown(0, visible, total) + 1
 	}
 
-	maxOffset := max(0, len(m.apps)-visible)
+	maxOffset := max(0, total-visible)
 	m.sidebarOffset = clamp(m.sidebarOffset, 0, maxOffset)
 }
 
+// sidebarRowsShown is how many list rows fit in a window of visible lines
+// starting at offset: one fewer while rows remain below it, so the last line
+// can say how many.
+func sidebarRowsShown(offset, visible, total int) int {
+	if visible > 1 && offset+visible < total {
+		return visible - 1
+	}
+	return visible
+}
+
+// appStateGlyph summarizes an app's state as ✗ (degraded/missing),
+// ! (out of sync), ✓ (synced and healthy) or blank (anything else).
+func appStateGlyph(a argocd.Application) string {
+	switch {
+	case strings.EqualFold(a.Health, "degraded") || strings.EqualFold(a.Health, "missing"):
+		return "✗"
+	case a.Sync != "" && a.Sync != "Synced":
+		return "!"
+	case a.Sync == "Synced" && a.Health == "Healthy":
+		return "✓"
+	default:
+		return " "
+	}
+}
+
 func blankIfEmpty(s, fallback string) string {
 	if s == "" {
 		return fallback
@@ -1654,6 +3252,8 @@ func (m *Model) buildSyncPreview(targets []string) map[string][]argocd.Resource
 			}
 		}
 		if len(rs) == 0 {
+			// Without resource data there's nothing to say; no entry
+			// tells the summary so.
 			continue
 		}
 		out := make([]argocd.Resource, 0)
@@ -1662,22 +3262,94 @@ func (m *Model) buildSyncPreview(targets []string) map[string][]argocd.Resource
 				out = append(out, r)
 			}
 		}
-		if len(out) > 0 {
-			preview[name] = out
-		}
+		preview[name] = out
 	}
 	return preview
 }
 
+// syncImpactSummary totals a sync preview: apps, drifted resources and a
+// breakdown by kind, most frequent first, e.g.
+// "Impact: 3 apps, 6 resources — Deployments: 4, Services: 2". Apps with no
+// preview entry have no resource data (the list doesn't carry it), so the
+// totals can't cover them; they are counted separately.
+func syncImpactSummary(targets []string, preview map[string][]argocd.Resource) string {
+	apps := countNoun(len(targets), "app")
+	counts := map[string]int{}
+	total, unknown := 0, 0
+	for _, name := range targets {
+		rs, ok := preview[name]
+		if !ok {
+			unknown++
+		}
+		for _, r := range rs {
+			counts[r.Kind]++
+			total++
+		}
+	}
+	missing := ""
+	if unknown > 0 {
+		missing = fmt.Sprintf("; %s without resource data", countNoun(unknown, "app"))
+	}
+	if total == 0 {
+		return "Impact: " + apps + ", no drifted resources known" + missing
+	}
+	kinds := make([]string, 0, len(counts))
+	for k := range counts {
+		kinds = append(kinds, k)
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		if counts[kinds[i]] != counts[kinds[j]] {
+			return counts[kinds[i]] > counts[kinds[j]]
+		}
+		return kinds[i] < kinds[j]
+	})
+	parts := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		label := k
+		if counts[k] != 1 {
+			label = pluralKind(k)
+		}
+		parts = append(parts, fmt.Sprintf("%s: %d", label, counts[k]))
+	}
+	return fmt.Sprintf("Impact: %s, %s — %s%s", apps, countNoun(total, "resource"), strings.Join(parts, ", "), missing)
+}
+
+// countNoun renders n and noun, adding an s unless n is 1.
+func countNoun(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// pluralKind pluralizes a Kubernetes kind the way English would:
+// Ingress → Ingresses, NetworkPolicy → NetworkPolicies. Kinds already in the
+// plural, like Endpoints, are left alone.
+func pluralKind(kind string) string {
+	switch {
+	case strings.HasSuffix(kind, "s") && !strings.HasSuffix(kind, "ss"):
+		return kind
+	case strings.HasSuffix(kind, "ss"), strings.HasSuffix(kind, "x"), strings.HasSuffix(kind, "ch"), strings.HasSuffix(kind, "sh"):
+		return kind + "es"
+	case strings.HasSuffix(kind, "y") && len(kind) > 1 && !strings.ContainsAny(kind[len(kind)-2:len(kind)-1], "aeiou"):
+		return kind[:len(kind)-1] + "ies"
+	}
+	return kind + "s"
+}
+
 func (m Model) resetCreateWizard() Model {
 	m.createModal = false
 	m.createStep = createStepName
 	m.createErr = nil
 	m.createCreating = false
+	m.createValidating = false
+	m.createValidateErr = nil
+	m.createValidateGen++
 	m.createProject = ""
 	m.createRepo = ""
 	m.createCluster = ""
 	m.createSyncPolicy = "manual"
+	m.createPreset = ""
 	m.createNameInput.Blur()
 	m.createPathInput.Blur()
 	m.createNSInput.Blur()
@@ -1692,6 +3364,146 @@ func (s stringItem) Title() string       { return string(s) }
 func (s stringItem) Description() string { return "" }
 func (s stringItem) FilterValue() string { return string(s) }
 
+// choiceItem is a list entry shown as label that stands for value, e.g. a
+// repository URL marked with its connection state.
+type choiceItem struct {
+	label string
+	value string
+}
+
+func (c choiceItem) Title() string       { return c.label }
+func (c choiceItem) Description() string { return "" }
+func (c choiceItem) FilterValue() string { return c.value }
+
+// createListSelection is the value of the create list's selected entry.
+func (m Model) createListSelection() (string, bool) {
+	switch it := m.createList.SelectedItem().(type) {
+	case stringItem:
+		return string(it), true
+	case choiceItem:
+		return it.value, true
+	}
+	return "", false
+}
+
+// setCreateChoices is setCreateListPreferring for labeled entries: the
+// entry whose value is want is selected, and added if the server didn't
+// list it (e.g. a preset's cluster).
+func (m Model) setCreateChoices(title string, choices []choiceItem, want string) Model {
+	items := make([]list.Item, 0, len(choices)+1)
+	sel := -1
+	for i, c := range choices {
+		items = append(items, c)
+		if c.value == want {
+			sel = i
+		}
+	}
+	if want != "" && sel < 0 {
+		items = append([]list.Item{choiceItem{label: "? " + want, value: want}}, items...)
+	}
+	m.createList.Title = title
+	m.createList.SetItems(items)
+	m.createList.Select(max(sel, 0))
+	return m
+}
+
+// connectionMark is ✓ for a connected repo or cluster, ✗ when Argo CD's
+// last connection check failed and ? when it hasn't checked yet.
+func connectionMark(connected, failed bool) string {
+	switch {
+	case connected:
+		return "✓"
+	case failed:
+		return "✗"
+	}
+	return "?"
+}
+
+// repoLabel names a repository for the wizard: its display name, if it has
+// one, then the URL, and the type when it is a Helm repository.
+func repoLabel(r argocd.Repository) string {
+	label := r.Repo
+	if r.Name != "" {
+		label = r.Name + "  " + r.Repo
+	}
+	if r.Type == "helm" {
+		label += " (helm)"
+	}
+	return label
+}
+
+// clusterLabel names a cluster as "name  server", or just its server.
+func clusterLabel(c argocd.Cluster) string {
+	if c.Name == "" || c.Name == c.Server {
+		return c.Server
+	}
+	return c.Name + "  " + c.Server
+}
+
+// setCreateRepoList fills the repository step, each repository marked
+// with its connection state, with the current choice selected.
+func (m Model) setCreateRepoList() Model {
+	choices := make([]choiceItem, 0, len(m.createRepos))
+	for _, r := range m.createRepos {
+		choices = append(choices, choiceItem{label: connectionMark(r.Connected(), r.ConnectionFailed()) + " " + repoLabel(r), value: r.Repo})
+	}
+	return m.setCreateChoices("Repository", choices, m.createRepo)
+}
+
+// setCreateClusterList fills the cluster step. A preset may name the
+// cluster by its friendly name; it is mapped to the server here. The
+// wizard sends destination.server, so clusters listed without a server
+// URL aren't offered.
+func (m Model) setCreateClusterList() Model {
+	choices := make([]choiceItem, 0, len(m.createClusters))
+	for _, c := range m.createClusters {
+		if c.Server == "" {
+			continue
+		}
+		if c.Name != "" && c.Name == m.createCluster {
+			m.createCluster = c.Server
+		}
+		choices = append(choices, choiceItem{label: connectionMark(c.Connected(), c.ConnectionFailed()) + " " + clusterLabel(c), value: c.Server})
+	}
+	return m.setCreateChoices("Cluster", choices, m.createCluster)
+}
+
+// createRepoLabel and createClusterLabel are the friendly labels of the
+// chosen repo and cluster for the confirm step, or the plain value when the
+// server didn't list it.
+func (m Model) createRepoLabel() string {
+	for _, r := range m.createRepos {
+		if r.Repo == m.createRepo {
+			return repoLabel(r)
+		}
+	}
+	return m.createRepo
+}
+
+func (m Model) createClusterLabel() string {
+	for _, c := range m.createClusters {
+		if c.Server != "" && c.Server == m.createCluster {
+			return clusterLabel(c)
+		}
+	}
+	return m.createCluster
+}
+
+// createRepoWarning warns when the chosen repository failed Argo CD's last
+// connection check.
+func (m Model) createRepoWarning() string {
+	for _, r := range m.createRepos {
+		if r.Repo == m.createRepo && r.ConnectionFailed() {
+			w := "Argo CD cannot reach " + r.Repo
+			if r.ConnectionMessage != "" {
+				w += ": " + r.ConnectionMessage
+			}
+			return w
+		}
+	}
+	return ""
+}
+
 func (m Model) setCreateList(title string, items []string) Model {
 	li := make([]list.Item, 0, len(items))
 	for _, it := range items {
@@ -1703,6 +3515,45 @@ func (m Model) setCreateList(title string, items []string) Model {
 	return m
 }
 
+// setCreateListPreferring is setCreateList with want selected, adding it to
+// the items if the server didn't list it (e.g. a preset's project).
+func (m Model) setCreateListPreferring(title string, items []string, want string) Model {
+	if want != "" && !slices.Contains(items, want) {
+		items = append([]string{want}, items...)
+	}
+	m = m.setCreateList(title, items)
+	if i := slices.Index(items, want); i >= 0 {
+		m.createList.Select(i)
+	}
+	return m
+}
+
+// noCreatePreset is the preset step's "start blank" choice.
+const noCreatePreset = "(no preset)"
+
+func (m Model) openCreatePresetStep() Model {
+	m.createStep = createStepPreset
+	m.createNameInput.Blur()
+	names := []string{noCreatePreset}
+	for _, p := range m.cfg.CreatePresets {
+		names = append(names, p.Name)
+	}
+	return m.setCreateListPreferring("Preset", names, m.createPreset)
+}
+
+// applyCreatePreset seeds the wizard's project, cluster, namespace, sync
+// policy and revision from p; the zero preset restores the plain defaults.
+// The later steps start on these values but can change any of them.
+func (m Model) applyCreatePreset(p config.CreatePreset) Model {
+	m.createPreset = p.Name
+	m.createProject = p.Project
+	m.createCluster = p.Cluster
+	m.createSyncPolicy = blankIfEmpty(p.SyncPolicy, "manual")
+	m.createNSInput.SetValue(p.Namespace)
+	m.createRevInput.SetValue(blankIfEmpty(p.Revision, "main"))
+	return m
+}
+
 func (m Model) updateCreateWizard(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch k.String() {
 	case "esc":
@@ -1710,7 +3561,10 @@ func (m Model) updateCreateWizard(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.statusLine = "create cancelled"
 		return m, nil
 	case "left":
-		if m.createStep > createStepName {
+		if m.createStep == createStepName && len(m.cfg.CreatePresets) > 0 {
+			m.createErr = nil
+			m = m.openCreatePresetStep()
+		} else if m.createStep > createStepName {
 			m.createStep--
 			m.createErr = nil
 		}
@@ -1722,12 +3576,30 @@ func (m Model) updateCreateWizard(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 
 	switch m.createStep {
+	case createStepPreset:
+		if k.String() == "enter" {
+			sel, ok := m.createListSelection()
+			if !ok {
+				return m, nil
+			}
+			m = m.applyCreatePreset(config.CreatePreset{})
+			for _, p := range m.cfg.CreatePresets {
+				if p.Name == sel {
+					m = m.applyCreatePreset(p)
+				}
+			}
+			m.createStep = createStepName
+			m.createNameInput.Focus()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.createList, cmd = m.createList.Update(k)
+		return m, cmd
 	case createStepName:
 		if k.String() == "enter" {
-			m.createProject = ""
 			m.createStep = createStepProject
 			m.createNameInput.Blur()
-			m = m.setCreateList("Project", m.createProjects)
+			m = m.setCreateListPreferring("Project", m.createProjects, m.createProject)
 			return m, nil
 		}
 		var cmd tea.Cmd
@@ -1735,24 +3607,32 @@ func (m Model) updateCreateWizard(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	case createStepProject, createStepRepo, createStepCluster, createStepSyncPolicy:
 		if k.String() == "enter" {
-			if it, ok := m.createList.SelectedItem().(stringItem); ok {
-				sel := string(it)
+			if sel, ok := m.createListSelection(); ok {
 				switch m.createStep {
 				case createStepProject:
 					m.createProject = sel
 					m.createStep = createStepRepo
-					m = m.setCreateList("Repository", m.createRepos)
+					m = m.setCreateRepoList()
 				case createStepRepo:
 					m.createRepo = sel
+					if w := m.createRepoWarning(); w != "" {
+						m.statusLine = "warning: " + w
+					}
 					m.createStep = createStepPath
 					m.createPathInput.Focus()
 				case createStepCluster:
 					m.createCluster = sel
 					m.createStep = createStepNamespace
+					m.createNamespaces = clusterNamespaces(m.appsAll, sel)
+					m.createNSPick = -1
 					m.createNSInput.Focus()
 				case createStepSyncPolicy:
 					m.createSyncPolicy = strings.ToLower(sel)
 					m.createStep = createStepConfirm
+					m.createValidateGen++
+					m.createValidating = true
+					m.createValidateErr = nil
+					return m, m.validateAppCmd(m.createWizardApp(), m.createValidateGen)
 				}
 			}
 			return m, nil
@@ -1763,46 +3643,34 @@ func (m Model) updateCreateWizard(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case createStepPath:
 		if k.String() == "enter" {
 			m.createPathInput.Blur()
-			m.createStep = createStepCluster
-			m = m.setCreateList("Cluster", m.createClusters)
+			m.createStep = createStepRevision
+			m.createRevInput.Focus()
 			return m, nil
 		}
 		var cmd tea.Cmd
 		m.createPathInput, cmd = m.createPathInput.Update(k)
 		return m, cmd
-	case createStepNamespace:
+	case createStepRevision:
 		if k.String() == "enter" {
-			m.createNSInput.Blur()
-			m.createStep = createStepSyncPolicy
-			m = m.setCreateList("Sync policy", []string{"manual", "auto"})
+			m.createRevInput.Blur()
+			m.createStep = createStepCluster
+			m = m.setCreateClusterList()
 			return m, nil
 		}
 		var cmd tea.Cmd
-		m.createNSInput, cmd = m.createNSInput.Update(k)
+		m.createRevInput, cmd = m.createRevInput.Update(k)
 		return m, cmd
+	case createStepNamespace:
+		return m.updateCreateNamespace(k)
 	case createStepConfirm:
 		switch k.String() {
 		case "y":
-			if m.createCreating {
+			if m.createCreating || m.createValidating {
 				return m, nil
 			}
 			m.createCreating = true
-			app := argocd.Application{
-				Name:           strings.TrimSpace(m.createNameInput.Value()),
-				Project:        strings.TrimSpace(m.createProject),
-				RepoURL:        strings.TrimSpace(m.createRepo),
-				Path:           strings.TrimSpace(m.createPathInput.Value()),
-				Revision:       strings.TrimSpace(blankIfEmpty(m.createRevInput.Value(), "main")),
-				Cluster:        strings.TrimSpace(m.createCluster),
-				Namespace:      strings.TrimSpace(m.createNSInput.Value()),
-				SyncPolicy:     m.createSyncPolicy,
-				Health:         "",
-				Sync:           "",
-				Resources:      nil,
-				OperationState: nil,
-			}
 			m.statusLine = "creating…"
-			return m, m.createAppCmd(app)
+			return m, m.createAppCmd(m.createWizardApp())
 		case "n":
 			m = m.resetCreateWizard()
 			m.statusLine = "create cancelled"
@@ -1812,6 +3680,35 @@ func (m Model) updateCreateWizard(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// createWizardApp is the application the create wizard has put together.
+func (m Model) createWizardApp() argocd.Application {
+	return argocd.Application{
+		Name:       strings.TrimSpace(m.createNameInput.Value()),
+		Project:    strings.TrimSpace(m.createProject),
+		RepoURL:    strings.TrimSpace(m.createRepo),
+		Path:       strings.TrimSpace(m.createPathInput.Value()),
+		Revision:   strings.TrimSpace(blankIfEmpty(m.createRevInput.Value(), "main")),
+		Cluster:    strings.TrimSpace(m.createCluster),
+		Namespace:  strings.TrimSpace(m.createNSInput.Value()),
+		SyncPolicy: m.createSyncPolicy,
+	}
+}
+
+// createValidationLines reports the confirm step's server-side validation.
+func (m Model) createValidationLines() []string {
+	switch {
+	case m.createValidating:
+		return []string{"Validating against the server…", ""}
+	case m.createValidateErr != nil:
+		lines := []string{m.styles.StatusWarn.Render("Validation found problems:")}
+		for _, l := range strings.Split(m.createValidateErr.Error(), "\n") {
+			lines = append(lines, "  ✗ "+l)
+		}
+		return append(lines, "")
+	}
+	return []string{m.styles.Success.Render("✓ validated against the server"), ""}
+}
+
 func (m Model) renderCreateWizard() string {
 	head := []string{"Create application", ""}
 	if m.createErr != nil {
@@ -1820,34 +3717,58 @@ func (m Model) renderCreateWizard() string {
 	if m.createCreating {
 		head = append(head, "Creating…", "")
 	}
+	if w := m.createRepoWarning(); w != "" && m.createStep > createStepRepo {
+		head = append(head, m.styles.StatusWarn.Render("⚠ "+w), "")
+	}
+
+	nameBack := "Enter=next  Esc=cancel"
+	if len(m.cfg.CreatePresets) > 0 {
+		nameBack = "Enter=next  ←=preset  Esc=cancel"
+	}
+	if m.createPreset != "" && m.createStep != createStepPreset {
+		head[0] += " (preset " + m.createPreset + ")"
+	}
 
 	switch m.createStep {
+	case createStepPreset:
+		return strings.Join(append(head, "Preset: defaults for project, cluster, namespace, sync policy and revision", m.createList.View(), "", "Enter=select  Esc=cancel"), "\n")
 	case createStepName:
-		return strings.Join(append(head, "Step 1/7: Name", m.createNameInput.View(), "", "Enter=next  Esc=cancel"), "\n")
+		return strings.Join(append(head, "Step 1/8: Name", m.createNameInput.View(), "", nameBack), "\n")
 	case createStepProject:
-		return strings.Join(append(head, "Step 2/7: Project", m.createList.View(), "", "Enter=select  ←=back  Esc=cancel"), "\n")
+		return strings.Join(append(head, "Step 2/8: Project", m.createList.View(), "", "Enter=select  ←=back  Esc=cancel"), "\n")
 	case createStepRepo:
-		return strings.Join(append(head, "Step 3/7: Repository", m.createList.View(), "", "Enter=select  ←=back  Esc=cancel"), "\n")
+		return strings.Join(append(head, "Step 3/8: Repository", m.createList.View(), "", "Enter=select  ←=back  Esc=cancel"), "\n")
 	case createStepPath:
-		return strings.Join(append(head, "Step 4/7: Path/Chart", m.createPathInput.View(), "", "Enter=next  ←=back  Esc=cancel"), "\n")
+		return strings.Join(append(head, "Step 4/8: Path/Chart", m.createPathInput.View(), "", "Enter=next  ←=back  Esc=cancel"), "\n")
+	case createStepRevision:
+		return strings.Join(append(head, "Step 5/8: Target revision", m.createRevInput.View(), "", "Enter=next  ←=back  Esc=cancel"), "\n")
 	case createStepCluster:
-		return strings.Join(append(head, "Step 5/7: Destination cluster", m.createList.View(), "", "Enter=select  ←=back  Esc=cancel"), "\n")
+		return strings.Join(append(head, "Step 6/8: Destination cluster", m.createList.View(), "", "Enter=select  ←=back  Esc=cancel"), "\n")
 	case createStepNamespace:
-		return strings.Join(append(head, "Step 6/7: Namespace", m.createNSInput.View(), "", "Enter=next  ←=back  Esc=cancel"), "\n")
+		return strings.Join(append(append(head, "Step 7/8: Namespace"), m.renderCreateNamespace()...), "\n")
 	case createStepSyncPolicy:
-		return strings.Join(append(head, "Step 7/7: Sync policy", m.createList.View(), "", "Enter=select  ←=back  Esc=cancel"), "\n")
+		return strings.Join(append(head, "Step 8/8: Sync policy", m.createList.View(), "", "Enter=select  ←=back  Esc=cancel"), "\n")
 	case createStepConfirm:
 		sum := []string{
 			"Confirm:",
 			"  name:      " + strings.TrimSpace(m.createNameInput.Value()),
 			"  project:   " + m.createProject,
-			"  repo:      " + m.createRepo,
+			"  repo:      " + m.createRepoLabel(),
 			"  path:      " + m.createPathInput.Value(),
-			"  cluster:   " + m.createCluster,
+			"  revision:  " + blankIfEmpty(strings.TrimSpace(m.createRevInput.Value()), "main"),
+			"  cluster:   " + m.createClusterLabel(),
 			"  namespace: " + m.createNSInput.Value(),
 			"  sync:      " + m.createSyncPolicy,
 			"",
-			"y=create  n=cancel  ←=back",
+		}
+		sum = append(sum, m.createValidationLines()...)
+		switch {
+		case m.createValidating:
+			sum = append(sum, "n=cancel  ←=back")
+		case m.createValidateErr != nil:
+			sum = append(sum, "y=create anyway  n=cancel  ←=back")
+		default:
+			sum = append(sum, "y=create  n=cancel  ←=back")
 		}
 		return strings.Join(append(head, sum...), "\n")
 	default:
@@ -1867,9 +3788,17 @@ func (m Model) resetEditWizard() Model {
 	m.editClusterIn.Blur()
 	m.editNSInput.Blur()
 	m.editSyncPolicy = "manual"
+	m.editOrigSyncPolicy = ""
+	m.editAutoAck = false
 	return m
 }
 
+// editEnablesAuto reports whether the edit turns on automated sync, which
+// starts reconciling as soon as it is saved.
+func (m Model) editEnablesAuto() bool {
+	return m.editOrigSyncPolicy != "auto" && m.editSyncPolicy == "auto"
+}
+
 func (m Model) updateEditWizard(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch k.String() {
 	case "esc":
@@ -1880,6 +3809,7 @@ func (m Model) updateEditWizard(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.editStep > createStepRepo {
 			m.editStep--
 			m.editErr = nil
+			m.editAutoAck = false
 		}
 		return m, nil
 	}
@@ -1962,10 +3892,19 @@ func (m Model) updateEditWizard(k tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case createStepConfirm:
 		switch k.String() {
+		case "A":
+			if m.editEnablesAuto() {
+				m.editAutoAck = true
+			}
+			return m, nil
 		case "y":
 			if m.editSaving {
 				return m, nil
 			}
+			if m.editEnablesAuto() && !m.editAutoAck {
+				m.statusLine = "press A to acknowledge automatic syncing first"
+				return m, nil
+			}
 			m.editSaving = true
 			m.statusLine = "saving…"
 			app := argocd.Application{
@@ -2027,8 +3966,19 @@ func (m Model) renderEditWizard() string {
 			"  namespace: " + strings.TrimSpace(m.editNSInput.Value()),
 			"  sync:      " + m.editSyncPolicy,
 			"",
-			"y=save  n=cancel  ←=back",
 		}
+		if m.editEnablesAuto() {
+			sum = append(sum,
+				m.styles.StatusWarn.Render("Sync policy changes from manual to auto: this will trigger automatic syncing,"),
+				m.styles.StatusWarn.Render("and the app may reconcile immediately after saving."),
+			)
+			if m.editAutoAck {
+				sum = append(sum, "Acknowledged.", "")
+			} else {
+				sum = append(sum, "Press A to acknowledge.", "")
+			}
+		}
+		sum = append(sum, "y=save  n=cancel  ←=back")
 		return strings.Join(append(head, sum...), "\n")
 	default:
 		return strings.Join(append(head, "Unknown step"), "\n")
@@ -2047,18 +3997,39 @@ type resourceTreeNode struct {
 func (m Model) renderResourceTree(app argocd.Application) string {
 	nodes := m.visibleResourceNodesFor(app)
 	if len(nodes) == 0 {
+		if len(app.Resources) > 0 && m.resourceFilterInput.Value() != "" {
+			return "  (no resources match filter; esc to clear)"
+		}
+		if len(app.Resources) > 0 && (m.resourceProblemsOnly || m.resourceHideHooks) {
+			return "  (no resources left after filtering; D/H to show all)"
+		}
 		return "  (none yet)"
 	}
 
-	hints := []string{"  (tab=focus  space=collapse  z=zoom  /=search  enter/v=view  l=logs)"}
+	hints := []string{"  (tab=focus  space=collapse  z=zoom  /=filter  S=sort  D=problems  H=hooks  enter/v=view  l=logs  L=logs+events  a=actions  ctrl+d=delete)"}
+	if m.resourceSortMode != sortByName {
+		hints = append(hints, m.styles.StatusWarn.Render("  [sort:"+m.resourceSortMode.String()+"]"))
+	}
+	if m.resourceFilterInput.Value() != "" || m.resourceProblemsOnly || m.resourceHideHooks {
+		shown := 0
+		for _, r := range app.Resources {
+			if m.resourceVisible(r) {
+				shown++
+			}
+		}
+		hints = append(hints, m.styles.StatusWarn.Render(fmt.Sprintf("  %d/%d resources (filtered)", shown, len(app.Resources))))
+	}
 	if m.resourceZoom != "" {
 		hints = append(hints, m.styles.StatusWarn.Render("  [zoom] press z to reset"))
 	}
-	if m.resourceSearchActive {
-		hints = append(hints, "  search: "+m.resourceSearchInput.View())
+	if m.resourceFilterActive {
+		hints = append(hints, "  filter: "+m.resourceFilterInput.View())
+	} else if q := m.resourceFilterInput.Value(); q != "" {
+		hints = append(hints, m.styles.StatusWarn.Render("  [filter: "+q+"] esc to clear"))
 	}
 
 	lines := append([]string{}, hints...)
+	now := time.Now()
 	for i, n := range nodes {
 		indent := strings.Repeat("  ", n.depth)
 		prefix := "  "
@@ -2086,7 +4057,13 @@ func (m Model) renderResourceTree(app argocd.Application) string {
 				label = m.styles.StatusWarn.Render(label)
 			}
 			if strings.EqualFold(r.Health, "degraded") || strings.EqualFold(r.Health, "missing") {
-				label = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render(label)
+				label = m.styles.Error.Render(label)
+			} else if r.Hook {
+				// Hooks aren't steady state; dim them so they don't read as drift.
+				label = m.styles.Hook.Render(label)
+			}
+			if extra := resourceExtras(r, now); extra != "" {
+				label += m.styles.Faint.Render("  " + extra)
 			}
 		}
 		lines = append(lines, style.Render(indent+prefix+label))
@@ -2094,6 +4071,27 @@ func (m Model) renderResourceTree(app argocd.Application) string {
 	return strings.Join(lines, "\n")
 }
 
+// resourceExtras is a resource's age and images, compactly ("3d
+// payments-api:1.8.2 +1"): images without their registry path, extras
+// counted. It is "" when neither is known.
+func resourceExtras(r argocd.Resource, now time.Time) string {
+	var parts []string
+	if t, ok := parseTimestamp(r.CreatedAt); ok {
+		parts = append(parts, strings.TrimSuffix(relativeTime(t, now), " ago"))
+	}
+	if len(r.Images) > 0 {
+		img := r.Images[0]
+		if i := strings.LastIndex(img, "/"); i >= 0 {
+			img = img[i+1:]
+		}
+		if len(r.Images) > 1 {
+			img += fmt.Sprintf(" +%d", len(r.Images)-1)
+		}
+		parts = append(parts, img)
+	}
+	return strings.Join(parts, "  ")
+}
+
 func (m Model) visibleResourceNodes() []resourceTreeNode {
 	if m.detail == nil {
 		return nil
@@ -2106,10 +4104,16 @@ func (m Model) visibleResourceNodesFor(app argocd.Application) []resourceTreeNod
 	if len(rs) == 0 {
 		return nil
 	}
+	if m.resourceSortMode == sortByWave {
+		return m.waveResourceNodes(rs)
+	}
 
 	nsOrder := make([]string, 0)
 	seenNS := map[string]bool{}
 	for _, r := range rs {
+		if !m.resourceVisible(r) {
+			continue
+		}
 		ns := r.Namespace
 		if ns == "" {
 			ns = "cluster"
@@ -2129,6 +4133,7 @@ func (m Model) visibleResourceNodesFor(app argocd.Application) []resourceTreeNod
 			// Zoomed elsewhere.
 			continue
 		}
+		nsNode := len(nodes)
 		nodes = append(nodes, resourceTreeNode{key: nsKey, label: "Namespace: " + ns, depth: 0, isGroup: true})
 
 		kindKeys := make([]string, 0)
@@ -2138,40 +4143,142 @@ func (m Model) visibleResourceNodesFor(app argocd.Application) []resourceTreeNod
 			if rns == "" {
 				rns = "cluster"
 			}
-			if rns != ns {
+			if rns != ns || !m.resourceVisible(r) {
 				continue
 			}
-			k := r.Kind
-			if r.Group != "" {
-				k = r.Group + "/" + r.Kind
-			}
+			k := resourceKindKey(r)
 			if _, ok := kindMap[k]; !ok {
 				kindKeys = append(kindKeys, k)
 			}
 			kindMap[k] = append(kindMap[k], i)
 		}
 		sort.Strings(kindKeys)
+		// Headers count the resources under them that pass the filters.
+		inNS := 0
+		for _, idxs := range kindMap {
+			inNS += len(idxs)
+		}
+		nodes[nsNode].label += fmt.Sprintf(" (%d)", inNS)
 		for _, k := range kindKeys {
 			kKey := nsKey + "/kind:" + k
 			if m.resourceZoom != "" && m.resourceZoom != kKey {
 				continue
 			}
-			nodes = append(nodes, resourceTreeNode{key: kKey, parentKey: nsKey, label: k, depth: 1, isGroup: true})
+			nodes = append(nodes, resourceTreeNode{key: kKey, parentKey: nsKey, label: fmt.Sprintf("%s (%d)", k, len(kindMap[k])), depth: 1, isGroup: true})
 			if m.resourceCollapsed[kKey] {
 				continue
 			}
 			idxs := kindMap[k]
-			sort.SliceStable(idxs, func(i, j int) bool { return rs[idxs[i]].Name < rs[idxs[j]].Name })
+			sort.SliceStable(idxs, func(i, j int) bool { return m.resourceLess(rs[idxs[i]], rs[idxs[j]]) })
 			for _, ri := range idxs {
 				r := rs[ri]
-				label := fmt.Sprintf("%s [%s/%s]", r.Name, blankIfEmpty(r.Health, "—"), blankIfEmpty(r.Status, "—"))
-				nodes = append(nodes, resourceTreeNode{key: kKey + "/" + r.Name, parentKey: kKey, label: label, depth: 2, isGroup: false, resourceIdx: ri})
+				label := resourceNodeLabel(r, r.Name)
+				if r.SyncWave != 0 {
+					label += fmt.Sprintf(" [wave %d]", r.SyncWave)
+				}
+				nodes = append(nodes, resourceTreeNode{key: resourceNodeKey(r), parentKey: kKey, label: label, depth: 2, isGroup: false, resourceIdx: ri})
+			}
+		}
+	}
+	return nodes
+}
+
+// waveResourceNodes groups the visible resources by sync wave across
+// namespaces and kinds, in the order a sync applies them. Leaves keep the
+// keys they have in the namespace tree so the selection survives S.
+func (m Model) waveResourceNodes(rs []argocd.Resource) []resourceTreeNode {
+	waves := make([]int, 0)
+	byWave := map[int][]int{}
+	for i, r := range rs {
+		if !m.resourceVisible(r) {
+			continue
+		}
+		if _, ok := byWave[r.SyncWave]; !ok {
+			waves = append(waves, r.SyncWave)
+		}
+		byWave[r.SyncWave] = append(byWave[r.SyncWave], i)
+	}
+	sort.Ints(waves)
+
+	nodes := make([]resourceTreeNode, 0)
+	for _, w := range waves {
+		wKey := fmt.Sprintf("wave:%d", w)
+		if m.resourceZoom != "" && m.resourceZoom != wKey {
+			continue
+		}
+		idxs := byWave[w]
+		nodes = append(nodes, resourceTreeNode{key: wKey, label: fmt.Sprintf("Wave %d (%d)", w, len(idxs)), depth: 0, isGroup: true})
+		if m.resourceCollapsed[wKey] {
+			continue
+		}
+		sort.SliceStable(idxs, func(i, j int) bool {
+			a, b := rs[idxs[i]], rs[idxs[j]]
+			if a.Namespace != b.Namespace {
+				return a.Namespace < b.Namespace
+			}
+			if ka, kb := resourceKindKey(a), resourceKindKey(b); ka != kb {
+				return ka < kb
 			}
+			return a.Name < b.Name
+		})
+		for _, ri := range idxs {
+			r := rs[ri]
+			name := r.Kind + "/" + r.Name
+			if r.Namespace != "" {
+				name = r.Namespace + "/" + name
+			}
+			nodes = append(nodes, resourceTreeNode{key: resourceNodeKey(r), parentKey: wKey, label: resourceNodeLabel(r, name), depth: 1, isGroup: false, resourceIdx: ri})
 		}
 	}
 	return nodes
 }
 
+// resourceKindKey is the kind group a resource belongs to: "group/Kind", or
+// just the kind for the core group.
+func resourceKindKey(r argocd.Resource) string {
+	if r.Group != "" {
+		return r.Group + "/" + r.Kind
+	}
+	return r.Kind
+}
+
+// resourceNodeKey identifies a resource's node in the tree regardless of
+// how the tree is grouped.
+func resourceNodeKey(r argocd.Resource) string {
+	ns := r.Namespace
+	if ns == "" {
+		ns = "cluster"
+	}
+	return "ns:" + ns + "/kind:" + resourceKindKey(r) + "/" + r.Name
+}
+
+// resourceNodeLabel is a resource's tree label: the given name, its
+// health/sync status and a hook tag.
+func resourceNodeLabel(r argocd.Resource, name string) string {
+	label := fmt.Sprintf("%s [%s/%s]", name, blankIfEmpty(r.Health, "—"), blankIfEmpty(r.Status, "—"))
+	if r.Hook {
+		label += " [hook]"
+	}
+	return label
+}
+
+// resourceLess orders resources inside a kind group by resourceSortMode,
+// falling back to the name. Wave mode regroups the tree instead (see
+// waveResourceNodes).
+func (m Model) resourceLess(a, b argocd.Resource) bool {
+	switch m.resourceSortMode {
+	case sortByHealth:
+		if ri, rj := healthRank(a.Health), healthRank(b.Health); ri != rj {
+			return ri < rj
+		}
+	case sortBySync:
+		if ri, rj := syncRank(a.Status), syncRank(b.Status); ri != rj {
+			return ri < rj
+		}
+	}
+	return a.Name < b.Name
+}
+
 func (m *Model) toggleResourceCollapse() {
 	nodes := m.visibleResourceNodes()
 	if len(nodes) == 0 {
@@ -2207,17 +4314,153 @@ func (m *Model) toggleResourceZoom() {
 	}
 }
 
-func (m *Model) jumpToResourceMatch(q string) {
-	q = strings.ToLower(strings.TrimSpace(q))
-	if q == "" {
-		return
+// updateDestructiveConfirm feeds a key to the typed confirmation of the open
+// terminate or rollback modal; run starts the action once the name matches.
+func (m Model) updateDestructiveConfirm(msg tea.KeyMsg, run func(Model) (tea.Model, tea.Cmd)) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeTerminate()
+		m.closeRollback()
+		m.statusLine = "cancelled"
+		return m, nil
+	case "enter":
+		if !m.destructiveConfirm.confirmed() {
+			m.statusLine = "type the exact app name to confirm"
+			return m, nil
+		}
+		m.destructiveConfirm.close()
+		return run(m)
 	}
-	if m.detail == nil {
+	var cmd tea.Cmd
+	m.destructiveConfirm, cmd = m.destructiveConfirm.Update(msg)
+	return m, cmd
+}
+
+// closeSyncModal closes the sync modal and forgets its targets, preview,
+// dry-run results and watch.
+func (m *Model) closeSyncModal() {
+	m.syncModal = false
+	m.syncTargets = nil
+	m.syncRevision = ""
+	m.syncPreview = nil
+	m.syncDryRunComplete = false
+	m.syncDryRunResults = nil
+	m.syncWatch = nil
+}
+
+// updateSyncWatch records app's operation in the watching sync modal. The
+// modal closes by itself once every target has succeeded; a failure keeps it
+// open so the message can be read.
+func (m *Model) updateSyncWatch(app, phase, message string, done bool) {
+	st := m.syncWatch[app]
+	if !m.syncModal || st == nil {
 		return
 	}
-	nodes := m.visibleResourceNodes()
-	for i, n := range nodes {
-		if strings.Contains(strings.ToLower(n.label), q) {
+	st.phase, st.message, st.done = phase, message, done
+	for _, st := range m.syncWatch {
+		if !st.done || st.phase != "Succeeded" {
+			return
+		}
+	}
+	m.closeSyncModal()
+}
+
+// syncWatchLines lists the watched operations in target order.
+func (m Model) syncWatchLines() []string {
+	lines := []string{"Operations:"}
+	pending := false
+	for _, name := range m.syncTargets {
+		st := m.syncWatch[name]
+		if st == nil {
+			continue
+		}
+		mark := "…"
+		switch {
+		case !st.done:
+			pending = true
+		case st.phase == "Succeeded":
+			mark = "✓"
+		default:
+			mark = "✗"
+		}
+		line := fmt.Sprintf("  %s %s: %s", mark, name, st.phase)
+		if st.message != "" {
+			line += " — " + st.message
+		}
+		lines = append(lines, line)
+	}
+	if pending {
+		return append(lines, "", "Watching… esc closes (the watch carries on in the status line).")
+	}
+	return append(lines, "", "Done. Press esc to close.")
+}
+
+// closeTerminate closes the terminate modal and resets its state.
+func (m *Model) closeTerminate() {
+	m.terminateModal = false
+	m.terminateApp = ""
+	m.terminateLoading = false
+	m.terminateErr = nil
+	m.terminateConfirm = false
+	m.destructiveConfirm.close()
+}
+
+// closeRollback also cancels a revisions load still in flight and drops its
+// late result.
+func (m *Model) closeRollback() {
+	stopLoad(&m.rollbackCancel)
+	m.revisionsGen++
+	m.rollbackModal = false
+	m.rollbackApp = ""
+	m.rollbackLoading = false
+	m.rollbackErr = nil
+	m.rollbackRevs = nil
+	m.rollbackConfirm = false
+	m.clearRollbackPreview()
+	m.destructiveConfirm.close()
+}
+
+// clearRollbackPreview drops the preview, and any load of it, when the
+// selection changes or the modal closes.
+func (m *Model) clearRollbackPreview() {
+	m.rollbackPreviewGen++
+	m.rollbackPreview = nil
+	m.rollbackPreviewErr = nil
+	m.rollbackPreviewLoading = false
+}
+
+func (m *Model) closeResourceDelete() {
+	m.resourceDeleteModal = false
+	m.resourceDeleteApp = ""
+	m.resourceDeleteRef = argocd.ResourceRef{}
+	m.resourceDeleteForce = false
+	m.resourceDeleteConfirm.close()
+}
+
+// resourceVisible reports whether a resource passes the detail-pane filters.
+func (m Model) resourceVisible(r argocd.Resource) bool {
+	if m.resourceProblemsOnly && !resourceHasProblem(r) {
+		return false
+	}
+	if m.resourceHideHooks && r.Hook {
+		return false
+	}
+	q := strings.ToLower(strings.TrimSpace(m.resourceFilterInput.Value()))
+	if q == "" {
+		return true
+	}
+	kind := r.Kind
+	if r.Group != "" {
+		kind = r.Group + "/" + r.Kind
+	}
+	return strings.Contains(strings.ToLower(kind), q) || strings.Contains(strings.ToLower(r.Name), q)
+}
+
+// jumpToResourceMatch moves the selection onto the first resource the filter
+// kept, past the namespace and kind headers above it.
+func (m *Model) jumpToResourceMatch() {
+	for i, n := range m.visibleResourceNodes() {
+		if !n.isGroup {
 			m.resourceSel = i
 			m.statusLine = "jumped to resource match"
 			return
@@ -2226,6 +4469,41 @@ func (m *Model) jumpToResourceMatch(q string) {
 	m.statusLine = "no resource match"
 }
 
+// resourceHasProblem reports whether a resource is out of sync or not healthy.
+// Resources without a health assessment (e.g. ConfigMaps) only count when out of sync.
+func resourceHasProblem(r argocd.Resource) bool {
+	if strings.TrimSpace(r.Status) != "" && !strings.EqualFold(r.Status, "synced") {
+		return true
+	}
+	return strings.TrimSpace(r.Health) != "" && !strings.EqualFold(r.Health, "healthy")
+}
+
+func (m Model) selectedResourceKey() string {
+	nodes := m.visibleResourceNodes()
+	if len(nodes) == 0 {
+		return ""
+	}
+	return nodes[clamp(m.resourceSel, 0, len(nodes)-1)].key
+}
+
+// reselectResource moves the selection back onto the node with the given key
+// after the visible set changed, falling back to clamping.
+func (m *Model) reselectResource(key string) {
+	for i, n := range m.visibleResourceNodes() {
+		if n.key == key {
+			m.resourceSel = i
+			return
+		}
+	}
+	m.clampResourceSel()
+}
+
+// clampResourceSel keeps resourceSel inside the currently visible resource nodes.
+func (m *Model) clampResourceSel() {
+	n := len(m.visibleResourceNodes())
+	m.resourceSel = clamp(m.resourceSel, 0, max(0, n-1))
+}
+
 func (m Model) selectedResource() (argocd.Resource, bool) {
 	if m.detail == nil {
 		return argocd.Resource{}, false
@@ -2262,6 +4540,53 @@ func renderConditions(cs []argocd.AppCondition, st styles) string {
 	return strings.Join(lines, "\n")
 }
 
+// syncWindowStatus summarizes whether a manual sync may run now: OPEN or
+// DENY, with when that changes if a window's end can be worked out.
+func syncWindowStatus(ws []argocd.SyncWindow, now time.Time, st styles) string {
+	if len(ws) == 0 {
+		return "OPEN (no sync windows)"
+	}
+	allowed := argocd.ManualSyncAllowed(ws)
+	// The state lasts until the active windows of the deciding kind close.
+	kind := "deny"
+	if allowed {
+		kind = "allow"
+	}
+	var until time.Time
+	for _, w := range ws {
+		if !w.Active || !strings.EqualFold(w.Kind, kind) {
+			continue
+		}
+		if end, ok := w.End(now); ok && end.After(until) {
+			until = end
+		}
+	}
+	s := "OPEN"
+	if !allowed {
+		s = "DENY"
+	}
+	switch {
+	case !until.IsZero():
+		s += " until " + formatWindowEnd(until, now)
+	case !allowed:
+		s += " (outside the allow windows)"
+	}
+	if !allowed {
+		return st.StatusWarn.Render(s)
+	}
+	return st.StatusValue.Render(s)
+}
+
+// formatWindowEnd shows a local clock time, with the date once it's more
+// than a day out.
+func formatWindowEnd(t, now time.Time) string {
+	t = t.Local()
+	if t.Sub(now) >= 24*time.Hour {
+		return t.Format("Jan 2 15:04")
+	}
+	return t.Format("15:04")
+}
+
 func renderSyncWindows(ws []argocd.SyncWindow, err error, st styles) string {
 	if err != nil {
 		return st.Error.Render("  error: " + err.Error())
@@ -2273,6 +4598,9 @@ func renderSyncWindows(ws []argocd.SyncWindow, err error, st styles) string {
 	for _, w := range ws {
 		kind := strings.ToLower(strings.TrimSpace(w.Kind))
 		line := fmt.Sprintf("  - %s  %s  %s", blankIfEmpty(kind, "—"), blankIfEmpty(w.Schedule, "—"), blankIfEmpty(w.Duration, "—"))
+		if w.Active {
+			line += "  (active)"
+		}
 		if kind == "deny" {
 			lines = append(lines, st.StatusWarn.Render(line))
 		} else {
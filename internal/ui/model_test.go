@@ -2,13 +2,19 @@ package ui
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"io"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
 	"lazyargo/internal/argocd"
 	"lazyargo/internal/config"
@@ -19,11 +25,26 @@ type fakeClient struct {
 
 	syncCalls []syncCall
 	syncErr   map[string]error
+	// blockRevisions makes ListRevisions wait for its context to end.
+	blockRevisions bool
+	// created is the last application passed to CreateApplication.
+	created argocd.Application
+	// diffs is what ServerSideDiff returns; diffCalls counts the calls.
+	diffs     []argocd.DiffResult
+	diffCalls int
+	// updatedRaw is the last manifest passed to UpdateApplicationRaw.
+	updatedRaw string
+	// validateErr is what ValidateApplication returns.
+	validateErr error
+	// messages and messageErrs are RevisionMetadata's results by revision.
+	messages    map[string]string
+	messageErrs map[string]error
 }
 
 type syncCall struct {
-	name   string
-	dryRun bool
+	name     string
+	dryRun   bool
+	revision string
 }
 
 func (f *fakeClient) ListApplications(ctx context.Context) ([]argocd.Application, error) {
@@ -40,8 +61,11 @@ func (f *fakeClient) RefreshApplication(ctx context.Context, name string, hard b
 }
 
 func (f *fakeClient) ListRevisions(ctx context.Context, name string) ([]argocd.Revision, error) {
-	_ = ctx
 	_ = name
+	if f.blockRevisions {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
 	return nil, nil
 }
 
@@ -67,21 +91,32 @@ func (f *fakeClient) DeleteApplication(ctx context.Context, name string, cascade
 
 func (f *fakeClient) CreateApplication(ctx context.Context, app argocd.Application) error {
 	_ = ctx
-	_ = app
+	f.created = app
+	return nil
+}
+
+func (f *fakeClient) CreateApplicationRaw(ctx context.Context, yaml string) error {
+	_ = ctx
+	_ = yaml
 	return nil
 }
 
+func (f *fakeClient) ValidateApplication(ctx context.Context, app argocd.Application) error {
+	_ = ctx
+	return f.validateErr
+}
+
 func (f *fakeClient) ListProjects(ctx context.Context) ([]string, error) {
 	_ = ctx
 	return nil, nil
 }
 
-func (f *fakeClient) ListClusters(ctx context.Context) ([]string, error) {
+func (f *fakeClient) ListClusters(ctx context.Context) ([]argocd.Cluster, error) {
 	_ = ctx
 	return nil, nil
 }
 
-func (f *fakeClient) ListRepositories(ctx context.Context) ([]string, error) {
+func (f *fakeClient) ListRepositories(ctx context.Context) ([]argocd.Repository, error) {
 	_ = ctx
 	return nil, nil
 }
@@ -92,8 +127,22 @@ func (f *fakeClient) UpdateApplication(ctx context.Context, app argocd.Applicati
 	return nil
 }
 
-func (f *fakeClient) SyncApplication(ctx context.Context, name string, dryRun bool) error {
-	f.syncCalls = append(f.syncCalls, syncCall{name: name, dryRun: dryRun})
+func (f *fakeClient) GetApplicationRaw(ctx context.Context, name string) (string, error) {
+	a, err := f.GetApplication(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return argocd.ApplicationYAML(a)
+}
+
+func (f *fakeClient) UpdateApplicationRaw(ctx context.Context, name, manifest string) error {
+	_ = ctx
+	f.updatedRaw = manifest
+	return nil
+}
+
+func (f *fakeClient) SyncApplication(ctx context.Context, name string, opts argocd.SyncOptions) error {
+	f.syncCalls = append(f.syncCalls, syncCall{name: name, dryRun: opts.DryRun, revision: opts.Revision})
 	if f.syncErr == nil {
 		return nil
 	}
@@ -119,6 +168,11 @@ func (f *fakeClient) ListEvents(ctx context.Context, appName string) ([]argocd.E
 	return nil, nil
 }
 
+func (f *fakeClient) ListApplicationEvents(ctx context.Context) ([]argocd.Event, error) {
+	_ = ctx
+	return nil, nil
+}
+
 func (f *fakeClient) PodLogs(ctx context.Context, appName, podName, container string, follow bool) (io.ReadCloser, error) {
 	_ = ctx
 	_ = appName
@@ -131,14 +185,17 @@ func (f *fakeClient) PodLogs(ctx context.Context, appName, podName, container st
 func (f *fakeClient) ServerSideDiff(ctx context.Context, appName string) ([]argocd.DiffResult, error) {
 	_ = ctx
 	_ = appName
-	return nil, nil
+	f.diffCalls++
+	return f.diffs, nil
 }
 
 func (f *fakeClient) RevisionMetadata(ctx context.Context, appName, revision string) (argocd.RevisionMeta, error) {
 	_ = ctx
 	_ = appName
-	_ = revision
-	return argocd.RevisionMeta{}, nil
+	if err := f.messageErrs[revision]; err != nil {
+		return argocd.RevisionMeta{}, err
+	}
+	return argocd.RevisionMeta{Message: f.messages[revision]}, nil
 }
 
 func (f *fakeClient) ChartDetails(ctx context.Context, appName, revision string) (argocd.ChartMeta, error) {
@@ -148,12 +205,46 @@ func (f *fakeClient) ChartDetails(ctx context.Context, appName, revision string)
 	return argocd.ChartMeta{}, nil
 }
 
+func (f *fakeClient) RevisionsDiff(ctx context.Context, appName, from, to string) (argocd.RevisionComparison, error) {
+	_ = ctx
+	_ = appName
+	return argocd.RevisionComparison{
+		From:          argocd.RevisionMeta{Message: "from " + from},
+		To:            argocd.RevisionMeta{Message: "to " + to},
+		FromManifests: []string{"kind: ConfigMap\ndata:\n  rev: " + from + "\n"},
+		ToManifests:   []string{"kind: ConfigMap\ndata:\n  rev: " + to + "\n"},
+	}, nil
+}
+
 func (f *fakeClient) GetSyncWindows(ctx context.Context, appName string) ([]argocd.SyncWindow, error) {
 	_ = ctx
 	_ = appName
 	return nil, nil
 }
 
+func (f *fakeClient) DeleteResource(ctx context.Context, appName string, ref argocd.ResourceRef, force bool) error {
+	_ = ctx
+	_ = appName
+	_ = ref
+	_ = force
+	return nil
+}
+
+func (f *fakeClient) ListResourceActions(ctx context.Context, appName string, ref argocd.ResourceRef) ([]string, error) {
+	_ = ctx
+	_ = appName
+	_ = ref
+	return nil, nil
+}
+
+func (f *fakeClient) RunResourceAction(ctx context.Context, appName string, ref argocd.ResourceRef, action string) error {
+	_ = ctx
+	_ = appName
+	_ = ref
+	_ = action
+	return nil
+}
+
 func TestModel_applyFilter_driftAndQuery(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -180,6 +271,33 @@ func TestModel_applyFilter_driftAndQuery(t *testing.T) {
 			// Default sort is by name.
 			wantNames: []string{"backend", "frontend"},
 		},
+		{
+			name:      "fuzzy query ranks by match quality over sort",
+			appsAll:   []argocd.Application{{Name: "api-gateway"}, {Name: "payments-api"}, {Name: "pm-api"}, {Name: "web"}},
+			query:     "pmapi",
+			wantNames: []string{"pm-api", "payments-api"},
+		},
+		{
+			name: "label selectors combine with the name query",
+			appsAll: []argocd.Application{
+				{Name: "pay-prod", Labels: map[string]string{"env": "prod", "team": "pay"}},
+				{Name: "pay-dev", Labels: map[string]string{"env": "dev", "team": "pay"}},
+				{Name: "web-prod", Labels: map[string]string{"env": "prod"}},
+				{Name: "unlabelled"},
+			},
+			query:     "label:env=prod label:team pay",
+			wantNames: []string{"pay-prod"},
+		},
+		{
+			name: "negated label selector keeps apps without the label",
+			appsAll: []argocd.Application{
+				{Name: "a", Labels: map[string]string{"env": "prod"}},
+				{Name: "b", Labels: map[string]string{"env": "dev"}},
+				{Name: "c"},
+			},
+			query:     "label:env!=prod",
+			wantNames: []string{"b", "c"},
+		},
 		{
 			name:      "query + drift only",
 			appsAll:   []argocd.Application{{Name: "frontend", Sync: "Synced"}, {Name: "backend", Sync: "OutOfSync"}, {Name: "worker", Sync: "OutOfSync"}},
@@ -282,3 +400,1983 @@ func TestModel_syncBatchCmd_dryRunAndReal(t *testing.T) {
 		t.Fatalf("expected non-dry-run calls: %+v", fc.syncCalls)
 	}
 }
+
+func TestModel_syncWatchInModal(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.syncModal = true
+	m.syncTargets = []string{"b", "c"}
+	m.syncDryRunComplete = true
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	m = updated.(Model)
+	if cmd == nil || m.syncWatch["c"] == nil || m.syncWatch["c"].phase != "Starting" {
+		t.Fatalf("expected w to start a watched sync, got %+v", m.syncWatch)
+	}
+	updated, _ = m.Update(syncBatchMsg{results: []syncResult{{name: "b", err: errors.New("boom")}, {name: "c"}}})
+	m = updated.(Model)
+	if !m.syncModal || !m.syncWatch["b"].done || m.syncWatch["b"].phase != "Error" {
+		t.Fatalf("expected the modal to stay open with b failed, got %+v", m.syncWatch["b"])
+	}
+
+	watch := opWatch{app: "c", started: time.Now()}
+	updated, _ = m.Update(opWatchMsg{watch: watch, op: &argocd.OperationState{Phase: "Running", Message: "waiting for healthy state"}})
+	m = updated.(Model)
+	if st := m.syncWatch["c"]; st.phase != "Running" || st.done {
+		t.Fatalf("expected c running, got %+v", st)
+	}
+	watch.seenRunning = true
+	updated, _ = m.Update(opWatchMsg{watch: watch, op: &argocd.OperationState{Phase: "Succeeded", Message: "successfully synced"}})
+	m = updated.(Model)
+	got := strings.Join(m.syncWatchLines(), "\n")
+	if !m.syncModal || !strings.Contains(got, "✗ b: Error — boom") || !strings.Contains(got, "✓ c: Succeeded — successfully synced") || !strings.Contains(got, "Done") {
+		t.Fatalf("expected the modal to stay open on a failure, got %q", got)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = updated.(Model); m.syncModal || m.syncWatch != nil {
+		t.Fatalf("expected esc to close the modal")
+	}
+
+	// When every operation succeeds the modal closes by itself.
+	m.syncModal = true
+	m.syncTargets = []string{"c"}
+	m.syncDryRunComplete = true
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	m = updated.(Model)
+	updated, _ = m.Update(syncBatchMsg{results: []syncResult{{name: "c"}}})
+	m = updated.(Model)
+	updated, _ = m.Update(opWatchMsg{watch: watch, op: &argocd.OperationState{Phase: "Succeeded"}})
+	if m = updated.(Model); m.syncModal {
+		t.Fatalf("expected the modal to close once everything succeeded")
+	}
+}
+
+func TestModel_rollbackStartsOperationWatch(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.rollbackModal = true
+	updated, cmd := m.Update(rollbackMsg{appName: "a"})
+	m = updated.(Model)
+	if m.rollbackModal || m.statusLine != "rollback started; watching operation…" {
+		t.Fatalf("expected the modal closed and the watch announced, got modal=%v status=%q", m.rollbackModal, m.statusLine)
+	}
+	// A refresh and the watch's first poll.
+	if batch, ok := cmd().(tea.BatchMsg); !ok || len(batch) != 2 {
+		t.Fatalf("expected a refresh and a watch, got %T", cmd())
+	}
+
+	watch := opWatch{app: "a", action: "rollback", started: time.Now(), seenRunning: true}
+	updated, _ = m.Update(opWatchMsg{watch: watch, op: &argocd.OperationState{Phase: "Failed"}})
+	if m = updated.(Model); m.statusLine != "rollback a: Failed" {
+		t.Fatalf("expected the rollback's result reported, got %q", m.statusLine)
+	}
+}
+
+func TestModel_yInRollbackModalConfirmsInsteadOfSyncing(t *testing.T) {
+	fc := &fakeClient{}
+	m := NewModel(config.Default(), fc)
+	m.appsAll = []argocd.Application{{Name: "a", Sync: "OutOfSync"}}
+	m.applyFilter(false)
+
+	m.rollbackModal = true
+	m.rollbackApp = "a"
+	m.rollbackRevs = []argocd.Revision{{ID: 7, Revision: "abc"}}
+	m.rollbackConfirm = true
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = updated.(Model)
+	if m.syncModal {
+		t.Fatalf("expected 'y' not to open the sync modal while rollback modal is active")
+	}
+	if cmd == nil {
+		t.Fatalf("expected rollback cmd")
+	}
+	if _, ok := cmd().(rollbackMsg); !ok {
+		t.Fatalf("expected rollbackMsg")
+	}
+	if len(fc.syncCalls) != 0 {
+		t.Fatalf("expected no sync calls, got %+v", fc.syncCalls)
+	}
+}
+
+func TestModel_syncAppKeyDoesNotUseModalConfirmKey(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	if key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}}, m.keys.SyncApp) {
+		t.Fatalf("SyncApp must not be bound to 'y'")
+	}
+}
+
+func TestModel_resourceFilter(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.detail = &argocd.Application{Name: "a", Resources: []argocd.Resource{
+		{Kind: "Deployment", Name: "web", Namespace: "default"},
+		{Kind: "Service", Name: "web", Namespace: "default"},
+		{Kind: "ConfigMap", Name: "cfg", Namespace: "default"},
+		{Group: "batch", Kind: "Job", Name: "migrate", Namespace: "default"},
+	}}
+	m.focusResources = true
+	m.resourceSel = len(m.visibleResourceNodes()) - 1
+
+	press := func(keys ...string) {
+		t.Helper()
+		for _, k := range keys {
+			var msg tea.KeyMsg
+			switch k {
+			case "esc":
+				msg = tea.KeyMsg{Type: tea.KeyEsc}
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			default:
+				msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			}
+			updated, _ := m.Update(msg)
+			m = updated.(Model)
+		}
+	}
+	names := func() []string {
+		var out []string
+		for _, n := range m.visibleResourceNodes() {
+			if !n.isGroup {
+				out = append(out, m.detail.Resources[n.resourceIdx].Kind)
+			}
+		}
+		return out
+	}
+
+	press("/", "w", "e", "b")
+	if got := names(); !reflect.DeepEqual(got, []string{"Deployment", "Service"}) {
+		t.Fatalf("expected the name filter to keep the web resources, got %v", got)
+	}
+	if n := len(m.visibleResourceNodes()); m.resourceSel >= n {
+		t.Fatalf("expected the selection clamped into %d nodes, got %d", n, m.resourceSel)
+	}
+
+	press("esc", "/", "b", "a", "t", "c", "h", "/")
+	if got := names(); !reflect.DeepEqual(got, []string{"Job"}) {
+		t.Fatalf("expected the group/kind filter to keep the job, got %v", got)
+	}
+	press("enter")
+	if r, ok := m.selectedResource(); !ok || r.Name != "migrate" {
+		t.Fatalf("expected enter to select the match, got %+v (ok=%v)", r, ok)
+	}
+	if m.resourceFilterActive || m.resourceFilterInput.Value() != "batch/" {
+		t.Fatalf("expected enter to keep the filter and leave input mode")
+	}
+
+	press("esc")
+	if m.resourceFilterInput.Value() != "" || len(names()) != 4 {
+		t.Fatalf("expected esc to clear the filter, got %q and %v", m.resourceFilterInput.Value(), names())
+	}
+}
+
+func TestModel_resourceFiltersCompose(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.detail = &argocd.Application{Name: "a", Resources: []argocd.Resource{
+		{Kind: "Deployment", Name: "web", Namespace: "default", Status: "OutOfSync", Health: "Healthy"},
+		{Kind: "Service", Name: "web", Namespace: "default", Status: "Synced", Health: "Healthy"},
+		{Kind: "ConfigMap", Name: "cfg", Namespace: "default", Status: "Synced"},
+		{Kind: "Pod", Name: "worker", Namespace: "default", Status: "Synced", Health: "Degraded"},
+	}}
+	m.focusResources = true
+
+	count := func() int {
+		n := 0
+		for _, node := range m.visibleResourceNodes() {
+			if !node.isGroup {
+				n++
+			}
+		}
+		return n
+	}
+	if got := count(); got != 4 {
+		t.Fatalf("expected 4 resources unfiltered, got %d", got)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	m = updated.(Model)
+	if !m.resourceProblemsOnly || m.driftOnly {
+		t.Fatalf("expected D in resource focus to toggle problem resources, not drift-only apps")
+	}
+	if got := count(); got != 2 {
+		t.Fatalf("expected 2 problem resources, got %d", got)
+	}
+
+	m.resourceFilterInput.SetValue("work")
+	if got := count(); got != 1 {
+		t.Fatalf("expected text filter to compose with problems toggle, got %d", got)
+	}
+}
+
+func TestModel_hookResourcesToggle(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.detail = &argocd.Application{Name: "a", Resources: []argocd.Resource{
+		{Group: "batch", Kind: "Job", Name: "migrate", Namespace: "default", Hook: true},
+		{Kind: "Service", Name: "web", Namespace: "default"},
+	}}
+	m.focusResources = true
+	labels := func() []string {
+		var out []string
+		for _, n := range m.visibleResourceNodes() {
+			if !n.isGroup {
+				out = append(out, n.label)
+			}
+		}
+		return out
+	}
+	if got := labels(); !reflect.DeepEqual(got, []string{"web [—/—]", "migrate [—/—] [hook]"}) {
+		t.Fatalf("expected only the hook to be tagged, got %v", got)
+	}
+	for i, n := range m.visibleResourceNodes() {
+		if !n.isGroup && m.detail.Resources[n.resourceIdx].Name == "web" {
+			m.resourceSel = i
+		}
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	m = updated.(Model)
+	if got := labels(); len(got) != 1 || strings.Contains(got[0], "[hook]") {
+		t.Fatalf("expected H to hide the hook, got %v", got)
+	}
+	if r, ok := m.selectedResource(); !ok || r.Name != "web" {
+		t.Fatalf("expected the selection to stay on web, got %+v", r)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	m = updated.(Model)
+	if r, ok := m.selectedResource(); len(labels()) != 2 || !ok || r.Name != "web" {
+		t.Fatalf("expected H again to show the hook and keep web selected, got %v / %+v", labels(), r)
+	}
+}
+
+func TestModel_forwardsLoadResultsToOpenOverlay(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	ev := newEventsModel(m.styles, m.client, "a")
+	m.eventsView = &ev
+
+	updated, _ := m.Update(eventsLoadedMsg{events: []argocd.Event{{Reason: "Pulled"}}})
+	m = updated.(Model)
+	if m.eventsView.load.loading() || len(m.eventsView.events) != 1 {
+		t.Fatalf("expected events overlay to receive its load result")
+	}
+}
+
+func TestModel_enterOnChildApplicationDrillsIn(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.appsAll = []argocd.Application{{Name: "child", Sync: "Synced"}, {Name: "root", Sync: "Synced"}}
+	m.applyFilter(false)
+	m.selected = 1
+	m.detail = &argocd.Application{Name: "root", Resources: []argocd.Resource{
+		{Group: "argoproj.io", Kind: "Application", Name: "child", Namespace: "argocd"},
+		{Group: "argoproj.io", Kind: "Application", Name: "elsewhere", Namespace: "argocd"},
+	}}
+	m.focusResources = true
+	m.resourceSel = 2 // ns group, kind group, then "child"
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.apps[m.selected].Name != "child" || cmd == nil || m.resourceDetails != nil {
+		t.Fatalf("expected enter on a child Application to select it in the sidebar")
+	}
+	if got := m.breadcrumb(); !strings.HasPrefix(got, "root > child") {
+		t.Fatalf("unexpected breadcrumb %q", got)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = updated.(Model)
+	if m.apps[m.selected].Name != "root" || !m.focusResources || m.resourceSel != 2 || m.breadcrumb() != "" {
+		t.Fatalf("expected backspace to return to root with the child resource selected")
+	}
+
+	// A child that isn't in the list falls back to the manifest viewer.
+	m.detail = &argocd.Application{Name: "child", Resources: []argocd.Resource{
+		{Group: "argoproj.io", Kind: "Application", Name: "elsewhere", Namespace: "argocd"},
+	}}
+	m.resourceSel = 2
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.resourceDetails == nil {
+		t.Fatalf("expected resource viewer for an Application not in the list")
+	}
+}
+
+func TestModel_selectionChangesClearDrillIn(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.appsAll = []argocd.Application{{Name: "child"}, {Name: "other"}, {Name: "root"}}
+	m.applyFilter(false)
+	drillIn := func() {
+		t.Helper()
+		m.navStack = nil
+		if _, ok := m.selectAppByName("root"); !ok {
+			t.Fatal("root not in the list")
+		}
+		m.detail = &argocd.Application{Name: "root", Resources: []argocd.Resource{{Group: "argoproj.io", Kind: "Application", Name: "child", Namespace: "argocd"}}}
+		m.focusResources = true
+		m.resourceSel = 2
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(Model)
+		if m.breadcrumb() == "" {
+			t.Fatal("expected a drill-in path")
+		}
+	}
+
+	drillIn()
+	m.applyFilter(true)
+	if m.breadcrumb() == "" {
+		t.Fatal("expected a reload that keeps the selection to keep the path")
+	}
+	m.filterInput.SetValue("o")
+	m.applyFilter(true)
+	if m.apps[m.selected].Name == "child" || m.breadcrumb() != "" || len(m.navStack) != 0 {
+		t.Fatalf("expected a filter that moves the selection to clear the path, got %v", m.navStack)
+	}
+
+	m.filterInput.SetValue("")
+	m.applyFilter(true)
+	drillIn()
+	if _, ok := m.gotoApp("other"); !ok || len(m.navStack) != 0 {
+		t.Fatalf("expected goto to clear the path, got %v", m.navStack)
+	}
+}
+
+func TestModel_alertsOnHealthRegression(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+
+	updated, _ := m.Update(appsMsg{apps: []argocd.Application{{Name: "a", Health: "Healthy"}, {Name: "b", Health: "Degraded"}}})
+	m = updated.(Model)
+	if m.alert != "" {
+		t.Fatalf("expected no alert on the first load, got %q", m.alert)
+	}
+
+	updated, _ = m.Update(appsMsg{apps: []argocd.Application{
+		{Name: "a", Health: "Missing"},
+		{Name: "b", Health: "Degraded"},
+		{Name: "c", Health: "Degraded"},
+	}})
+	m = updated.(Model)
+	if m.alert != "degraded: a" {
+		t.Fatalf("expected only the regressed app in the alert, got %q", m.alert)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = updated.(Model)
+	if m.alert != "" {
+		t.Fatalf("expected a key press to acknowledge the alert")
+	}
+}
+
+func TestModel_alertRingsBellInFrame(t *testing.T) {
+	for _, bell := range []bool{false, true} {
+		cfg := config.Default()
+		cfg.UI.Alerts.Bell = bell
+		m := NewModel(cfg, &fakeClient{})
+		updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+		m = updated.(Model)
+		updated, _ = m.Update(appsMsg{apps: []argocd.Application{{Name: "a", Health: "Healthy"}}})
+		m = updated.(Model)
+		updated, _ = m.Update(appsMsg{apps: []argocd.Application{{Name: "a", Health: "Degraded"}}})
+		m = updated.(Model)
+		if m.alert == "" {
+			t.Fatalf("bell=%v: expected an alert", bell)
+		}
+		if got := strings.HasPrefix(m.View(), "\a"); got != bell {
+			t.Fatalf("bell=%v: expected BEL in the frame %v, got %v", bell, bell, got)
+		}
+		if !bell {
+			continue
+		}
+
+		updated, _ = m.Update(bellDoneMsg{gen: m.bellGen - 1})
+		m = updated.(Model)
+		if !strings.HasPrefix(m.View(), "\a") {
+			t.Fatal("expected a stale stop tick to leave the bell alone")
+		}
+		updated, _ = m.Update(bellDoneMsg{gen: m.bellGen})
+		m = updated.(Model)
+		if strings.Contains(m.View(), "\a") {
+			t.Fatal("expected the BEL out of the frame once the ring is done")
+		}
+	}
+}
+
+func largeAppList(n int) []argocd.Application {
+	apps := make([]argocd.Application, n)
+	for i := range apps {
+		apps[i] = argocd.Application{Name: fmt.Sprintf("app-%05d", i), Health: "Healthy", Sync: "Synced"}
+		if i%7 == 0 {
+			apps[i].Sync = "OutOfSync"
+		}
+	}
+	return apps
+}
+
+func TestModel_ensureSidebarSelectionVisible_largeList(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.width, m.height = 120, 40
+	m.appsAll = largeAppList(1000)
+	m.applyFilter(false)
+
+	visible := m.sidebarListRows()
+	for m.selected < len(m.apps)-1 {
+		m.selected++
+		m.ensureSidebarSelectionVisible()
+		if m.selected < m.sidebarOffset || m.selected >= m.sidebarOffset+visible {
+			t.Fatalf("selection %d outside window at offset %d", m.selected, m.sidebarOffset)
+		}
+	}
+	if want := len(m.apps) - visible; m.sidebarOffset != want {
+		t.Fatalf("offset at end of list: got %d, want %d", m.sidebarOffset, want)
+	}
+}
+
+func TestModel_noColorSidebarGlyphs(t *testing.T) {
+	cfg := config.Default()
+	cfg.UI.NoColor = true
+	m := NewModel(cfg, &fakeClient{})
+	updated, _ := m.Update(appsMsg{apps: []argocd.Application{
+		{Name: "a-degraded", Health: "Degraded", Sync: "Synced"},
+		{Name: "b-drifted", Health: "Healthy", Sync: "OutOfSync"},
+		{Name: "c-healthy", Health: "Healthy", Sync: "Synced"},
+	}})
+	m = updated.(Model)
+	want := map[string]string{"a-degraded": "✗ a-degraded", "b-drifted": "! b-drifted", "c-healthy": "✓ c-healthy"}
+	for i, a := range m.apps {
+		if got := m.renderSidebarApp(i, "", 40); !strings.Contains(got, want[a.Name]) {
+			t.Fatalf("expected %q in the sidebar line, got %q", want[a.Name], got)
+		}
+	}
+
+	m.cfg.UI.NoColor = false
+	if got := m.renderSidebarApp(2, "", 40); strings.Contains(got, "✓") {
+		t.Fatalf("expected no glyph for a healthy app with colors on, got %q", got)
+	}
+}
+
+func TestModel_lastAppReachableAtAnyHeight(t *testing.T) {
+	for _, height := range []int{12, 13, 17, 24, 40} {
+		m := NewModel(config.Default(), &fakeClient{})
+		updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: height})
+		m = updated.(Model)
+		updated, _ = m.Update(appsMsg{apps: largeAppList(60)})
+		m = updated.(Model)
+		for m.selected < len(m.apps)-1 {
+			updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+			m = updated.(Model)
+		}
+
+		sw, _ := m.paneWidths()
+		side := m.renderSidebar(sw, m.bodyHeight())
+		if got := lipgloss.Height(side); got != m.bodyHeight() {
+			t.Fatalf("height %d: sidebar is %d lines tall, body is %d", height, got, m.bodyHeight())
+		}
+		if last := m.apps[len(m.apps)-1].Name; !strings.Contains(side, last) {
+			t.Fatalf("height %d: selected last app %s is not in the sidebar:\n%s", height, last, side)
+		}
+		if rows := m.sidebarListRows(); m.sidebarOffset != len(m.apps)-rows {
+			t.Fatalf("height %d: offset %d, want %d", height, m.sidebarOffset, len(m.apps)-rows)
+		}
+	}
+}
+
+func TestModel_sidebarMoreIndicator(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.width, m.height = 120, 20
+	m.appsAll = largeAppList(100)
+	m.applyFilter(false)
+	visible := m.sidebarListRows()
+
+	check := func() {
+		t.Helper()
+		shown := sidebarRowsShown(m.sidebarOffset, visible, len(m.apps))
+		if m.selected < m.sidebarOffset || m.selected >= m.sidebarOffset+shown {
+			t.Fatalf("selection %d outside rows %d..%d", m.selected, m.sidebarOffset, m.sidebarOffset+shown-1)
+		}
+		side := m.renderSidebar(40, m.bodyHeight())
+		more := len(m.apps) - m.sidebarOffset - shown
+		if hint := fmt.Sprintf("▼ %d more", more); more > 0 != strings.Contains(side, hint) {
+			t.Fatalf("at offset %d expected %q only while apps are hidden below:\n%s", m.sidebarOffset, hint, side)
+		}
+		if strings.Contains(side, "…") {
+			t.Fatalf("no app line should carry the old truncation marker")
+		}
+	}
+
+	for m.selected < len(m.apps)-1 {
+		m.selected++
+		m.ensureSidebarSelectionVisible()
+		check()
+	}
+	if m.sidebarOffset != len(m.apps)-visible {
+		t.Fatalf("offset at end of list: got %d, want %d", m.sidebarOffset, len(m.apps)-visible)
+	}
+	for m.selected > 0 {
+		m.selected--
+		m.ensureSidebarSelectionVisible()
+		check()
+	}
+}
+
+func BenchmarkModel_sidebarNavigation(b *testing.B) {
+	m := NewModel(config.Default(), &fakeClient{})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 50})
+	m = updated.(Model)
+	updated, _ = m.Update(appsMsg{apps: largeAppList(5000)})
+	m = updated.(Model)
+	down := tea.KeyMsg{Type: tea.KeyDown}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if m.selected == len(m.apps)-1 {
+			m.selected = 0
+			m.ensureSidebarSelectionVisible()
+		}
+		updated, _ := m.Update(down)
+		m = updated.(Model)
+		_ = m.View()
+	}
+}
+
+func TestModel_groupByProject(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.width, m.height = 80, 30
+	m.appsAll = []argocd.Application{
+		{Name: "b", Project: "platform"},
+		{Name: "c", Project: "default"},
+		{Name: "a", Project: "platform"},
+	}
+	m.applyFilter(false)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	m = updated.(Model)
+	names := func() []string {
+		out := make([]string, 0, len(m.apps))
+		for _, a := range m.apps {
+			out = append(out, a.Name)
+		}
+		return out
+	}
+	if got := names(); !reflect.DeepEqual(got, []string{"c", "a", "b"}) {
+		t.Fatalf("expected apps ordered by group, got %v", got)
+	}
+	if len(m.sidebarRows) != 5 || !m.sidebarRows[0].header || !m.sidebarRows[2].header {
+		t.Fatalf("expected two headers with their apps, got %+v", m.sidebarRows)
+	}
+
+	// Down from "c" skips the platform header and lands on "a".
+	m.selected = 0
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(Model)
+	if m.apps[m.selected].Name != "a" {
+		t.Fatalf("expected navigation to skip the header, selected %q", m.apps[m.selected].Name)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	m = updated.(Model)
+	if got := names(); !reflect.DeepEqual(got, []string{"c"}) {
+		t.Fatalf("expected the platform group to collapse, got %v", got)
+	}
+	if r := m.sidebarRows[len(m.sidebarRows)-1]; !r.header || !r.collapsed || r.count != 2 {
+		t.Fatalf("expected a collapsed platform header with its count, got %+v", r)
+	}
+}
+
+func TestModel_sidebarDriftCountSurvivesNarrowWidth(t *testing.T) {
+	cfg := config.Default()
+	cfg.UI.SidebarDriftCounts = true
+	m := NewModel(cfg, &fakeClient{})
+	m.appsAll = []argocd.Application{{
+		Name: "a-rather-long-application-name",
+		Resources: []argocd.Resource{
+			{Kind: "Deployment", Name: "x", Status: "OutOfSync"},
+			{Kind: "Service", Name: "x", Status: "OutOfSync"},
+			{Kind: "ConfigMap", Name: "x", Status: "Synced"},
+		},
+	}}
+	m.applyFilter(false)
+
+	line := m.renderSidebarApp(0, "", 20)
+	if !strings.HasSuffix(line, "2▲") {
+		t.Fatalf("expected the count at the end of the line, got %q", line)
+	}
+	if w := lipgloss.Width(line); w > 20 {
+		t.Fatalf("expected the line to fit 20 columns, got %d: %q", w, line)
+	}
+}
+
+func TestModel_resizeSidebarPersistsAndClamps(t *testing.T) {
+	cfg := config.Default()
+	cfg.StateFile = filepath.Join(t.TempDir(), "state.yaml")
+	m := NewModel(cfg, &fakeClient{})
+	m.width, m.height = 60, 20
+
+	press := func(r rune) {
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+		if cmd != nil {
+			if msg, ok := cmd().(stateSavedMsg); ok && msg.err != nil {
+				t.Fatalf("save state: %v", msg.err)
+			}
+		}
+	}
+
+	press('>')
+	if m.sidebarWidth != 30 {
+		t.Fatalf("expected sidebar width 30, got %d", m.sidebarWidth)
+	}
+	for i := 0; i < 20; i++ {
+		press('>')
+	}
+	if sb, main := m.paneWidths(); sb != 40 || main != 20 {
+		t.Fatalf("expected the main pane kept at its minimum, got %d/%d", sb, main)
+	}
+	for i := 0; i < 20; i++ {
+		press('<')
+	}
+	if m.sidebarWidth != minPaneWidth {
+		t.Fatalf("expected sidebar clamped to %d, got %d", minPaneWidth, m.sidebarWidth)
+	}
+
+	// A new session starts from the persisted width.
+	if got := NewModel(cfg, &fakeClient{}).sidebarWidth; got != minPaneWidth {
+		t.Fatalf("expected persisted width %d, got %d", minPaneWidth, got)
+	}
+}
+
+func TestModel_detailLoadIsDebounced(t *testing.T) {
+	cfg := config.Default()
+	cfg.UI.DetailDebounce = time.Millisecond
+	m := NewModel(cfg, &fakeClient{})
+	m.width, m.height = 80, 30
+	m.appsAll = []argocd.Application{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	m.applyFilter(false)
+
+	var pending []tea.Cmd
+	for i := 0; i < 2; i++ {
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = updated.(Model)
+		pending = append(pending, cmd)
+	}
+
+	// The load scheduled while passing over "b" is dropped.
+	updated, cmd := m.Update(pending[0]())
+	m = updated.(Model)
+	if cmd != nil {
+		t.Fatalf("expected the superseded debounce to issue no load")
+	}
+
+	updated, cmd = m.Update(pending[1]())
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatalf("expected the settled selection to load details")
+	}
+	msg, ok := cmd().(detailMsg)
+	if !ok || msg.app.Name != "c" {
+		t.Fatalf("expected details for c, got %#v", msg)
+	}
+}
+
+func TestModel_staleDetailIsDropped(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.width, m.height = 80, 30
+	m.appsAll = []argocd.Application{{Name: "a"}, {Name: "b"}}
+	m.applyFilter(false)
+
+	slow := m.loadDetailCmd("a", false)
+	m.selected = 1
+	fast := m.loadDetailCmd("b", false)
+
+	updated, _ := m.Update(fast())
+	m = updated.(Model)
+	updated, _ = m.Update(slow())
+	m = updated.(Model)
+
+	if m.detail == nil || m.detail.Name != "b" {
+		t.Fatalf("expected the late result for a to be dropped, got %+v", m.detail)
+	}
+}
+
+func TestErrorText_apiError(t *testing.T) {
+	err := fmt.Errorf("load: %w", &argocd.APIError{
+		Method: "GET",
+		Path:   "/api/v1/applications/demo",
+		Status: 403,
+		Body:   `{"error":"permission denied","code":7,"message":"permission denied: applications, get, default/demo"}`,
+	})
+
+	short := errorText(err, false)
+	if !strings.HasPrefix(short, "403 Forbidden: permission denied: applications, get, default/demo") {
+		t.Fatalf("unexpected summary %q", short)
+	}
+	if strings.Contains(short, `"code"`) {
+		t.Fatalf("expected the raw body to stay hidden, got %q", short)
+	}
+	if full := errorText(err, true); !strings.Contains(full, "GET /api/v1/applications/demo") || !strings.Contains(full, `"code":7`) {
+		t.Fatalf("expected request and body when expanded, got %q", full)
+	}
+	if got := errorText(errors.New("dial tcp: refused"), false); got != "dial tcp: refused" {
+		t.Fatalf("expected plain errors unchanged, got %q", got)
+	}
+}
+
+type recordingClient struct {
+	*fakeClient
+	records []argocd.RequestRecord
+}
+
+func (c recordingClient) RecentRequests() []argocd.RequestRecord { return c.records }
+
+func TestDebugModel_listsRequests(t *testing.T) {
+	c := recordingClient{fakeClient: &fakeClient{}, records: []argocd.RequestRecord{
+		{Method: "GET", Path: "/api/v1/applications/a", Status: 403, Duration: 12 * time.Millisecond},
+		{Method: "GET", Path: "/api/v1/applications", Status: 200, Duration: 80 * time.Millisecond},
+	}}
+	m := newDebugModel(newStyles(config.Theme{}), c)
+	m.setSize(120, 20)
+
+	body := m.renderBody()
+	if !strings.Contains(body, "403") || !strings.Contains(body, "80ms") {
+		t.Fatalf("expected both requests, got %q", body)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if body := m.renderBody(); strings.Contains(body, " 200 ") {
+		t.Fatalf("expected errors only, got %q", body)
+	}
+
+	if got := newDebugModel(newStyles(config.Theme{}), &fakeClient{}).renderBody(); !strings.Contains(got, "only kept by the HTTP client") {
+		t.Fatalf("expected a hint for clients without a request log, got %q", got)
+	}
+}
+
+func TestModel_resizeRelaysOutOverlays(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 60})
+	m = updated.(Model)
+
+	var history []argocd.SyncHistoryEntry
+	for i := 0; i < 20; i++ {
+		history = append(history, argocd.SyncHistoryEntry{Revision: fmt.Sprintf("r%d", i)})
+	}
+	hv := newHistoryModel(m.styles, m.client, argocd.Application{Name: "a", History: history}, true)
+	hv.setSize(m.overlaySize())
+	hv.selected = len(history) - 1
+	hv.ensureVisible()
+	m.historyView = &hv
+
+	lv := newLogsModel(m.styles, m.client, "a", "web-0")
+	lv.wrap = true
+	lv.lines = []string{strings.Repeat("x", 300)}
+	lv.setSize(m.overlaySize())
+	m.logsView = &lv
+
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = updated.(Model)
+	w, h := m.overlaySize()
+	if _, main := m.paneWidths(); w >= main {
+		t.Fatalf("overlay width %d does not fit the %d-column main pane", w, main)
+	}
+
+	if m.historyView.vp.Width != w || m.historyView.vp.Height != h-2 {
+		t.Fatalf("history viewport %dx%d, want %dx%d", m.historyView.vp.Width, m.historyView.vp.Height, w, h-2)
+	}
+	top := m.historyView.selected * historyEntryLines
+	if vp := m.historyView.vp; top < vp.YOffset || top+2 >= vp.YOffset+vp.Height {
+		t.Fatalf("selected entry at line %d is outside the window at offset %d", top, vp.YOffset)
+	}
+
+	if m.logsView.vp.Width != w {
+		t.Fatalf("logs viewport width %d, want %d", m.logsView.vp.Width, w)
+	}
+	for _, l := range strings.Split(m.logsView.renderBody(), "\n") {
+		if len(l) > w {
+			t.Fatalf("wrapped log line is %d wide, viewport is %d", len(l), w)
+		}
+	}
+	if !m.logsView.vp.AtBottom() {
+		t.Fatalf("expected a following log view to stay at the bottom after a resize")
+	}
+}
+
+func TestModel_detailReloadKeepsResourceSelection(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	res := func(names ...string) []argocd.Resource {
+		var rs []argocd.Resource
+		for _, n := range names {
+			rs = append(rs, argocd.Resource{Kind: "Deployment", Namespace: "web", Name: n})
+		}
+		return rs
+	}
+	m.detail = &argocd.Application{Name: "a", Resources: res("web", "worker")}
+	for i, n := range m.visibleResourceNodes() {
+		if !n.isGroup && m.detail.Resources[n.resourceIdx].Name == "worker" {
+			m.resourceSel = i
+		}
+	}
+	if r, ok := m.selectedResource(); !ok || r.Name != "worker" {
+		t.Fatalf("setup: expected worker selected, got %+v", r)
+	}
+
+	updated, _ := m.Update(detailMsg{gen: m.detailGen, app: argocd.Application{Name: "a", Resources: res("api", "cache", "worker", "web")}})
+	m = updated.(Model)
+	if r, ok := m.selectedResource(); !ok || r.Name != "worker" {
+		t.Fatalf("expected the selection to follow worker after the reload, got %+v", r)
+	}
+
+	updated, _ = m.Update(detailMsg{gen: m.detailGen, app: argocd.Application{Name: "a", Resources: res("api")}})
+	m = updated.(Model)
+	if r, ok := m.selectedResource(); !ok || r.Name != "api" {
+		t.Fatalf("expected a clamped selection once worker is gone, got %+v", r)
+	}
+}
+
+func TestModel_appsReloadKeepsResourceSelection(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	res := []argocd.Resource{
+		{Kind: "Deployment", Namespace: "web", Name: "web"},
+		{Kind: "Deployment", Namespace: "web", Name: "worker"},
+	}
+	updated, _ := m.Update(appsMsg{apps: []argocd.Application{{Name: "a"}, {Name: "b"}}})
+	m = updated.(Model)
+	updated, _ = m.Update(detailMsg{gen: m.detailGen, app: argocd.Application{Name: "a", Resources: res}})
+	m = updated.(Model)
+	for i, n := range m.visibleResourceNodes() {
+		if !n.isGroup && m.detail.Resources[n.resourceIdx].Name == "worker" {
+			m.resourceSel = i
+		}
+	}
+
+	// A refresh (r, auto-refresh, after a sync) reloads the list, then the details.
+	updated, _ = m.Update(appsMsg{apps: []argocd.Application{{Name: "a"}, {Name: "b"}}})
+	m = updated.(Model)
+	if m.detail == nil {
+		t.Fatalf("expected the selected app's details to stay until the reload lands")
+	}
+	updated, _ = m.Update(detailMsg{gen: m.detailGen, app: argocd.Application{Name: "a", Resources: append([]argocd.Resource{{Kind: "Deployment", Namespace: "web", Name: "api"}}, res...)}})
+	m = updated.(Model)
+	if r, ok := m.selectedResource(); !ok || r.Name != "worker" {
+		t.Fatalf("expected worker to stay selected across the list reload, got %+v", r)
+	}
+
+	// Details of an app that is no longer selected are dropped.
+	updated, _ = m.Update(appsMsg{apps: []argocd.Application{{Name: "b"}}})
+	m = updated.(Model)
+	if m.detail != nil {
+		t.Fatalf("expected stale details to be cleared, got %q", m.detail.Name)
+	}
+}
+
+func TestModel_resourceGroupCounts(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.detail = &argocd.Application{Name: "a", Resources: []argocd.Resource{
+		{Kind: "Deployment", Namespace: "web", Name: "api"},
+		{Kind: "Pod", Namespace: "web", Name: "api-0", Health: "Healthy"},
+		{Kind: "Pod", Namespace: "web", Name: "api-1", Health: "Degraded"},
+		{Kind: "ClusterRole", Name: "reader"},
+	}}
+	var groups []string
+	for _, n := range m.visibleResourceNodes() {
+		if n.isGroup {
+			groups = append(groups, n.label)
+		}
+	}
+	want := []string{"Namespace: cluster (1)", "ClusterRole (1)", "Namespace: web (3)", "Deployment (1)", "Pod (2)"}
+	if !reflect.DeepEqual(groups, want) {
+		t.Fatalf("group headers = %q, want %q", groups, want)
+	}
+
+	m.resourceProblemsOnly = true
+	groups = nil
+	for _, n := range m.visibleResourceNodes() {
+		if n.isGroup {
+			groups = append(groups, n.label)
+		}
+	}
+	if want := []string{"Namespace: web (1)", "Pod (1)"}; !reflect.DeepEqual(groups, want) {
+		t.Fatalf("filtered group headers = %q, want %q", groups, want)
+	}
+}
+
+func TestModel_resourceSortKeepsSelection(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.detail = &argocd.Application{Name: "a", Resources: []argocd.Resource{
+		{Kind: "Pod", Namespace: "web", Name: "a-0", Health: "Healthy", Status: "Synced"},
+		{Kind: "Pod", Namespace: "web", Name: "b-0", Health: "Progressing", Status: "OutOfSync", SyncWave: 3},
+		{Kind: "Pod", Namespace: "web", Name: "c-0", Health: "Degraded", Status: "Synced", SyncWave: -2},
+	}}
+	m.focusResources = true
+	m.resourceSel = 2 // namespace, kind, then a-0
+	names := func() []string {
+		var out []string
+		for _, n := range m.visibleResourceNodes() {
+			if !n.isGroup {
+				out = append(out, m.detail.Resources[n.resourceIdx].Name)
+			}
+		}
+		return out
+	}
+	sortKey := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}}
+
+	updated, _ := m.Update(sortKey)
+	m = updated.(Model)
+	if got := names(); !reflect.DeepEqual(got, []string{"c-0", "b-0", "a-0"}) || m.sortMode != sortByName {
+		t.Fatalf("health sort: %v (app sort %v)", got, m.sortMode)
+	}
+	if r, _ := m.selectedResource(); r.Name != "a-0" {
+		t.Fatalf("expected the selection to stay on a-0, got %s", r.Name)
+	}
+
+	updated, _ = m.Update(sortKey)
+	m = updated.(Model)
+	if got := names(); !reflect.DeepEqual(got, []string{"b-0", "a-0", "c-0"}) {
+		t.Fatalf("sync sort: %v", got)
+	}
+	updated, _ = m.Update(sortKey)
+	m = updated.(Model)
+	if got := names(); !reflect.DeepEqual(got, []string{"c-0", "a-0", "b-0"}) || m.resourceSortMode != sortByWave {
+		t.Fatalf("wave sort: %v", got)
+	}
+	updated, _ = m.Update(sortKey)
+	m = updated.(Model)
+	if got := names(); !reflect.DeepEqual(got, []string{"a-0", "b-0", "c-0"}) || m.resourceSortMode != sortByName {
+		t.Fatalf("name sort: %v", got)
+	}
+}
+
+func TestModel_waveSortSpansKinds(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.detail = &argocd.Application{Name: "a", Resources: []argocd.Resource{
+		{Kind: "Deployment", Group: "apps", Namespace: "web", Name: "api", SyncWave: 1},
+		{Kind: "ConfigMap", Namespace: "web", Name: "cfg", SyncWave: -1},
+		{Kind: "Job", Group: "batch", Namespace: "web", Name: "migrate", Hook: true},
+		{Kind: "Namespace", Name: "web", SyncWave: -1},
+	}}
+	m.focusResources = true
+	m.resourceSortMode = sortByWave
+
+	var labels []string
+	for _, n := range m.visibleResourceNodes() {
+		labels = append(labels, n.label)
+	}
+	want := []string{
+		"Wave -1 (2)",
+		"Namespace/web [—/—]",
+		"web/ConfigMap/cfg [—/—]",
+		"Wave 0 (1)",
+		"web/Job/migrate [—/—] [hook]",
+		"Wave 1 (1)",
+		"web/Deployment/api [—/—]",
+	}
+	if !reflect.DeepEqual(labels, want) {
+		t.Fatalf("wave tree = %q, want %q", labels, want)
+	}
+
+	// Leaving wave mode keeps the selected resource and drops a wave zoom.
+	m.resourceZoom = "wave:1"
+	m.resourceSel = 1 // the wave header, then api
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	m = updated.(Model)
+	if m.resourceZoom != "" {
+		t.Fatalf("expected the wave zoom to be dropped, got %q", m.resourceZoom)
+	}
+	if r, _ := m.selectedResource(); r.Name != "api" {
+		t.Fatalf("expected the selection to stay on api, got %s", r.Name)
+	}
+}
+
+func TestModel_cheatsheet(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyF1})
+	m = updated.(Model)
+	if m.cheatsheet == nil {
+		t.Fatalf("expected F1 to open the cheatsheet")
+	}
+	body := m.cheatsheet.renderBody()
+	for _, want := range []string{"Application list", "Resource pane", "Logs", "all keybindings", "follow", "ignore whitespace"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("cheatsheet is missing %q", want)
+		}
+	}
+	if strings.Count(body, "history") > 1 {
+		t.Errorf("keys listed twice in FullHelp should appear once:\n%s", body)
+	}
+
+	// Keys go to the cheatsheet, not the list behind it.
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	m = updated.(Model)
+	if m.sortMode != sortByName {
+		t.Fatalf("expected S to be swallowed while the cheatsheet is open")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyF1})
+	m = updated.(Model)
+	if m.cheatsheet != nil {
+		t.Fatalf("expected F1 to close the cheatsheet")
+	}
+}
+
+func TestModel_footerHelpFollowsContext(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 400, Height: 40})
+	m = updated.(Model)
+
+	if got := m.renderFooter(m.width); !strings.Contains(got, "sort") || strings.Contains(got, "cancel") {
+		t.Fatalf("list footer should show the list keys:\n%s", got)
+	}
+
+	m.syncModal = true
+	got := m.renderFooter(m.width)
+	for _, want := range []string{"y sync", "n/esc cancel"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("sync modal footer is missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "history") {
+		t.Errorf("sync modal footer should not list app keys:\n%s", got)
+	}
+	m.syncModal = false
+
+	dv := newDiffModel(m.styles, m.client, "a", nil)
+	m.diffView = &dv
+	if got := m.helpContext(); got != helpContextOverlay {
+		t.Fatalf("context with the diff open = %v", got)
+	}
+	m.diffView = nil
+
+	m.focusResources = true
+	if got := m.renderFooter(m.width); !strings.Contains(got, "collapse") {
+		t.Fatalf("resource pane footer should show the resource keys:\n%s", got)
+	}
+}
+
+func TestModel_detailUpdatesListEntry(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.width, m.height = 120, 40
+	m.appsAll = []argocd.Application{
+		{Name: "a", Health: "Healthy", Sync: "Synced"},
+		{Name: "b", Health: "Healthy", Sync: "OutOfSync"},
+	}
+	m.driftCount = countDrifted(m.appsAll)
+	m.applyFilter(false)
+	m.selected = 1
+	before := m.appsAll
+
+	updated, _ := m.Update(detailMsg{gen: m.detailGen, app: argocd.Application{Name: "b", Health: "Degraded", Sync: "Synced"}})
+	m = updated.(Model)
+	if got := m.appsAll[1]; got.Health != "Degraded" || got.Sync != "Synced" {
+		t.Fatalf("list entry = %+v, want the refreshed status", got)
+	}
+	if m.driftCount != 0 {
+		t.Fatalf("drift count = %d, want 0 after b synced", m.driftCount)
+	}
+	if m.apps[m.selected].Name != "b" {
+		t.Fatalf("selection moved to %s", m.apps[m.selected].Name)
+	}
+	if before[1].Health != "Healthy" {
+		t.Fatalf("the previous model's list was modified in place")
+	}
+}
+
+func TestModel_staleReconcileHint(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	app := argocd.Application{Name: "a", ReconciledAt: "2026-03-01T10:00:00Z"}
+
+	if got := m.staleReconcileHint(app, now); got != "⚠ last reconciled 2h ago — press R to refresh" {
+		t.Fatalf("unexpected hint %q", got)
+	}
+	app.ReconciledAt = "2026-03-01T11:45:00Z"
+	if got := m.staleReconcileHint(app, now); got != "" {
+		t.Fatalf("expected no hint within the threshold, got %q", got)
+	}
+	app.ReconciledAt = ""
+	if got := m.staleReconcileHint(app, now); got != "" {
+		t.Fatalf("expected no hint without reconciledAt, got %q", got)
+	}
+	app.ReconciledAt = "2026-03-01T10:00:00Z"
+	m.cfg.UI.StaleReconcileMinutes = 0
+	if got := m.staleReconcileHint(app, now); got != "" {
+		t.Fatalf("expected 0 to disable the hint, got %q", got)
+	}
+}
+
+func TestModel_customActions(t *testing.T) {
+	if err := ValidateCustomActionKeys([]config.CustomAction{{Key: "ctrl+o", Name: "ok"}}); err != nil {
+		t.Fatalf("ctrl+o rejected: %v", err)
+	}
+	for _, k := range []string{"s", "tab", "l", "?"} {
+		if err := ValidateCustomActionKeys([]config.CustomAction{{Key: k, Name: "clash"}}); err == nil {
+			t.Errorf("key %q should clash with a built-in key", k)
+		}
+	}
+
+	cfg := config.Default()
+	cfg.CustomActions = []config.CustomAction{{Key: "ctrl+o", Name: "echo", Command: "echo {{.Name}} {{.Namespace}}"}}
+	m := NewModel(cfg, &fakeClient{})
+	m.apps = []argocd.Application{{Name: "team-a/web", Namespace: "web-prod"}}
+	m.selected = 0
+	a, ok := m.customAction("ctrl+o")
+	if !ok {
+		t.Fatal("action not found for ctrl+o")
+	}
+	got, err := a.Render(m.customActionVars(m.apps[0]))
+	if err != nil || got != "echo 'web' 'web-prod'" {
+		t.Fatalf("render = %q, %v", got, err)
+	}
+
+	m.width, m.height = 120, 40
+	updated, _ := m.Update(customActionDoneMsg{action: "echo", app: "team-a/web", output: "web web-prod\n"})
+	m = updated.(Model)
+	if m.actionOutput == nil {
+		t.Fatal("output overlay not opened")
+	}
+	if v := m.actionOutput.View(); !strings.Contains(v, "web web-prod") || !strings.Contains(v, "Action: echo") {
+		t.Fatalf("overlay view = %q", v)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.(Model).actionOutput != nil {
+		t.Fatal("esc did not close the output overlay")
+	}
+}
+
+func TestStripControl(t *testing.T) {
+	in := "\x1b[31mred\x1b[0m\tok\r\n\x1b]52;c;cHduZWQ=\a\x1b]0;title\x1b\\done\x1b[2J\x07\u009b\n"
+	if got, want := stripControl(in), "red\tok\ndone\n"; got != want {
+		t.Fatalf("stripControl = %q, want %q", got, want)
+	}
+}
+
+func TestAppWebURL(t *testing.T) {
+	cases := []struct {
+		app  argocd.Application
+		want string
+	}{
+		{argocd.Application{Name: "web"}, "https://argocd.example/applications/web"},
+		{argocd.Application{Name: "web", AppObjectNamespace: "argocd"}, "https://argocd.example/applications/argocd/web"},
+		{argocd.Application{Name: "team-a/web", AppObjectNamespace: "team-a"}, "https://argocd.example/applications/team-a/web"},
+	}
+	for _, c := range cases {
+		if got := appWebURL("https://argocd.example/", c.app); got != c.want {
+			t.Errorf("appWebURL(%+v) = %q, want %q", c.app, got, c.want)
+		}
+	}
+}
+
+func TestModel_browserFallbackShowsURL(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	u := "https://argocd.example/applications/web"
+	got, cmd := m.handleBrowserOpened(browserOpenedMsg{url: u, err: errNoBrowser})
+	if !strings.Contains(got.statusLine, u+" (copy requested)") || cmd == nil {
+		t.Fatalf("status = %q, copy cmd = %v", got.statusLine, cmd != nil)
+	}
+	// The copy request goes out with the frame, not behind the renderer.
+	got.width, got.height = 120, 30
+	osc := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(u)) + "\a"
+	if !strings.HasPrefix(got.View(), osc) {
+		t.Fatalf("expected the OSC 52 request in the frame")
+	}
+	updated, _ := got.Update(cmd())
+	if strings.Contains(updated.(Model).View(), "\x1b]52") {
+		t.Fatal("expected the request out of the frame once it has been sent")
+	}
+	got, cmd = m.handleBrowserOpened(browserOpenedMsg{url: u})
+	if got.statusLine != "opened "+u || cmd != nil {
+		t.Fatalf("status = %q", got.statusLine)
+	}
+}
+
+func TestModel_diffAllDrifted(t *testing.T) {
+	fc := &fakeClient{diffs: []argocd.DiffResult{
+		{Ref: argocd.ResourceRef{Kind: "Deployment", Name: "api"}, Modified: true, Diff: "-replicas: 1\n+replicas: 2"},
+		{Ref: argocd.ResourceRef{Kind: "Service", Name: "api"}},
+	}}
+	m := NewModel(config.Default(), fc)
+	m.width, m.height = 120, 60
+	m.appsAll = []argocd.Application{{Name: "a", Sync: "Synced"}, {Name: "b", Sync: "OutOfSync"}, {Name: "c", Sync: "OutOfSync"}}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	m = updated.(Model)
+	if m.driftDiffs == nil || cmd == nil {
+		t.Fatal("V did not open the drifted-apps diff")
+	}
+	if v := m.driftDiffs.View(); !strings.Contains(v, "loading 0/2") {
+		t.Fatalf("expected progress in header:\n%s", v)
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("expected one diff request per drifted app, got %T", cmd())
+	}
+	for _, c := range batch {
+		updated, _ = m.Update(c())
+		m = updated.(Model)
+	}
+	if fc.diffCalls != 2 {
+		t.Fatalf("diff calls = %d, want 2", fc.diffCalls)
+	}
+	v := m.driftDiffs.View()
+	for _, want := range []string{"b (1 modified)", "c (1 modified)", "Deployment/api", "+replicas: 2"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("view missing %q:\n%s", want, v)
+		}
+	}
+	if strings.Contains(v, "loading") || strings.Contains(v, "Service/api") {
+		t.Errorf("unexpected progress or unmodified resource:\n%s", v)
+	}
+
+	m.driftDiffs.vp.Height = 2
+	dd := *m.driftDiffs
+	dd.jumpApp(1)
+	if dd.vp.YOffset != dd.appLines[1] {
+		t.Fatalf("n went to line %d, want %d", dd.vp.YOffset, dd.appLines[1])
+	}
+	dd.jumpApp(-1)
+	if dd.vp.YOffset != 0 {
+		t.Fatalf("N went to line %d, want 0", dd.vp.YOffset)
+	}
+}
+
+func TestSyncImpactSummary(t *testing.T) {
+	preview := map[string][]argocd.Resource{
+		"a": {{Kind: "Deployment"}, {Kind: "Service"}, {Kind: "NetworkPolicy"}},
+		"b": {{Kind: "Deployment"}, {Kind: "NetworkPolicy"}, {Kind: "Ingress"}},
+	}
+	got := syncImpactSummary([]string{"a", "b"}, preview)
+	want := "Impact: 2 apps, 6 resources — Deployments: 2, NetworkPolicies: 2, Ingress: 1, Service: 1"
+	if got != want {
+		t.Fatalf("summary = %q\nwant      %q", got, want)
+	}
+	// List apps carry no resources, so a batch often can't be totalled.
+	preview["c"] = []argocd.Resource{}
+	got = syncImpactSummary([]string{"a", "b", "c", "d", "e"}, preview)
+	if want := want[:len("Impact: ")] + "5 apps" + want[len("Impact: 2 apps"):] + "; 2 apps without resource data"; got != want {
+		t.Fatalf("summary = %q\nwant      %q", got, want)
+	}
+	if got := syncImpactSummary([]string{"a"}, nil); got != "Impact: 1 app, no drifted resources known; 1 app without resource data" {
+		t.Fatalf("empty summary = %q", got)
+	}
+	for kind, want := range map[string]string{"Ingress": "Ingresses", "Endpoints": "Endpoints", "NetworkPolicy": "NetworkPolicies", "Service": "Services"} {
+		if got := pluralKind(kind); got != want {
+			t.Errorf("pluralKind(%s) = %q, want %q", kind, got, want)
+		}
+	}
+}
+
+func TestHistoryModel_renderEntry(t *testing.T) {
+	app := argocd.Application{Name: "a", History: []argocd.SyncHistoryEntry{
+		{Revision: "abc123", DeployedAt: "2026-02-01T12:00:00Z", Status: "Succeeded", Message: "Bump chart to 1.2\n\nLong body.", Source: "alice"},
+		{Revision: "def456", Status: "Succeeded"},
+	}}
+	hv := newHistoryModel(newStyles(config.Theme{}), &fakeClient{}, app, true)
+	body := hv.renderBody()
+	for _, want := range []string{"abc123  Succeeded", "    Bump chart to 1.2\n", "    by: alice", "def456  Succeeded", "    —\n", "    by: —"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in history:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "Long body.") {
+		t.Fatalf("expected only the commit summary line, got:\n%s", body)
+	}
+}
+
+func TestModel_historyEnterOpensRevisionDetails(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	app := argocd.Application{Name: "a", History: []argocd.SyncHistoryEntry{{Revision: "abc123"}}}
+	hv := newHistoryModel(m.styles, m.client, app, true)
+	m.historyView = &hv
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.revisionView == nil || m.revisionView.revision != "abc123" || cmd == nil {
+		t.Fatalf("expected enter to open revision details for the selected entry")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.revisionView != nil || m.historyView == nil {
+		t.Fatalf("expected esc to close revision details and return to history")
+	}
+}
+
+func TestModel_historyDiffComparesWithPreviousOrMarked(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	app := argocd.Application{Name: "a", History: []argocd.SyncHistoryEntry{{Revision: "r1"}, {Revision: "r2"}, {Revision: "r3"}}}
+	hv := newHistoryModel(m.styles, m.client, app, true)
+	hv.selected = 2
+	m.historyView = &hv
+
+	if from, to, ok := m.historyView.diffRevisions(); !ok || from != "r2" || to != "r3" {
+		t.Fatalf("expected selected vs previous, got %q..%q", from, to)
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(Model)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = updated.(Model)
+	if m.revisionDiff == nil || m.revisionDiff.from != "r1" || m.revisionDiff.to != "r3" || cmd == nil {
+		t.Fatalf("expected d to diff the marked revision against the selected one")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if body := m.revisionDiff.renderBody(); !strings.Contains(body, "rev: r3") || !strings.Contains(body, "to r3") {
+		t.Fatalf("expected metadata and manifest diff, got:\n%s", body)
+	}
+}
+
+func TestModel_historySyncsToSelectedRevision(t *testing.T) {
+	c := &fakeClient{}
+	m := NewModel(config.Default(), c)
+	app := argocd.Application{Name: "a", History: []argocd.SyncHistoryEntry{{Revision: "r1"}, {Revision: "r2"}}}
+	hv := newHistoryModel(m.styles, m.client, app, true)
+	m.historyView = &hv
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}})
+	m = updated.(Model)
+	if !m.syncModal || m.syncRevision != "r1" || m.historyView != nil || cmd == nil {
+		t.Fatalf("expected Y in history to open the sync modal pinned to r1")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = updated.(Model)
+	cmd()
+
+	want := []syncCall{{name: "a", dryRun: true, revision: "r1"}, {name: "a", revision: "r1"}}
+	if len(c.syncCalls) != 2 || c.syncCalls[0] != want[0] || c.syncCalls[1] != want[1] {
+		t.Fatalf("expected dry-run and sync pinned to r1, got %+v", c.syncCalls)
+	}
+}
+
+func TestModel_confirmDestructiveRequiresTypedNameForTerminate(t *testing.T) {
+	cfg := config.Default()
+	cfg.UI.ConfirmDestructive = true
+	m := NewModel(cfg, &fakeClient{})
+	m.terminateModal = true
+	m.terminateApp = "prod-api"
+
+	press := func(msg tea.KeyMsg) tea.Cmd {
+		updated, cmd := m.Update(msg)
+		m = updated.(Model)
+		return cmd
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.destructiveConfirm.active() {
+		t.Fatalf("expected enter to ask for the app name")
+	}
+	for _, r := range "prod-ap" {
+		press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if cmd := press(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || m.terminateLoading {
+		t.Fatalf("expected a partial name not to terminate")
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	cmd := press(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || !m.terminateLoading {
+		t.Fatalf("expected the typed name to start the terminate")
+	}
+	if _, ok := cmd().(terminateMsg); !ok {
+		t.Fatalf("expected terminateMsg")
+	}
+}
+
+func TestModel_escWaitsForTerminateInFlight(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.terminateModal = true
+	m.terminateApp = "a"
+
+	press := func(msg tea.KeyMsg) tea.Cmd {
+		updated, cmd := m.Update(msg)
+		m = updated.(Model)
+		return cmd
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	cmd := press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil || !m.terminateLoading {
+		t.Fatalf("expected y to start the terminate")
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.terminateModal || !m.terminateLoading {
+		t.Fatalf("expected esc to be ignored while the terminate is in flight")
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	if m.terminateModal || m.statusLine != "operation terminated" {
+		t.Fatalf("expected the terminate's result reported, got modal=%v status=%q", m.terminateModal, m.statusLine)
+	}
+}
+
+func TestModel_closingRollbackCancelsRevisionsLoad(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{blockRevisions: true})
+	m.appsAll = []argocd.Application{{Name: "a"}}
+	m.applyFilter(false)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	m = updated.(Model)
+	if !m.rollbackModal || cmd == nil {
+		t.Fatalf("expected the rollback modal to start loading revisions")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected closing the modal to cancel the revisions load")
+	}
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	if m.rollbackModal || m.rollbackErr != nil || m.statusLine != "rollback cancelled" {
+		t.Fatalf("expected the late result to be dropped, got modal=%v err=%v status=%q", m.rollbackModal, m.rollbackErr, m.statusLine)
+	}
+}
+
+func TestModel_rollbackConfirmShowsPreview(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.width, m.height = 120, 40
+	m.rollbackModal = true
+	m.rollbackApp = "a"
+	m.rollbackRevs = []argocd.Revision{{ID: 3, Revision: "v3"}, {ID: 2, Revision: "v2", Author: "dev", Message: "bump image\nmore"}}
+
+	press := func(msg tea.KeyMsg) tea.Cmd {
+		updated, cmd := m.Update(msg)
+		m = updated.(Model)
+		return cmd
+	}
+	if cmd := press(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Fatalf("expected no preview load for the deployed revision")
+	}
+	if view := m.renderMain(120, 40); !strings.Contains(view, "Already the deployed revision") {
+		t.Fatalf("expected the deployed revision to be called out, got:\n%s", view)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	if m.rollbackConfirm {
+		t.Fatalf("expected moving to reset the confirmation")
+	}
+	cmd := press(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || !m.rollbackPreviewLoading {
+		t.Fatalf("expected enter to load the preview")
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	view := m.renderMain(120, 40)
+	for _, want := range []string{"Rolling back to v2", "dev", "to v2", "Manifest changes: +1 −1 lines", "rev: v2", "y=confirm"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected preview to contain %q, got:\n%s", want, view)
+		}
+	}
+	if cmd := press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}}); cmd == nil || !m.rollbackLoading {
+		t.Fatalf("expected y to still start the rollback")
+	}
+}
+
+func TestModel_refreshThenDiffOpensDiffAfterHardRefresh(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.appsAll = []argocd.Application{{Name: "a"}, {Name: "b"}}
+	m.applyFilter(false)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	m = updated.(Model)
+	if cmd == nil || m.diffView != nil {
+		t.Fatalf("expected F to start a hard refresh without opening the diff yet")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.diffView == nil || m.diffView.app != "a" || m.pendingDiff != "" {
+		t.Fatalf("expected the diff to open once the refresh landed")
+	}
+
+	// A plain refresh afterwards doesn't reopen it.
+	m.diffView = nil
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.diffView != nil {
+		t.Fatalf("expected the pending diff to be cleared after use")
+	}
+}
+
+func TestSyncWindowStatus(t *testing.T) {
+	st := newStyles(config.Default().UI.Theme)
+	now := time.Now()
+	if got := syncWindowStatus(nil, now, st); got != "OPEN (no sync windows)" {
+		t.Errorf("no windows: %q", got)
+	}
+	allow := argocd.SyncWindow{Kind: "allow", Schedule: "0 9 * * *", Duration: "8h"}
+	if got := syncWindowStatus([]argocd.SyncWindow{allow}, now, st); !strings.Contains(got, "DENY (outside the allow windows)") {
+		t.Errorf("outside allow windows: %q", got)
+	}
+	deny := argocd.SyncWindow{Kind: "deny", Schedule: "* * * * *", Duration: "10m", Active: true}
+	want := "DENY until " + now.Truncate(time.Minute).Add(10*time.Minute).Local().Format("15:04")
+	if got := syncWindowStatus([]argocd.SyncWindow{deny}, now, st); !strings.Contains(got, want) {
+		t.Errorf("active deny: %q, want %q", got, want)
+	}
+}
+
+func TestModel_compareMarkedApps(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.width, m.height = 120, 40
+	m.appsAll = []argocd.Application{
+		{Name: "svc-staging", Project: "svc", RepoURL: "https://git.example/svc", Path: "deploy", Revision: "main", Namespace: "staging", SyncPolicy: "auto"},
+		{Name: "svc-prod", Project: "svc", RepoURL: "https://git.example/svc", Path: "deploy", Revision: "v1.4.0", Namespace: "prod", SyncPolicy: "manual"},
+		{Name: "other"},
+	}
+	m.applyFilter(false)
+
+	press := func(r rune) {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	selectApp := func(name string) {
+		for i, a := range m.apps {
+			if a.Name == name {
+				m.selected = i
+			}
+		}
+	}
+	press('=')
+	if m.compareView != nil {
+		t.Fatalf("expected compare to need marks")
+	}
+	selectApp("other")
+	press('M')
+	selectApp("svc-staging")
+	press('M')
+	selectApp("svc-prod")
+	press('M') // a third mark drops "other"
+	if !reflect.DeepEqual(m.compareMarks, []string{"svc-staging", "svc-prod"}) {
+		t.Fatalf("marks = %v", m.compareMarks)
+	}
+	press('=')
+	if m.compareView == nil {
+		t.Fatalf("expected = to open the comparison")
+	}
+	if got := m.compareView.differences(); !reflect.DeepEqual(got, []string{"Revision", "Namespace", "Sync policy"}) {
+		t.Fatalf("differences = %v", got)
+	}
+	view := m.compareView.View()
+	if !strings.Contains(view, "svc-staging ↔ svc-prod") || !strings.Contains(view, "≠ Revision") {
+		t.Fatalf("unexpected view:\n%s", view)
+	}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.compareView != nil {
+		t.Fatalf("expected esc to close the comparison")
+	}
+}
+
+func TestModel_editToAutoSyncNeedsAcknowledgement(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.appsAll = []argocd.Application{{Name: "a", SyncPolicy: "manual", RepoURL: "https://example.com/repo"}}
+	m.applyFilter(false)
+
+	press := func(msg tea.KeyMsg) tea.Cmd {
+		updated, cmd := m.Update(msg)
+		m = updated.(Model)
+		return cmd
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	for m.editStep != createStepSyncPolicy {
+		press(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if cmd := press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}}); cmd != nil || m.editSaving {
+		t.Fatalf("expected save to wait for the manual → auto acknowledgement")
+	}
+	if !strings.Contains(m.renderEditWizard(), "trigger automatic syncing") {
+		t.Fatalf("expected a warning on the confirm step")
+	}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	if cmd := press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}}); cmd == nil || !m.editSaving {
+		t.Fatalf("expected save after acknowledging")
+	}
+}
+
+func TestModel_gotoApp(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.width, m.height = 120, 40
+	m.appsAll = []argocd.Application{
+		{Name: "billing", Project: "finance"},
+		{Name: "payments-api", Project: "finance"},
+		{Name: "payments", Project: "finance"},
+		{Name: "web", Project: "frontend"},
+	}
+	m.applyFilter(false)
+
+	send := func(msg tea.KeyMsg) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	gotoApp := func(q string) {
+		send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{':'}})
+		for _, r := range q {
+			send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		send(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+
+	gotoApp("pay")
+	if got := m.apps[m.selected].Name; got != "payments" {
+		t.Fatalf("prefix selected %q, want the first match in sidebar order", got)
+	}
+	gotoApp("PAYMENTS-API")
+	if got := m.apps[m.selected].Name; got != "payments-api" || m.gotoActive {
+		t.Fatalf("exact name selected %q (prompt open %v)", got, m.gotoActive)
+	}
+	if len(m.apps) != 4 || m.filterInput.Value() != "" {
+		t.Fatalf("go-to must not filter the list")
+	}
+
+	gotoApp("nope")
+	if m.apps[m.selected].Name != "payments-api" || !strings.Contains(m.statusLine, "no app matching") {
+		t.Fatalf("unmatched go-to moved the selection or said %q", m.statusLine)
+	}
+
+	// A collapsed group is expanded to reach the app.
+	m.groupMode = groupByProject
+	m.groupCollapsed = map[string]bool{"frontend": true}
+	m.applyFilter(true)
+	gotoApp("we")
+	if got := m.apps[m.selected].Name; got != "web" || m.groupCollapsed["frontend"] {
+		t.Fatalf("selected %q, collapsed %v", got, m.groupCollapsed)
+	}
+}
+
+func TestModel_pinnedAppsSortFirstAndPersist(t *testing.T) {
+	cfg := config.Default()
+	cfg.StateFile = filepath.Join(t.TempDir(), "state.yaml")
+	m := NewModel(cfg, &fakeClient{})
+	m.width, m.height = 80, 30
+	m.appsAll = []argocd.Application{{Name: "alpha"}, {Name: "bravo"}, {Name: "charlie"}}
+	m.applyFilter(false)
+
+	press := func(r rune) {
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+		if cmd != nil {
+			if msg, ok := cmd().(stateSavedMsg); ok && msg.err != nil {
+				t.Fatalf("save state: %v", msg.err)
+			}
+		}
+	}
+	names := func() []string {
+		var out []string
+		for _, a := range m.apps {
+			out = append(out, a.Name)
+		}
+		return out
+	}
+
+	m.selected = 2
+	press('p')
+	if got := names(); !reflect.DeepEqual(got, []string{"charlie", "alpha", "bravo"}) {
+		t.Fatalf("order = %v, want the pin first", got)
+	}
+	if m.apps[m.selected].Name != "charlie" {
+		t.Fatalf("expected the selection to follow the pinned app")
+	}
+	if !strings.Contains(m.renderSidebarApp(0, "", 30), "★ charlie") {
+		t.Fatalf("expected a ★ marker, got %q", m.renderSidebarApp(0, "", 30))
+	}
+
+	press('P')
+	if got := names(); !reflect.DeepEqual(got, []string{"charlie"}) {
+		t.Fatalf("pinned only = %v", got)
+	}
+	press('P')
+
+	// A new session starts with the saved pins.
+	if got := NewModel(cfg, &fakeClient{}).pinned; !got["charlie"] || len(got) != 1 {
+		t.Fatalf("expected persisted pins, got %v", got)
+	}
+
+	press('p')
+	if got := names(); !reflect.DeepEqual(got, []string{"alpha", "bravo", "charlie"}) {
+		t.Fatalf("order after unpin = %v", got)
+	}
+}
+
+func TestModel_createWizardPreset(t *testing.T) {
+	cfg := config.Default()
+	cfg.CreatePresets = []config.CreatePreset{
+		{Name: "prod-service", Project: "services", Cluster: "https://prod.example", Namespace: "prod", SyncPolicy: "auto", Revision: "release"},
+	}
+	client := &fakeClient{}
+	m := NewModel(cfg, client)
+	m.width, m.height = 120, 40
+	m.createProjects = []string{"default", "services"}
+	m.createRepos = []argocd.Repository{{Repo: "https://git.example/svc", ConnectionStatus: "Successful"}}
+	m.createClusters = []argocd.Cluster{{Server: "https://kubernetes.default.svc", Name: "in-cluster"}}
+
+	send := func(msg tea.KeyMsg) tea.Cmd {
+		updated, cmd := m.Update(msg)
+		m = updated.(Model)
+		return cmd
+	}
+	typeText := func(s string) {
+		for _, r := range s {
+			send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+	enter := func() tea.Cmd { return send(tea.KeyMsg{Type: tea.KeyEnter}) }
+	selected := func() string {
+		sel, _ := m.createListSelection()
+		return sel
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if m.createStep != createStepPreset {
+		t.Fatalf("expected the wizard to start at the preset step, got %d", m.createStep)
+	}
+	send(tea.KeyMsg{Type: tea.KeyDown})
+	enter()
+	if m.createStep != createStepName || m.createPreset != "prod-service" {
+		t.Fatalf("expected the preset applied, step %d preset %q", m.createStep, m.createPreset)
+	}
+	typeText("payments")
+	enter()
+	if got := selected(); got != "services" {
+		t.Fatalf("project step starts on %q, want the preset's", got)
+	}
+	send(tea.KeyMsg{Type: tea.KeyUp}) // override the preset's project
+	enter()
+	enter() // repo
+	typeText("deploy")
+	enter()
+	if m.createStep != createStepRevision || m.createRevInput.Value() != "release" {
+		t.Fatalf("expected the revision step prefilled, step %d value %q", m.createStep, m.createRevInput.Value())
+	}
+	enter()
+	if got := selected(); got != "https://prod.example" {
+		t.Fatalf("cluster step starts on %q, want the preset's even though it wasn't listed", got)
+	}
+	enter()
+	if m.createNSInput.Value() != "prod" {
+		t.Fatalf("namespace = %q", m.createNSInput.Value())
+	}
+	enter()
+	if got := selected(); got != "auto" {
+		t.Fatalf("sync policy step starts on %q", got)
+	}
+	validate := enter()
+	if cmd := send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}}); cmd != nil {
+		t.Fatalf("y must wait for the validation")
+	}
+	updated, _ := m.Update(validate())
+	m = updated.(Model)
+	cmd := send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatalf("expected a create command")
+	}
+	cmd()
+	want := argocd.Application{Name: "payments", Project: "default", RepoURL: "https://git.example/svc", Path: "deploy", Revision: "release", Cluster: "https://prod.example", Namespace: "prod", SyncPolicy: "auto"}
+	if !reflect.DeepEqual(client.created, want) {
+		t.Fatalf("created %+v, want %+v", client.created, want)
+	}
+}
+
+func TestModel_createWizardShowsValidationErrors(t *testing.T) {
+	client := &fakeClient{validateErr: errors.Join(errors.New("application payments already exists"), errors.New("source: path not found"))}
+	m := NewModel(config.Default(), client)
+	m.width, m.height = 120, 40
+	m.createModal = true
+	m.createStep = createStepSyncPolicy
+	m.createNameInput.SetValue("payments")
+	m = m.setCreateList("Sync policy", []string{"manual", "auto"})
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.createStep != createStepConfirm || !m.createValidating || cmd == nil {
+		t.Fatalf("expected validation to start on the confirm step")
+	}
+	res := cmd()
+
+	// Stepping back and forth again makes the first result stale.
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	m = updated.(Model)
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	updated, _ = m.Update(res)
+	m = updated.(Model)
+	if !m.createValidating {
+		t.Fatal("a stale validation result was applied")
+	}
+
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	view := m.renderCreateWizard()
+	for _, want := range []string{"✗ application payments already exists", "✗ source: path not found", "y=create anyway"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("confirm step missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestModel_createRepoStepShowsConnectionState(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.width, m.height = 120, 40
+	m.createModal = true
+	m.createStep = createStepRepo
+	m.createRepos = []argocd.Repository{
+		{Repo: "https://git.example/ok", ConnectionStatus: "Successful"},
+		{Repo: "https://git.example/down", ConnectionStatus: "Failed", ConnectionMessage: "authentication required"},
+		{Repo: "https://git.example/new"},
+	}
+	m = m.setCreateRepoList()
+	var labels []string
+	for _, it := range m.createList.Items() {
+		labels = append(labels, it.(choiceItem).label)
+	}
+	want := []string{"✓ https://git.example/ok", "✗ https://git.example/down", "? https://git.example/new"}
+	if !reflect.DeepEqual(labels, want) {
+		t.Fatalf("labels = %q", labels)
+	}
+
+	m.createList.Select(1)
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.createRepo != "https://git.example/down" || m.createStep != createStepPath {
+		t.Fatalf("repo = %q, step %d", m.createRepo, m.createStep)
+	}
+	if !strings.Contains(m.statusLine, "authentication required") || !strings.Contains(m.renderCreateWizard(), "⚠ Argo CD cannot reach https://git.example/down") {
+		t.Fatalf("expected a warning for the failing repo, status %q", m.statusLine)
+	}
+}
+
+func TestModel_createWizardFriendlyClusterNames(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.createClusters = []argocd.Cluster{
+		{Server: "https://kubernetes.default.svc", Name: "in-cluster", ConnectionStatus: "Successful"},
+		{Server: "https://prod.example:6443", Name: "prod", ConnectionStatus: "Failed"},
+		// Without a server URL there is nothing to send as destination.server.
+		{Name: "no-server"},
+	}
+	m.createRepos = []argocd.Repository{{Repo: "https://charts.example", Name: "charts", Type: "helm"}}
+	m.createRepo = "https://charts.example"
+	// A preset may name the cluster rather than its server.
+	m.createCluster = "prod"
+	m = m.setCreateClusterList()
+
+	var labels []string
+	for _, it := range m.createList.Items() {
+		labels = append(labels, it.(choiceItem).label)
+	}
+	want := []string{"✓ in-cluster  https://kubernetes.default.svc", "✗ prod  https://prod.example:6443"}
+	if !reflect.DeepEqual(labels, want) {
+		t.Fatalf("labels = %q", labels)
+	}
+	if sel, _ := m.createListSelection(); sel != "https://prod.example:6443" || m.createCluster != sel {
+		t.Fatalf("selected %q, createCluster %q", sel, m.createCluster)
+	}
+
+	m.createModal = true
+	m.createStep = createStepConfirm
+	view := m.renderCreateWizard()
+	for _, want := range []string{"cluster:   prod  https://prod.example:6443", "repo:      charts  https://charts.example (helm)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("confirm step missing %q:\n%s", want, view)
+		}
+	}
+	if got := m.createWizardApp(); got.Cluster != "https://prod.example:6443" || got.RepoURL != "https://charts.example" {
+		t.Fatalf("app sends %q / %q, want the canonical values", got.Cluster, got.RepoURL)
+	}
+}
+
+func TestClusterNamespaces(t *testing.T) {
+	apps := []argocd.Application{
+		{Cluster: "https://a", Namespace: "web"},
+		{Cluster: "https://a", Namespace: "payments"},
+		{Cluster: "https://a", Namespace: "payments"},
+		{Cluster: "https://b", Namespace: "batch"},
+		{Cluster: "https://a"},
+	}
+	if got := clusterNamespaces(apps, "https://a"); !reflect.DeepEqual(got, []string{"payments", "web"}) {
+		t.Fatalf("namespaces = %q", got)
+	}
+	if got := namespaceSuggestions([]string{"payments", "web", "payments-staging"}, "pay"); !reflect.DeepEqual(got, []string{"payments", "payments-staging"}) {
+		t.Fatalf("suggestions = %q", got)
+	}
+}
+
+func TestModel_createNamespaceSuggestions(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.width, m.height = 120, 40
+	m.appsAll = []argocd.Application{
+		{Cluster: "https://kubernetes.default.svc", Namespace: "web"},
+		{Cluster: "https://kubernetes.default.svc", Namespace: "payments"},
+		{Cluster: "https://other", Namespace: "batch"},
+	}
+	m.createModal = true
+	m.createStep = createStepCluster
+	m = m.setCreateList("Cluster", []string{"https://kubernetes.default.svc"})
+	send := func(msg tea.KeyMsg) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.createStep != createStepNamespace {
+		t.Fatalf("step = %d", m.createStep)
+	}
+	view := m.renderCreateWizard()
+	if !strings.Contains(view, "payments") || strings.Contains(view, "batch") {
+		t.Fatalf("expected this cluster's namespaces only:\n%s", view)
+	}
+
+	// Typing narrows the list; tab completes the best match.
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("pa")})
+	send(tea.KeyMsg{Type: tea.KeyTab})
+	if m.createNSInput.Value() != "payments" {
+		t.Fatalf("tab completed %q", m.createNSInput.Value())
+	}
+
+	// ↓ then enter takes the picked suggestion over the typed text.
+	m.createNSInput.SetValue("")
+	send(tea.KeyMsg{Type: tea.KeyDown})
+	send(tea.KeyMsg{Type: tea.KeyDown})
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.createStep != createStepSyncPolicy || m.createNSInput.Value() != "web" {
+		t.Fatalf("step %d, namespace %q", m.createStep, m.createNSInput.Value())
+	}
+
+	// Free text still works when nothing is picked.
+	send(tea.KeyMsg{Type: tea.KeyLeft})
+	m.createNSInput.Focus()
+	m.createNSInput.SetValue("")
+	send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("brand-new")})
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.createNSInput.Value() != "brand-new" {
+		t.Fatalf("namespace = %q", m.createNSInput.Value())
+	}
+}
+
+func TestModel_webUIHintIsDismissible(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.width, m.height = 140, 30
+	updated, _ := m.Update(appsMsg{err: &argocd.APIError{Method: "GET", Path: "/api/v1/applications", Status: 404, Body: "<html></html>", ContentType: "text/html"}})
+	m = updated.(Model)
+	if !strings.Contains(m.View(), "Argo CD web UI, not the API") {
+		t.Fatalf("expected the web UI hint")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if strings.Contains(m.View(), "Argo CD web UI, not the API") {
+		t.Fatalf("expected esc to dismiss the hint")
+	}
+
+	m.webUIHintDismissed = false
+	m.err = errors.New("connection refused")
+	if strings.Contains(m.View(), "Argo CD web UI") {
+		t.Fatalf("unrelated errors must not show the hint")
+	}
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"lazyargo/internal/argocd"
+)
+
+// maxNamespaceSuggestions caps the suggestions listed under the create
+// wizard's namespace input.
+const maxNamespaceSuggestions = 8
+
+// clusterNamespaces are the destination namespaces the loaded apps already
+// use on cluster, most used first. Argo CD has no API to list a cluster's
+// namespaces, so these stand in as the suggestions.
+func clusterNamespaces(apps []argocd.Application, cluster string) []string {
+	counts := map[string]int{}
+	for _, a := range apps {
+		if a.Cluster == cluster && a.Namespace != "" {
+			counts[a.Namespace]++
+		}
+	}
+	out := make([]string, 0, len(counts))
+	for ns := range counts {
+		out = append(out, ns)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if counts[out[i]] != counts[out[j]] {
+			return counts[out[i]] > counts[out[j]]
+		}
+		return out[i] < out[j]
+	})
+	return out
+}
+
+// namespaceSuggestions narrows namespaces to those fuzzy-matching query,
+// best match first; an empty query keeps them all in order.
+func namespaceSuggestions(namespaces []string, query string) []string {
+	query = strings.TrimSpace(query)
+	type match struct {
+		ns    string
+		score int
+	}
+	var matches []match
+	for _, ns := range namespaces {
+		if s, ok := fuzzyScore(query, ns); ok {
+			matches = append(matches, match{ns, s})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	out := make([]string, 0, min(len(matches), maxNamespaceSuggestions))
+	for _, mt := range matches {
+		if len(out) == maxNamespaceSuggestions {
+			break
+		}
+		out = append(out, mt.ns)
+	}
+	return out
+}
+
+// createNSSuggestions are the suggestions for what is typed so far.
+func (m Model) createNSSuggestions() []string {
+	return namespaceSuggestions(m.createNamespaces, m.createNSInput.Value())
+}
+
+// updateCreateNamespace handles the namespace step: ↑/↓ pick a suggestion,
+// tab copies it into the input, and enter takes the picked suggestion, or
+// else the typed text as is.
+func (m Model) updateCreateNamespace(k tea.KeyMsg) (Model, tea.Cmd) {
+	sugg := m.createNSSuggestions()
+	switch k.String() {
+	case "down":
+		if m.createNSPick < len(sugg)-1 {
+			m.createNSPick++
+		}
+		return m, nil
+	case "up":
+		if m.createNSPick >= 0 {
+			m.createNSPick--
+		}
+		return m, nil
+	case "tab":
+		if len(sugg) > 0 {
+			m.createNSInput.SetValue(sugg[max(m.createNSPick, 0)])
+			m.createNSInput.CursorEnd()
+			m.createNSPick = -1
+		}
+		return m, nil
+	case "enter":
+		if m.createNSPick >= 0 && m.createNSPick < len(sugg) {
+			m.createNSInput.SetValue(sugg[m.createNSPick])
+		}
+		m.createNSPick = -1
+		m.createNSInput.Blur()
+		m.createStep = createStepSyncPolicy
+		m = m.setCreateListPreferring("Sync policy", []string{"manual", "auto"}, m.createSyncPolicy)
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.createNSInput, cmd = m.createNSInput.Update(k)
+	// The suggestions change with the text; start over unpicked.
+	m.createNSPick = -1
+	return m, cmd
+}
+
+// renderCreateNamespace is the namespace step's input and suggestions.
+func (m Model) renderCreateNamespace() []string {
+	lines := []string{m.createNSInput.View()}
+	sugg := m.createNSSuggestions()
+	if len(sugg) == 0 {
+		if len(m.createNamespaces) > 0 {
+			lines = append(lines, "  (no matching namespace in use; enter creates it as typed)")
+		}
+		return append(lines, "", "Enter=next  ←=back  Esc=cancel")
+	}
+	lines = append(lines, "", "In use on this cluster:")
+	for i, ns := range sugg {
+		prefix := "  "
+		if i == m.createNSPick {
+			prefix = "▶ "
+		}
+		lines = append(lines, prefix+ns)
+	}
+	return append(lines, "", "↑/↓=pick  Tab=complete  Enter=next  ←=back  Esc=cancel")
+}
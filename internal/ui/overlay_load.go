@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"lazyargo/internal/argocd"
+)
+
+type loadPhase int
+
+const (
+	loadIdle loadPhase = iota
+	loadLoading
+	loadLoaded
+	loadFailed
+)
+
+// loadState is the load lifecycle every overlay shares: idle → loading →
+// loaded or failed, and back to loading on retry (r). It owns the
+// placeholder body and the header tag so overlays look the same while
+// loading or failed.
+type loadState struct {
+	phase    loadPhase
+	retrying bool
+	err      error
+	// expanded shows the full API error response (!).
+	expanded bool
+}
+
+// start marks a load as in flight.
+func (s *loadState) start() {
+	s.phase = loadLoading
+	s.err = nil
+}
+
+// finish records the result of the load in flight.
+func (s *loadState) finish(err error) {
+	s.retrying = false
+	s.err = err
+	s.phase = loadLoaded
+	if err != nil {
+		s.phase = loadFailed
+	}
+}
+
+func (s loadState) loading() bool { return s.phase == loadLoading }
+func (s loadState) failed() bool  { return s.phase == loadFailed }
+
+// handleKey handles ! and r while the load has failed. retry reports that
+// the caller should re-issue its load command.
+func (s *loadState) handleKey(msg tea.KeyMsg) (handled, retry bool) {
+	if s.phase != loadFailed {
+		return false, false
+	}
+	switch msg.String() {
+	case "!":
+		s.expanded = !s.expanded
+		return true, false
+	case "r":
+		s.start()
+		s.retrying = true
+		return true, true
+	}
+	return false, false
+}
+
+// body is the placeholder to render instead of the overlay's data; ok is
+// false once the data is loaded.
+func (s loadState) body() (string, bool) {
+	switch s.phase {
+	case loadLoading:
+		return loadingText(s.retrying), true
+	case loadFailed:
+		return loadErrorText(s.err, s.expanded), true
+	}
+	return "", false
+}
+
+// headerTag is appended to the overlay header.
+func (s loadState) headerTag() string {
+	switch s.phase {
+	case loadLoading:
+		if s.retrying {
+			return "  [retrying…]"
+		}
+		return "  [loading…]"
+	case loadFailed:
+		return "  [error: r=retry]"
+	}
+	return ""
+}
+
+// loadingText is the body an overlay shows while its data is loading.
+func loadingText(retrying bool) string {
+	if retrying {
+		return "Retrying…"
+	}
+	return "Loading…"
+}
+
+// errorText renders err for display. API errors collapse to their status and
+// reason; expanded adds the request and the full response body.
+func errorText(err error, expanded bool) string {
+	var apiErr *argocd.APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+	if !expanded {
+		return apiErr.Summary() + "\n(! = full response)"
+	}
+	return apiErr.Summary() + "\n\n" + apiErr.Method + " " + apiErr.Path + "\n\n" + apiErr.Body
+}
+
+// loadErrorText is the body of an overlay whose load failed; r re-issues it.
+func loadErrorText(err error, expanded bool) string {
+	return "Error:\n\n" + errorText(err, expanded) + "\n\nPress r to retry."
+}
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"lazyargo/internal/argocd"
+	"lazyargo/internal/config"
+)
+
+func TestLoadState_lifecycle(t *testing.T) {
+	var s loadState
+	if _, ok := s.body(); ok || s.headerTag() != "" {
+		t.Fatalf("idle state should render nothing")
+	}
+
+	s.start()
+	if body, ok := s.body(); !ok || body != "Loading…" || s.headerTag() != "  [loading…]" {
+		t.Fatalf("unexpected loading render %q %q", body, s.headerTag())
+	}
+	if handled, _ := s.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}); handled {
+		t.Fatalf("r should not be handled while loading")
+	}
+
+	s.finish(errors.New("boom"))
+	if !s.failed() || !strings.Contains(s.headerTag(), "error") {
+		t.Fatalf("expected failed state, got %+v", s)
+	}
+	if body, ok := s.body(); !ok || !strings.Contains(body, "boom") {
+		t.Fatalf("expected error body, got %q", body)
+	}
+
+	handled, retry := s.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if !handled || !retry || !s.loading() || !s.retrying || s.err != nil {
+		t.Fatalf("expected retry to restart the load, got %+v", s)
+	}
+	if s.headerTag() != "  [retrying…]" {
+		t.Fatalf("unexpected header tag %q", s.headerTag())
+	}
+
+	s.finish(nil)
+	if s.phase != loadLoaded || s.retrying {
+		t.Fatalf("expected loaded state, got %+v", s)
+	}
+	if _, ok := s.body(); ok {
+		t.Fatalf("loaded state should not render a placeholder")
+	}
+}
+
+func TestHistoryModel_loadsWhenListEntryHasNoHistory(t *testing.T) {
+	c := &fakeClient{}
+	hv := newHistoryModel(newStyles(config.Theme{}), c, argocd.Application{Name: "a"}, false)
+	cmd := hv.initCmd()
+	if cmd == nil || !hv.load.loading() || hv.selectedRevision() != "" {
+		t.Fatalf("expected history to start loading")
+	}
+	hv, _ = hv.Update(cmd())
+	if hv.load.phase != loadLoaded {
+		t.Fatalf("expected loaded history, got %+v", hv.load)
+	}
+}
+
+func TestModel_historyDropsLoadOfEarlierOverlay(t *testing.T) {
+	m := NewModel(config.Default(), &fakeClient{})
+	m.appsAll = []argocd.Application{{Name: "a"}, {Name: "b"}}
+	m.apps = m.appsAll
+
+	m.openHistory(m.apps[0], false)
+	staleGen := m.historyView.gen
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	m.openHistory(m.apps[1], false)
+
+	stale := historyLoadedMsg{gen: staleGen, app: argocd.Application{Name: "a", History: []argocd.SyncHistoryEntry{{Revision: "r-a"}}}}
+	updated, _ = m.Update(stale)
+	m = updated.(Model)
+	if !m.historyView.load.loading() || m.historyView.app.Name != "b" {
+		t.Fatalf("expected the late load of a to be dropped, got %+v", m.historyView.app)
+	}
+
+	fresh := historyLoadedMsg{gen: m.historyView.gen, app: argocd.Application{Name: "b", History: []argocd.SyncHistoryEntry{{Revision: "r-b"}}}}
+	updated, _ = m.Update(fresh)
+	m = updated.(Model)
+	if m.historyView.load.phase != loadLoaded || m.historyView.selectedRevision() != "r-b" {
+		t.Fatalf("expected b's history, got %+v", m.historyView.app)
+	}
+}
+
+func TestHistoryModel_fetchesCommitMessages(t *testing.T) {
+	c := &fakeClient{
+		messages:    map[string]string{"r1": "Initial import", "r2": "Bump image\n\nbody"},
+		messageErrs: map[string]error{"r2": errors.New("repo unreachable")},
+	}
+	app := argocd.Application{Name: "a", History: []argocd.SyncHistoryEntry{{Revision: "r1"}, {Revision: "r2"}, {Revision: "r3", Message: "already known"}}}
+	hv := newHistoryModel(newStyles(config.Theme{}), c, app, true)
+	hv.setSize(100, 30)
+	if !strings.Contains(hv.View(), "[loading commit messages…]") {
+		t.Fatalf("expected the messages to be loading, got:\n%s", hv.View())
+	}
+
+	hv, _ = hv.Update(hv.initCmd()())
+	body := hv.renderBody()
+	if !strings.Contains(body, "Initial import") || !strings.Contains(body, "already known") {
+		t.Fatalf("expected the fetched and known messages, got:\n%s", body)
+	}
+	if !strings.Contains(hv.View(), "1 of 2 commit messages: repo unreachable; r=retry") {
+		t.Fatalf("expected the failed fetch in the header, got:\n%s", hv.View())
+	}
+
+	delete(c.messageErrs, "r2")
+	hv, cmd := hv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if got := hv.missingMessages(); len(got) != 1 || got[0] != "r2" {
+		t.Fatalf("expected only r2 to be refetched, got %v", got)
+	}
+	hv, _ = hv.Update(cmd())
+	if !strings.Contains(hv.renderBody(), "Bump image") || strings.Contains(hv.View(), "commit messages") {
+		t.Fatalf("expected the retry to fill in r2, got:\n%s", hv.View())
+	}
+}
@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// togglePin pins or unpins an app and persists the pins to the state file.
+// Pinned apps sort to the top of the sidebar within the current sort.
+func (m *Model) togglePin(name string) tea.Cmd {
+	if m.pinned == nil {
+		m.pinned = map[string]bool{}
+	}
+	if m.pinned[name] {
+		delete(m.pinned, name)
+		m.statusLine = "unpinned " + name
+	} else {
+		m.pinned[name] = true
+		m.statusLine = "pinned " + name
+	}
+	m.state.Pinned = pinnedNames(m.pinned)
+	m.applyFilter(true)
+	m.ensureSidebarSelectionVisible()
+	return m.saveStateCmd()
+}
+
+// pinnedNames lists the pins sorted, so the state file stays stable.
+func pinnedNames(pinned map[string]bool) []string {
+	names := make([]string, 0, len(pinned))
+	for n := range pinned {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
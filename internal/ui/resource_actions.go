@@ -0,0 +1,212 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"lazyargo/internal/argocd"
+)
+
+// dangerousResourceActions are built-in Argo CD actions that interrupt or
+// force a rollout; the confirm prompt calls them out explicitly.
+var dangerousResourceActions = map[string]bool{
+	"abort":             true,
+	"promote-full":      true,
+	"skip-current-step": true,
+	"terminate":         true,
+	"delete":            true,
+}
+
+type resourceActionsModel struct {
+	styles styles
+	client argocd.Client
+
+	appName string
+	ref     argocd.ResourceRef
+
+	width  int
+	height int
+	vp     viewport.Model
+
+	loading bool
+	err     error
+	actions []string
+
+	selected   int
+	confirming bool
+	running    bool
+	result     string
+}
+
+type resourceActionsLoadedMsg struct {
+	actions []string
+	err     error
+}
+
+type resourceActionRunMsg struct {
+	action string
+	err    error
+}
+
+func newResourceActionsModel(st styles, c argocd.Client, appName string, ref argocd.ResourceRef) resourceActionsModel {
+	vp := viewport.New(0, 0)
+	vp.MouseWheelEnabled = false
+	return resourceActionsModel{styles: st, client: c, appName: appName, ref: ref, vp: vp, loading: true}
+}
+
+func (m resourceActionsModel) initCmd() tea.Cmd {
+	return func() tea.Msg {
+		actions, err := m.client.ListResourceActions(context.Background(), m.appName, m.ref)
+		return resourceActionsLoadedMsg{actions: actions, err: err}
+	}
+}
+
+func (m resourceActionsModel) runCmd(action string) tea.Cmd {
+	return func() tea.Msg {
+		err := m.client.RunResourceAction(context.Background(), m.appName, m.ref, action)
+		return resourceActionRunMsg{action: action, err: err}
+	}
+}
+
+func (m *resourceActionsModel) setSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.vp.Width = max(1, w)
+	m.vp.Height = max(1, h-2)
+	m.vp.SetContent(m.renderBody())
+}
+
+func (m resourceActionsModel) Update(msg tea.Msg) (resourceActionsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.setSize(msg.Width, msg.Height)
+		return m, nil
+	case resourceActionsLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		m.actions = msg.actions
+		m.selected = 0
+		m.vp.SetContent(m.renderBody())
+		return m, nil
+	case resourceActionRunMsg:
+		m.running = false
+		m.err = msg.err
+		if msg.err == nil {
+			m.result = fmt.Sprintf("ran %q on %s/%s", msg.action, m.ref.Kind, m.ref.Name)
+		}
+		m.vp.SetContent(m.renderBody())
+		return m, nil
+	case tea.KeyMsg:
+		if m.running {
+			return m, nil
+		}
+		if m.confirming {
+			switch msg.String() {
+			case "y":
+				m.confirming = false
+				m.running = true
+				m.err = nil
+				m.result = ""
+				m.vp.SetContent(m.renderBody())
+				return m, m.runCmd(m.actions[m.selected])
+			case "n", "esc":
+				m.confirming = false
+				m.vp.SetContent(m.renderBody())
+			}
+			return m, nil
+		}
+		switch msg.String() {
+		case "up", "k":
+			if m.selected > 0 {
+				m.selected--
+				m.vp.SetContent(m.renderBody())
+			}
+			return m, nil
+		case "down", "j":
+			if m.selected < len(m.actions)-1 {
+				m.selected++
+				m.vp.SetContent(m.renderBody())
+			}
+			return m, nil
+		case "g", "G":
+			m.selected = 0
+			if msg.String() == "G" {
+				m.selected = max(0, len(m.actions)-1)
+			}
+			m.vp.SetContent(m.renderBody())
+			return m, nil
+		case "enter":
+			if len(m.actions) == 0 {
+				return m, nil
+			}
+			m.confirming = true
+			m.result = ""
+			m.vp.SetContent(m.renderBody())
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.vp, cmd = m.vp.Update(msg)
+	return m, cmd
+}
+
+// capturingInput reports whether the overlay is waiting on a confirmation,
+// in which case esc cancels the prompt rather than closing the overlay.
+func (m resourceActionsModel) capturingInput() bool {
+	return m.confirming || m.running
+}
+
+func (m resourceActionsModel) View() string {
+	head := fmt.Sprintf("Actions: %s/%s (%s)  enter=run  esc=close", m.ref.Kind, m.ref.Name, blankIfEmpty(m.ref.Namespace, "cluster"))
+	return lipgloss.JoinVertical(lipgloss.Top, m.styles.OverlayHeader.Width(m.width).Render(head+scrollIndicator(m.vp)), m.vp.View())
+}
+
+func (m resourceActionsModel) renderBody() string {
+	if m.loading {
+		return "Loading…"
+	}
+	if m.err != nil && len(m.actions) == 0 {
+		return "Error:\n\n" + m.err.Error()
+	}
+	if len(m.actions) == 0 {
+		return "(no actions available for this resource)"
+	}
+
+	lines := make([]string, 0, len(m.actions)+6)
+	for i, a := range m.actions {
+		prefix := "  "
+		st := m.styles.StatusValue
+		if i == m.selected {
+			prefix = "▶ "
+			st = m.styles.SidebarSelected
+		}
+		label := a
+		if dangerousResourceActions[a] {
+			label += "  (!)"
+		}
+		lines = append(lines, st.Render(prefix+label))
+	}
+	lines = append(lines, "")
+
+	action := m.actions[m.selected]
+	switch {
+	case m.running:
+		lines = append(lines, fmt.Sprintf("Running %q…", action))
+	case m.confirming:
+		if dangerousResourceActions[action] {
+			lines = append(lines, m.styles.StatusWarn.Render(fmt.Sprintf("%q interrupts the resource's rollout and may not be reversible.", action)))
+		}
+		lines = append(lines, fmt.Sprintf("Run %q on %s/%s in the cluster? y=confirm, n/esc=cancel", action, m.ref.Kind, m.ref.Name))
+	case m.err != nil:
+		lines = append(lines, m.styles.Error.Render("Error: "+m.err.Error()))
+	case m.result != "":
+		lines = append(lines, m.result)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -19,8 +19,13 @@ type resourceDetailsTab int
 const (
 	resourceTabLive resourceDetailsTab = iota
 	resourceTabDesired
+	// resourceTabDiff loads the app's server-side diff the first time it is
+	// shown and keeps this resource's part.
+	resourceTabDiff
 )
 
+var resourceTabNames = map[resourceDetailsTab]string{resourceTabLive: "Live", resourceTabDesired: "Desired", resourceTabDiff: "Diff"}
+
 type resourceDetailsModel struct {
 	styles styles
 	client argocd.Client
@@ -33,14 +38,21 @@ type resourceDetailsModel struct {
 
 	vp viewport.Model
 
-	loading bool
-	err     error
+	load loadState
 
 	liveManifest    string
 	desiredManifest string
 
+	// diffLoad stays idle until the diff tab is first selected.
+	diffLoad loadState
+	diff     *argocd.DiffResult
+
 	tab        resourceDetailsTab
 	showAsJSON bool
+
+	nav   lineNav
+	hs    hScroll
+	query pathQuery
 }
 
 type resourceDetailsLoadedMsg struct {
@@ -49,19 +61,28 @@ type resourceDetailsLoadedMsg struct {
 	err     error
 }
 
+type resourceDiffLoadedMsg struct {
+	ref   argocd.ResourceRef
+	diffs []argocd.DiffResult
+	err   error
+}
+
 func newResourceDetailsModel(styles styles, client argocd.Client, appName string, ref argocd.ResourceRef) resourceDetailsModel {
 	vp := viewport.New(0, 0)
 	vp.MouseWheelEnabled = false
 
-	return resourceDetailsModel{
+	m := resourceDetailsModel{
 		styles:  styles,
 		client:  client,
 		appName: appName,
 		ref:     ref,
 		vp:      vp,
-		loading: true,
 		tab:     resourceTabLive,
+		nav:     newLineNav(styles),
+		query:   newPathQuery(),
 	}
+	m.load.start()
+	return m
 }
 
 func (m resourceDetailsModel) initCmd() tea.Cmd {
@@ -80,6 +101,22 @@ func (m resourceDetailsModel) initCmd() tea.Cmd {
 	}
 }
 
+func (m resourceDetailsModel) diffCmd() tea.Cmd {
+	return func() tea.Msg {
+		d, err := m.client.ServerSideDiff(context.Background(), m.appName)
+		return resourceDiffLoadedMsg{ref: m.ref, diffs: d, err: err}
+	}
+}
+
+// activeLoad is the load behind the current tab: the diff's own, or the
+// manifests' shared by Live and Desired.
+func (m *resourceDetailsModel) activeLoad() *loadState {
+	if m.tab == resourceTabDiff {
+		return &m.diffLoad
+	}
+	return &m.load
+}
+
 func (m *resourceDetailsModel) setSize(w, h int) {
 	m.width = w
 	m.height = h
@@ -87,7 +124,7 @@ func (m *resourceDetailsModel) setSize(w, h int) {
 	innerH := max(1, h-2)
 	m.vp.Width = max(1, w)
 	m.vp.Height = innerH
-	m.vp.SetContent(m.renderBody())
+	m.refresh()
 }
 
 func (m resourceDetailsModel) Update(msg tea.Msg) (resourceDetailsModel, tea.Cmd) {
@@ -96,32 +133,81 @@ func (m resourceDetailsModel) Update(msg tea.Msg) (resourceDetailsModel, tea.Cmd
 		m.setSize(msg.Width, msg.Height)
 		return m, nil
 	case resourceDetailsLoadedMsg:
-		m.loading = false
-		m.err = msg.err
+		m.load.finish(msg.err)
 		m.liveManifest = msg.live
 		m.desiredManifest = msg.desired
-		m.vp.SetContent(m.renderBody())
+		m.refresh()
+		return m, nil
+	case resourceDiffLoadedMsg:
+		if msg.ref != m.ref {
+			return m, nil
+		}
+		m.diffLoad.finish(msg.err)
+		m.diff = nil
+		for _, d := range msg.diffs {
+			if diffMatchesRef(d, m.ref) {
+				d := d
+				m.diff = &d
+				break
+			}
+		}
+		m.refresh()
 		return m, nil
 	case tea.KeyMsg:
+		// The path query narrows Live and Desired; the go-to-line prompt
+		// keeps its keys while it is open.
+		if m.tab != resourceTabDiff && !m.nav.capturingInput() {
+			if handled, redraw, cmd := m.query.update(msg); handled {
+				if redraw {
+					m.vp.GotoTop()
+					m.refresh()
+				}
+				return m, cmd
+			}
+		}
+		if handled, redraw, cmd := m.nav.update(msg, &m.vp); handled {
+			if redraw {
+				m.refresh()
+			}
+			return m, cmd
+		}
+		if handled, retry := m.activeLoad().handleKey(msg); handled {
+			m.refresh()
+			if retry && m.tab == resourceTabDiff {
+				return m, m.diffCmd()
+			}
+			if retry {
+				return m, m.initCmd()
+			}
+			return m, nil
+		}
+		if m.hs.update(msg) {
+			m.refresh()
+			return m, nil
+		}
+		if scrollEnds(msg, &m.vp) {
+			return m, nil
+		}
 		switch msg.String() {
 		case "esc", "q":
 			// parent handles close
 			return m, nil
 		case "tab":
-			if m.tab == resourceTabLive {
-				m.tab = resourceTabDesired
-			} else {
-				m.tab = resourceTabLive
+			m.tab = (m.tab + 1) % resourceDetailsTab(len(resourceTabNames))
+			var cmd tea.Cmd
+			if m.tab == resourceTabDiff && m.diffLoad.phase == loadIdle {
+				m.diffLoad.start()
+				cmd = m.diffCmd()
 			}
-			m.vp.SetContent(m.renderBody())
-			return m, nil
+			m.refresh()
+			return m, cmd
 		case "j", "down", "k", "up", "pgdown", "pgup":
 			var cmd tea.Cmd
 			m.vp, cmd = m.vp.Update(msg)
 			return m, cmd
 		case "t":
 			m.showAsJSON = !m.showAsJSON
-			m.vp.SetContent(m.renderBody())
+			m.refresh()
 			return m, nil
 		}
 	}
@@ -132,51 +218,109 @@ func (m resourceDetailsModel) Update(msg tea.Msg) (resourceDetailsModel, tea.Cmd
 }
 
 func (m resourceDetailsModel) View() string {
-	header := fmt.Sprintf("Resource: %s/%s (%s)  [tab=%s]  [t=%s]  esc=close",
+	header := fmt.Sprintf("Resource: %s/%s (%s)%s  [tab=%s]  [t=%s]  %s  %s  %s  esc=close",
 		m.ref.Kind,
 		m.ref.Name,
 		blankIfEmpty(m.ref.Namespace, "cluster"),
-		map[resourceDetailsTab]string{resourceTabLive: "Live", resourceTabDesired: "Desired"}[m.tab],
+		m.activeLoad().headerTag(),
+		resourceTabNames[m.tab],
 		map[bool]string{false: "yaml", true: "json"}[m.showAsJSON],
+		m.query.hint(),
+		m.nav.hint(),
+		m.hs.hint(),
 	)
 
-	headStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Padding(0, 1)
 	body := m.vp.View()
-	return lipgloss.JoinVertical(lipgloss.Top, headStyle.Width(m.width).Render(header), body)
+	return lipgloss.JoinVertical(lipgloss.Top, m.styles.OverlayHeader.Width(m.width).Render(header+scrollIndicator(m.vp)), body)
 }
 
 func (m resourceDetailsModel) renderBody() string {
-	if m.loading {
-		return "Loading…"
+	if body, ok := m.activeLoad().body(); ok {
+		return body
 	}
-	if m.err != nil {
-		return "Error:\n\n" + m.err.Error()
+
+	if m.tab == resourceTabDiff {
+		switch {
+		case m.diff == nil:
+			return "(no diff for this resource)"
+		case !m.diff.Modified:
+			return "(in sync: live matches desired)"
+		}
+		return renderUnifiedDiff(m.diff.Diff, false, m.styles)
 	}
 
 	var s string
 	if m.tab == resourceTabLive {
 		s = m.liveManifest
 		if strings.TrimSpace(s) == "" {
-			s = "(empty live manifest)"
+			return "(empty live manifest)"
 		}
 	} else {
 		s = m.desiredManifest
 		if strings.TrimSpace(s) == "" {
-			s = "(desired manifest not found via /manifests)"
+			return "(desired manifest not found via /manifests)"
 		}
 	}
 
+	if m.query.path != "" {
+		return m.renderQuery(s)
+	}
+
 	if m.showAsJSON {
 		// Best-effort YAML->JSON; if it fails, show original.
 		var obj any
 		if err := yaml.Unmarshal([]byte(s), &obj); err == nil {
 			b, err := json.MarshalIndent(obj, "", "  ")
 			if err == nil {
-				return string(b)
+				return highlightJSON(string(b), m.styles.Syntax)
 			}
 		}
 	}
-	return s
+	return highlightYAML(s, m.styles.Syntax)
+}
+
+// renderQuery shows only the value at the query path, or why the path does
+// not resolve.
+func (m resourceDetailsModel) renderQuery(manifest string) string {
+	var obj any
+	if err := yaml.Unmarshal([]byte(manifest), &obj); err != nil {
+		return m.styles.Error.Render("Cannot parse the manifest: " + err.Error())
+	}
+	v, err := queryManifest(obj, m.query.path)
+	if err != nil {
+		return m.styles.Error.Render("." + m.query.path + ": " + err.Error())
+	}
+	if m.showAsJSON {
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return m.styles.Error.Render(err.Error())
+		}
+		return highlightJSON(string(b), m.styles.Syntax)
+	}
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return m.styles.Error.Render(err.Error())
+	}
+	return highlightYAML(strings.TrimSuffix(string(b), "\n"), m.styles.Syntax)
+}
+
+// refresh re-renders the viewport: the manifest is cut to the horizontal
+// scroll window first, then the line-number gutter goes in front.
+func (m *resourceDetailsModel) refresh() {
+	body := m.renderBody()
+	if _, placeholder := m.activeLoad().body(); placeholder {
+		m.vp.SetContent(body)
+		return
+	}
+	width := m.vp.Width - m.nav.gutterWidth(body)
+	m.hs.fit(body, width)
+	m.vp.SetContent(m.nav.decorate(m.hs.apply(body, width)))
+}
+
+// capturingInput reports whether the overlay is reading text input, in which
+// case the parent must not treat esc/q as "close".
+func (m resourceDetailsModel) capturingInput() bool {
+	return m.nav.capturingInput() || m.query.capturingInput()
 }
 
 func findDesiredManifest(manifests []string, ref argocd.ResourceRef) string {
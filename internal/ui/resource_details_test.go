@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"lazyargo/internal/argocd"
+	"lazyargo/internal/config"
+)
+
+func TestResourceDetails_diffTabLoadsLazily(t *testing.T) {
+	ref := argocd.ResourceRef{Group: "apps", Kind: "Deployment", Name: "web", Namespace: "prod", Version: "v1"}
+	client := &fakeClient{diffs: []argocd.DiffResult{
+		{Ref: argocd.ResourceRef{Kind: "Service", Name: "web", Namespace: "prod"}, Diff: "-port: 80\n+port: 8080", Modified: true},
+		{Ref: argocd.ResourceRef{Group: "apps", Kind: "Deployment", Name: "web", Namespace: "prod"}, Diff: "-replicas: 2\n+replicas: 3", Modified: true},
+	}}
+	m := newResourceDetailsModel(newStyles(config.Theme{}), client, "guestbook", ref)
+	m.setSize(80, 20)
+	m, _ = m.Update(resourceDetailsLoadedMsg{live: "kind: Deployment", desired: "kind: Deployment"})
+
+	tab := func() tea.Cmd {
+		var cmd tea.Cmd
+		m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+		return cmd
+	}
+	if cmd := tab(); cmd != nil || m.tab != resourceTabDesired {
+		t.Fatalf("expected Desired without a load")
+	}
+	cmd := tab()
+	if m.tab != resourceTabDiff || cmd == nil || !strings.Contains(m.View(), "[loading…]") {
+		t.Fatalf("expected the diff tab to start loading")
+	}
+	m, _ = m.Update(cmd())
+	body := m.renderBody()
+	if !strings.Contains(body, "replicas: 3") || strings.Contains(body, "port: 8080") {
+		t.Fatalf("expected only this resource's diff, got %q", body)
+	}
+
+	// Cycling back to the diff reuses the loaded result.
+	tab()
+	tab()
+	if cmd := tab(); cmd != nil || m.tab != resourceTabDiff || client.diffCalls != 1 {
+		t.Fatalf("diff loaded %d times", client.diffCalls)
+	}
+
+	// A diff for another resource's overlay is ignored.
+	m, _ = m.Update(resourceDiffLoadedMsg{ref: argocd.ResourceRef{Kind: "Service", Name: "web"}})
+	if !strings.Contains(m.renderBody(), "replicas: 3") {
+		t.Fatalf("a stale diff replaced this one")
+	}
+}
+
+func TestResourceDetails_pathQuery(t *testing.T) {
+	m := newResourceDetailsModel(newStyles(config.Theme{}), &fakeClient{}, "guestbook", argocd.ResourceRef{Kind: "Deployment", Name: "web"})
+	m.setSize(100, 20)
+	live := "kind: Deployment\nspec:\n  replicas: 3\n  template:\n    spec:\n      containers:\n      - image: web:1.2\n"
+	m, _ = m.Update(resourceDetailsLoadedMsg{live: live, desired: live})
+
+	query := func(path string) {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'.'}})
+		if !m.capturingInput() {
+			t.Fatalf("expected . to open the path prompt")
+		}
+		m.query.input.SetValue(path)
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+
+	query("spec.template.spec.containers[*].image")
+	body := m.renderBody()
+	if !strings.Contains(body, "web:1.2") || strings.Contains(body, "replicas") {
+		t.Fatalf("expected only the image, got %q", body)
+	}
+	if !strings.Contains(m.View(), "[.spec.template.spec.containers[*].image]") {
+		t.Fatalf("expected the header to show the path")
+	}
+
+	query("spec.replica")
+	if body := m.renderBody(); !strings.Contains(body, `no field "replica" at .spec (fields: replicas, template)`) {
+		t.Fatalf("expected a helpful error, got %q", body)
+	}
+
+	query("")
+	if body := m.renderBody(); !strings.Contains(body, "replicas") || !strings.Contains(body, "kind") {
+		t.Fatalf("expected an empty path to show the whole manifest, got %q", body)
+	}
+}
@@ -23,8 +23,7 @@ type revisionDetailsModel struct {
 	height int
 	vp     viewport.Model
 
-	loading bool
-	err     error
+	load loadState
 
 	meta  argocd.RevisionMeta
 	chart argocd.ChartMeta
@@ -39,7 +38,9 @@ type revisionDetailsLoadedMsg struct {
 func newRevisionDetailsModel(st styles, c argocd.Client, appName, revision string) revisionDetailsModel {
 	vp := viewport.New(0, 0)
 	vp.MouseWheelEnabled = false
-	return revisionDetailsModel{styles: st, client: c, appName: appName, revision: revision, vp: vp, loading: true}
+	m := revisionDetailsModel{styles: st, client: c, appName: appName, revision: revision, vp: vp}
+	m.load.start()
+	return m
 }
 
 func (m revisionDetailsModel) initCmd() tea.Cmd {
@@ -67,8 +68,7 @@ func (m *revisionDetailsModel) setSize(w, h int) {
 func (m revisionDetailsModel) Update(msg tea.Msg) (revisionDetailsModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case revisionDetailsLoadedMsg:
-		m.loading = false
-		m.err = msg.err
+		m.load.finish(msg.err)
 		m.meta = msg.meta
 		m.chart = msg.chart
 		m.vp.SetContent(m.renderBody())
@@ -77,6 +77,16 @@ func (m revisionDetailsModel) Update(msg tea.Msg) (revisionDetailsModel, tea.Cmd
 		m.setSize(msg.Width, msg.Height)
 		return m, nil
 	case tea.KeyMsg:
+		if handled, retry := m.load.handleKey(msg); handled {
+			m.vp.SetContent(m.renderBody())
+			if retry {
+				return m, m.initCmd()
+			}
+			return m, nil
+		}
+		if scrollEnds(msg, &m.vp) {
+			return m, nil
+		}
 		var cmd tea.Cmd
 		m.vp, cmd = m.vp.Update(msg)
 		return m, cmd
@@ -88,17 +98,13 @@ func (m revisionDetailsModel) Update(msg tea.Msg) (revisionDetailsModel, tea.Cmd
 }
 
 func (m revisionDetailsModel) View() string {
-	head := fmt.Sprintf("Revision: %s  esc=close", m.revision)
-	headStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57")).Padding(0, 1)
-	return lipgloss.JoinVertical(lipgloss.Top, headStyle.Width(m.width).Render(head), m.vp.View())
+	head := fmt.Sprintf("Revision: %s%s  esc=close", m.revision, m.load.headerTag())
+	return lipgloss.JoinVertical(lipgloss.Top, m.styles.OverlayHeader.Width(m.width).Render(head+scrollIndicator(m.vp)), m.vp.View())
 }
 
 func (m revisionDetailsModel) renderBody() string {
-	if m.loading {
-		return "Loading…"
-	}
-	if m.err != nil {
-		return "Error:\n\n" + m.err.Error()
+	if body, ok := m.load.body(); ok {
+		return body
 	}
 
 	lines := []string{
@@ -0,0 +1,223 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"sigs.k8s.io/yaml"
+
+	"lazyargo/internal/argocd"
+)
+
+// revisionDiffContext is the number of unchanged lines kept around each
+// change in the manifest diff.
+const revisionDiffContext = 3
+
+// revisionDiffModel compares two history revisions: the commit metadata of
+// both, then a diff of the manifests rendered at each.
+type revisionDiffModel struct {
+	styles styles
+	client argocd.Client
+
+	appName string
+	from    string
+	to      string
+
+	width  int
+	height int
+	vp     viewport.Model
+
+	load loadState
+	cmp  argocd.RevisionComparison
+
+	showWhitespace bool
+	hs             hScroll
+}
+
+type revisionDiffLoadedMsg struct {
+	cmp argocd.RevisionComparison
+	err error
+}
+
+func newRevisionDiffModel(st styles, c argocd.Client, appName, from, to string) revisionDiffModel {
+	vp := viewport.New(0, 0)
+	vp.MouseWheelEnabled = false
+	m := revisionDiffModel{styles: st, client: c, appName: appName, from: from, to: to, vp: vp}
+	m.load.start()
+	return m
+}
+
+func (m revisionDiffModel) initCmd() tea.Cmd {
+	return func() tea.Msg {
+		cmp, err := m.client.RevisionsDiff(context.Background(), m.appName, m.from, m.to)
+		return revisionDiffLoadedMsg{cmp: cmp, err: err}
+	}
+}
+
+func (m *revisionDiffModel) setSize(w, h int) {
+	m.width = w
+	m.height = h
+	m.vp.Width = max(1, w)
+	m.vp.Height = max(1, h-2)
+	m.refresh()
+}
+
+func (m revisionDiffModel) Update(msg tea.Msg) (revisionDiffModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case revisionDiffLoadedMsg:
+		m.load.finish(msg.err)
+		m.cmp = msg.cmp
+		m.refresh()
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.setSize(msg.Width, msg.Height)
+		return m, nil
+	case tea.KeyMsg:
+		if handled, retry := m.load.handleKey(msg); handled {
+			m.refresh()
+			if retry {
+				return m, m.initCmd()
+			}
+			return m, nil
+		}
+		if m.hs.update(msg) {
+			m.refresh()
+			return m, nil
+		}
+		if scrollEnds(msg, &m.vp) {
+			return m, nil
+		}
+		if msg.String() == "W" {
+			m.showWhitespace = !m.showWhitespace
+			m.refresh()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.vp, cmd = m.vp.Update(msg)
+		return m, cmd
+	}
+
+	var cmd tea.Cmd
+	m.vp, cmd = m.vp.Update(msg)
+	return m, cmd
+}
+
+func (m revisionDiffModel) View() string {
+	head := fmt.Sprintf("Revisions: %s..%s%s  W=whitespace  %s  esc=close",
+		shortRevision(m.from), shortRevision(m.to), m.load.headerTag(), m.hs.hint())
+	return lipgloss.JoinVertical(lipgloss.Top, m.styles.OverlayHeader.Width(m.width).Render(head+scrollIndicator(m.vp)), m.vp.View())
+}
+
+func (m *revisionDiffModel) refresh() {
+	body := m.renderBody()
+	m.hs.fit(body, m.vp.Width)
+	m.vp.SetContent(m.hs.apply(body, m.vp.Width))
+}
+
+func (m revisionDiffModel) renderBody() string {
+	if body, ok := m.load.body(); ok {
+		return body
+	}
+
+	meta := func(label, rev string, r argocd.RevisionMeta) []string {
+		msg, _, _ := strings.Cut(strings.TrimSpace(r.Message), "\n")
+		return []string{
+			m.styles.StatusValue.Render(label + rev),
+			"  " + blankIfEmpty(strings.TrimSpace(r.Author), "—") + "  " + blankIfEmpty(strings.TrimSpace(r.Date), "—"),
+			"  " + blankIfEmpty(msg, "—"),
+		}
+	}
+	lines := meta("From: ", m.from, m.cmp.From)
+	lines = append(lines, meta("To:   ", m.to, m.cmp.To)...)
+	lines = append(lines, "", "Manifests:")
+
+	switch {
+	case m.cmp.ManifestsErr != "":
+		lines = append(lines, "(manifest diff not available: "+m.cmp.ManifestsErr+")")
+	default:
+		d := lineDiff(joinManifests(m.cmp.FromManifests), joinManifests(m.cmp.ToManifests), m.from, m.to, revisionDiffContext)
+		if d == "" {
+			lines = append(lines, "(no manifest changes)")
+		} else {
+			lines = append(lines, renderUnifiedDiff(d, m.showWhitespace, m.styles))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// rollbackPreviewMaxLines caps the manifest diff shown in the rollback
+// modal; the history diff (d) shows it in full.
+const rollbackPreviewMaxLines = 12
+
+// rollbackPreviewLines summarizes what rolling back to the selected revision
+// changes: its commit, then the start of the manifest diff from the
+// deployed revision.
+func (m Model) rollbackPreviewLines() []string {
+	rev := m.rollbackRevs[m.rollbackSelected]
+	author, date, msg := rev.Author, rev.Date, rev.Message
+	if p := m.rollbackPreview; p != nil && (p.To.Author != "" || p.To.Message != "") {
+		author, date, msg = p.To.Author, p.To.Date, p.To.Message
+	}
+	msg, _, _ = strings.Cut(strings.TrimSpace(msg), "\n")
+	lines := []string{
+		m.styles.StatusValue.Render("Rolling back to " + shortRevision(rev.Revision)),
+		"  " + blankIfEmpty(strings.TrimSpace(author), "—") + "  " + blankIfEmpty(strings.TrimSpace(date), "—"),
+		"  " + blankIfEmpty(msg, "—"),
+	}
+
+	from := m.rollbackRevs[0].Revision
+	switch {
+	case from == rev.Revision:
+		return append(lines, "  Already the deployed revision: no manifest changes.")
+	case m.rollbackPreviewLoading:
+		return append(lines, "  Loading manifest changes…")
+	case m.rollbackPreviewErr != nil:
+		return append(lines, "  (manifest diff not available: "+m.rollbackPreviewErr.Error()+")")
+	case m.rollbackPreview == nil:
+		return lines
+	case m.rollbackPreview.ManifestsErr != "":
+		return append(lines, "  (manifest diff not available: "+m.rollbackPreview.ManifestsErr+")")
+	}
+	d := lineDiff(joinManifests(m.rollbackPreview.FromManifests), joinManifests(m.rollbackPreview.ToManifests), "deployed "+shortRevision(from), shortRevision(rev.Revision), 1)
+	if d == "" {
+		return append(lines, "  No manifest changes.")
+	}
+	added, removed := diffLineCounts(d)
+	lines = append(lines, fmt.Sprintf("Manifest changes: +%d −%d lines", added, removed))
+	diffLines := strings.Split(d, "\n")[2:] // drop the ---/+++ header
+	more := len(diffLines) - rollbackPreviewMaxLines
+	if more > 0 {
+		diffLines = diffLines[:rollbackPreviewMaxLines]
+	}
+	lines = append(lines, renderUnifiedDiff(strings.Join(diffLines, "\n"), false, m.styles))
+	if more > 0 {
+		lines = append(lines, fmt.Sprintf("  … %d more lines (compare revisions in full from history: h)", more))
+	}
+	return lines
+}
+
+// joinManifests renders manifests as one YAML stream. The API returns them
+// as JSON; YAML diffs line by line far more readably.
+func joinManifests(manifests []string) string {
+	docs := make([]string, 0, len(manifests))
+	for _, man := range manifests {
+		if y, err := yaml.JSONToYAML([]byte(man)); err == nil {
+			man = string(y)
+		}
+		docs = append(docs, strings.TrimSuffix(man, "\n"))
+	}
+	return strings.Join(docs, "\n---\n")
+}
+
+// shortRevision abbreviates a git SHA for headers; other revisions (tags,
+// chart versions) are short already.
+func shortRevision(rev string) string {
+	if len(rev) == 40 {
+		return rev[:8]
+	}
+	return rev
+}
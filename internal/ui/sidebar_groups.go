@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	"lazyargo/internal/argocd"
+)
+
+type groupMode int
+
+const (
+	groupNone groupMode = iota
+	groupByProject
+	groupByLabel
+)
+
+func (g groupMode) String() string {
+	switch g {
+	case groupByProject:
+		return "project"
+	case groupByLabel:
+		return "label"
+	default:
+		return "none"
+	}
+}
+
+// sidebarRow is one line of the grouped sidebar: a group header or an app
+// (an index into m.apps).
+type sidebarRow struct {
+	header    bool
+	group     string
+	count     int
+	collapsed bool
+	app       int
+}
+
+// nextGroupMode cycles flat → project → label → flat, skipping label
+// grouping when no ui.groupLabel is configured.
+func (m Model) nextGroupMode() groupMode {
+	next := (m.groupMode + 1) % 3
+	if next == groupByLabel && m.cfg.UI.GroupLabel == "" {
+		next = groupNone
+	}
+	return next
+}
+
+func (m Model) appGroup(a argocd.Application) string {
+	switch m.groupMode {
+	case groupByProject:
+		return blankIfEmpty(a.Project, "(no project)")
+	case groupByLabel:
+		if v, ok := a.Labels[m.cfg.UI.GroupLabel]; ok {
+			return v
+		}
+		return "(no " + m.cfg.UI.GroupLabel + ")"
+	default:
+		return ""
+	}
+}
+
+// groupApps orders m.apps by group (keeping the current order within each
+// group), drops apps in collapsed groups and precomputes the sidebar rows so
+// rendering stays windowed. In flat mode the rows are cleared.
+func (m *Model) groupApps() {
+	m.sidebarRows = nil
+	m.appRow = nil
+	if m.groupMode == groupNone {
+		return
+	}
+
+	sort.SliceStable(m.apps, func(i, j int) bool {
+		return m.appGroup(m.apps[i]) < m.appGroup(m.apps[j])
+	})
+
+	visible := make([]argocd.Application, 0, len(m.apps))
+	rows := make([]sidebarRow, 0, len(m.apps)+8)
+	appRow := make([]int, 0, len(m.apps))
+	for i := 0; i < len(m.apps); {
+		g := m.appGroup(m.apps[i])
+		j := i
+		for j < len(m.apps) && m.appGroup(m.apps[j]) == g {
+			j++
+		}
+		collapsed := m.groupCollapsed[g]
+		rows = append(rows, sidebarRow{header: true, group: g, count: j - i, collapsed: collapsed})
+		if !collapsed {
+			for _, a := range m.apps[i:j] {
+				appRow = append(appRow, len(rows))
+				rows = append(rows, sidebarRow{group: g, app: len(visible)})
+				visible = append(visible, a)
+			}
+		}
+		i = j
+	}
+	m.apps = visible
+	m.sidebarRows = rows
+	m.appRow = appRow
+}
+
+// sidebarPosition returns the selected row and the total row count, in
+// sidebar lines (group headers included).
+func (m Model) sidebarPosition() (sel, total int) {
+	if m.sidebarRows == nil {
+		return m.selected, len(m.apps)
+	}
+	if m.selected < len(m.appRow) {
+		sel = m.appRow[m.selected]
+	}
+	return sel, len(m.sidebarRows)
+}
+
+// toggleGroupCollapse collapses or expands the selected app's group.
+func (m *Model) toggleGroupCollapse() {
+	if m.groupMode == groupNone || len(m.apps) == 0 {
+		return
+	}
+	g := m.appGroup(m.apps[m.selected])
+	if m.groupCollapsed == nil {
+		m.groupCollapsed = map[string]bool{}
+	}
+	m.groupCollapsed[g] = !m.groupCollapsed[g]
+	m.applyFilter(true)
+	m.ensureSidebarSelectionVisible()
+	m.statusLine = fmt.Sprintf("collapsed %s", g)
+	if !m.groupCollapsed[g] {
+		m.statusLine = fmt.Sprintf("expanded %s", g)
+	}
+}
+
+func (m Model) renderGroupHeader(r sidebarRow) string {
+	arrow := "▾"
+	if r.collapsed {
+		arrow = "▸"
+	}
+	return m.styles.SidebarTitle.Render(fmt.Sprintf("%s %s (%d)", arrow, r.group, r.count))
+}
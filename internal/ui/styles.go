@@ -1,6 +1,10 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"lazyargo/internal/config"
+)
 
 type styles struct {
 	App             lipgloss.Style
@@ -16,49 +20,206 @@ type styles struct {
 	StatusWarn      lipgloss.Style
 	HelpBar         lipgloss.Style
 	Error           lipgloss.Style
+	Success         lipgloss.Style
+	// OverlayHeader is the title bar of full-panel overlays (events, logs, diff, ...).
+	OverlayHeader lipgloss.Style
+	// FilterMatch marks the characters of a sidebar item that matched the filter.
+	FilterMatch lipgloss.Style
+	// Hook dims hook resources in the resource tree; Faint is for secondary
+	// details such as a resource's age.
+	Hook  lipgloss.Style
+	Faint lipgloss.Style
+	// Gutter is the line-number gutter of the manifest viewer.
+	Gutter lipgloss.Style
+	Syntax syntaxStyles
+}
+
+// syntaxStyles color manifest tokens in the YAML/JSON viewer.
+type syntaxStyles struct {
+	Key, String, Number, Bool, Comment, Punct lipgloss.Style
+}
+
+// palette is the set of colors a theme preset defines. Only the roles exposed
+// in config.Theme can be overridden; the rest follow the preset.
+type palette struct {
+	headerFG, headerBG     string
+	selectedFG, selectedBG string
+	warn, err, success     string
+
+	title, border, muted string
+	statusFG, statusBG   string
+	label, value         string
+
+	hook, gutter                      string
+	hlKey, hlString, hlNumber, hlBool string
+	hlComment, hlPunct                string
+}
+
+var themePresets = map[string]palette{
+	"dark": {
+		headerFG: "229", headerBG: "62",
+		selectedFG: "229", selectedBG: "57",
+		warn: "203", err: "196", success: "42",
+		title: "205", border: "240", muted: "241",
+		statusFG: "254", statusBG: "236",
+		label: "250", value: "229",
+		hook: "244", gutter: "241",
+		hlKey: "81", hlString: "150", hlNumber: "215", hlBool: "141",
+		hlComment: "241", hlPunct: "245",
+	},
+	"light": {
+		headerFG: "255", headerBG: "25",
+		selectedFG: "255", selectedBG: "31",
+		warn: "166", err: "160", success: "28",
+		title: "90", border: "246", muted: "243",
+		statusFG: "235", statusBG: "253",
+		label: "238", value: "24",
+		hook: "243", gutter: "245",
+		hlKey: "25", hlString: "28", hlNumber: "130", hlBool: "90",
+		hlComment: "245", hlPunct: "242",
+	},
+}
+
+// resolvePalette picks the named preset (dark if unknown) and applies overrides.
+func resolvePalette(name string, t config.Theme) palette {
+	p, ok := themePresets[name]
+	if !ok {
+		p = themePresets["dark"]
+	}
+	override := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	override(&p.headerFG, t.Header.FG)
+	override(&p.headerBG, t.Header.BG)
+	override(&p.selectedFG, t.Selected.FG)
+	override(&p.selectedBG, t.Selected.BG)
+	override(&p.warn, t.Warn)
+	override(&p.err, t.Error)
+	override(&p.success, t.Success)
+	override(&p.hook, t.Hook)
+	override(&p.gutter, t.Gutter)
+	override(&p.hlKey, t.Syntax.Key)
+	override(&p.hlString, t.Syntax.String)
+	override(&p.hlNumber, t.Syntax.Number)
+	override(&p.hlBool, t.Syntax.Bool)
+	override(&p.hlComment, t.Syntax.Comment)
+	override(&p.hlPunct, t.Syntax.Punct)
+	return p
 }
 
-func newStyles() styles {
+// themeColors is a resolved palette, ready for lipgloss.
+type themeColors struct {
+	headerFG, headerBG     lipgloss.TerminalColor
+	selectedFG, selectedBG lipgloss.TerminalColor
+	warn, err, success     lipgloss.TerminalColor
+
+	title, border, muted lipgloss.TerminalColor
+	statusFG, statusBG   lipgloss.TerminalColor
+	label, value         lipgloss.TerminalColor
+
+	hook, gutter                      lipgloss.TerminalColor
+	hlKey, hlString, hlNumber, hlBool lipgloss.TerminalColor
+	hlComment, hlPunct                lipgloss.TerminalColor
+}
+
+// resolveColors turns the theme into concrete colors. With no preset name
+// ("" or "auto") every role is an AdaptiveColor over the dark and light
+// presets, so lipgloss picks whichever suits the terminal background;
+// overrides apply to both.
+func resolveColors(t config.Theme) themeColors {
+	adaptive := t.Name == "" || t.Name == "auto"
+	var dark, light palette
+	if adaptive {
+		dark, light = resolvePalette("dark", t), resolvePalette("light", t)
+	} else {
+		dark = resolvePalette(t.Name, t)
+		light = dark
+	}
+	col := func(d, l string) lipgloss.TerminalColor {
+		if !adaptive {
+			return lipgloss.Color(d)
+		}
+		return lipgloss.AdaptiveColor{Light: l, Dark: d}
+	}
+	return themeColors{
+		headerFG: col(dark.headerFG, light.headerFG), headerBG: col(dark.headerBG, light.headerBG),
+		selectedFG: col(dark.selectedFG, light.selectedFG), selectedBG: col(dark.selectedBG, light.selectedBG),
+		warn: col(dark.warn, light.warn), err: col(dark.err, light.err), success: col(dark.success, light.success),
+		title: col(dark.title, light.title), border: col(dark.border, light.border), muted: col(dark.muted, light.muted),
+		statusFG: col(dark.statusFG, light.statusFG), statusBG: col(dark.statusBG, light.statusBG),
+		label: col(dark.label, light.label), value: col(dark.value, light.value),
+		hook: col(dark.hook, light.hook), gutter: col(dark.gutter, light.gutter),
+		hlKey: col(dark.hlKey, light.hlKey), hlString: col(dark.hlString, light.hlString),
+		hlNumber: col(dark.hlNumber, light.hlNumber), hlBool: col(dark.hlBool, light.hlBool),
+		hlComment: col(dark.hlComment, light.hlComment), hlPunct: col(dark.hlPunct, light.hlPunct),
+	}
+}
+
+func newStyles(theme config.Theme) styles {
 	border := lipgloss.RoundedBorder()
+	tc := resolveColors(theme)
 
 	return styles{
 		App: lipgloss.NewStyle(),
 		Header: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("229")).
-			Background(lipgloss.Color("62")).
+			Foreground(tc.headerFG).
+			Background(tc.headerBG).
 			Padding(0, 1),
 		Sidebar: lipgloss.NewStyle().
 			Border(border).
-			BorderForeground(lipgloss.Color("240")).
+			BorderForeground(tc.border).
 			Padding(0, 1),
 		SidebarTitle: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("205")),
+			Foreground(tc.title),
 		SidebarItem: lipgloss.NewStyle(),
 		SidebarSelected: lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("229")).
-			Background(lipgloss.Color("57")),
+			Foreground(tc.selectedFG).
+			Background(tc.selectedBG),
 		Main: lipgloss.NewStyle().
 			Border(border).
-			BorderForeground(lipgloss.Color("240")).
+			BorderForeground(tc.border).
 			Padding(0, 1),
 		StatusBar: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("254")).
-			Background(lipgloss.Color("236")).
+			Foreground(tc.statusFG).
+			Background(tc.statusBG).
 			Padding(0, 1),
 		StatusLabel: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("250")).
+			Foreground(tc.label).
 			Bold(true),
 		StatusValue: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("229")),
+			Foreground(tc.value),
 		StatusWarn: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("203")).
+			Foreground(tc.warn).
 			Bold(true),
 		HelpBar: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
+			Foreground(tc.muted).
 			Padding(0, 1),
-		Error: lipgloss.NewStyle().Foreground(lipgloss.Color("196")),
+		Error:   lipgloss.NewStyle().Foreground(tc.err),
+		Success: lipgloss.NewStyle().Foreground(tc.success),
+		OverlayHeader: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(tc.selectedFG).
+			Background(tc.selectedBG).
+			Padding(0, 1),
+		FilterMatch: lipgloss.NewStyle().
+			Bold(true).
+			Underline(true).
+			Foreground(tc.title),
+		Hook:   lipgloss.NewStyle().Foreground(tc.hook).Italic(true),
+		Faint:  lipgloss.NewStyle().Foreground(tc.hook),
+		Gutter: lipgloss.NewStyle().Foreground(tc.gutter),
+		Syntax: syntaxStyles{
+			Key:     lipgloss.NewStyle().Foreground(tc.hlKey),
+			String:  lipgloss.NewStyle().Foreground(tc.hlString),
+			Number:  lipgloss.NewStyle().Foreground(tc.hlNumber),
+			Bool:    lipgloss.NewStyle().Foreground(tc.hlBool),
+			Comment: lipgloss.NewStyle().Foreground(tc.hlComment),
+			Punct:   lipgloss.NewStyle().Foreground(tc.hlPunct),
+		},
 	}
 }
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"lazyargo/internal/config"
+)
+
+func TestResolveColors(t *testing.T) {
+	auto := resolveColors(config.Theme{Warn: "1"})
+	ac, ok := auto.headerBG.(lipgloss.AdaptiveColor)
+	if !ok || ac.Dark != themePresets["dark"].headerBG || ac.Light != themePresets["light"].headerBG {
+		t.Fatalf("expected adaptive header bg, got %#v", auto.headerBG)
+	}
+	if w, ok := auto.warn.(lipgloss.AdaptiveColor); !ok || w.Dark != "1" || w.Light != "1" {
+		t.Fatalf("expected override to apply to both variants, got %#v", auto.warn)
+	}
+
+	light := resolveColors(config.Theme{Name: "light"})
+	if c, ok := light.headerBG.(lipgloss.Color); !ok || string(c) != themePresets["light"].headerBG {
+		t.Fatalf("expected fixed light header bg, got %#v", light.headerBG)
+	}
+}
+
+func TestResolveColors_syntaxFollowsTheme(t *testing.T) {
+	auto := resolveColors(config.Theme{})
+	for name, c := range map[string]lipgloss.TerminalColor{"gutter": auto.gutter, "hook": auto.hook, "key": auto.hlKey, "string": auto.hlString, "comment": auto.hlComment} {
+		if _, ok := c.(lipgloss.AdaptiveColor); !ok {
+			t.Fatalf("expected an adaptive %s color, got %#v", name, c)
+		}
+	}
+	light := resolveColors(config.Theme{Name: "light"})
+	if c, ok := light.hlKey.(lipgloss.Color); !ok || string(c) != themePresets["light"].hlKey {
+		t.Fatalf("expected the light preset's key color, got %#v", light.hlKey)
+	}
+
+	custom := resolveColors(config.Theme{Name: "dark", Gutter: "8", Syntax: config.SyntaxColors{Key: "#0000ff"}})
+	if custom.gutter != lipgloss.Color("8") || custom.hlKey != lipgloss.Color("#0000ff") {
+		t.Fatalf("expected overrides to apply, got %#v and %#v", custom.gutter, custom.hlKey)
+	}
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// parseTimestamp parses the RFC3339 timestamps Argo CD and Kubernetes return
+// (with or without fractional seconds).
+func parseTimestamp(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// relativeTime renders t as a compact "2m ago" relative to now.
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	if d < 0 {
+		d = 0
+	}
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd ago", int(d/(24*time.Hour)))
+	}
+}
+
+// agoTickInterval is how long until relativeTime's output for an age of d
+// next changes.
+func agoTickInterval(d time.Duration) time.Duration {
+	unit := time.Second
+	switch {
+	case d >= 24*time.Hour:
+		unit = 24 * time.Hour
+	case d >= time.Hour:
+		unit = time.Hour
+	case d >= time.Minute:
+		unit = time.Minute
+	}
+	if d < 0 {
+		return unit
+	}
+	return unit - d%unit
+}
+
+// formatTimestamp renders an API timestamp either relative to now or as a
+// local absolute time. Unparseable values are returned as-is.
+func formatTimestamp(s string, now time.Time, absolute bool) string {
+	t, ok := parseTimestamp(s)
+	if !ok {
+		return blankIfEmpty(strings.TrimSpace(s), "—")
+	}
+	if absolute {
+		return t.Local().Format("2006-01-02 15:04:05")
+	}
+	return relativeTime(t, now)
+}
+
+// truncate shortens s to at most w runes, marking the cut with an ellipsis.
+func truncate(s string, w int) string {
+	if w <= 0 {
+		return ""
+	}
+	r := []rune(s)
+	if len(r) <= w {
+		return s
+	}
+	if w == 1 {
+		return "…"
+	}
+	return string(r[:w-1]) + "…"
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"lazyargo/internal/argocd"
+)
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{ago: 5 * time.Second, want: "5s ago"},
+		{ago: 2*time.Minute + 30*time.Second, want: "2m ago"},
+		{ago: 3 * time.Hour, want: "3h ago"},
+		{ago: 50 * time.Hour, want: "2d ago"},
+		{ago: -time.Minute, want: "0s ago"},
+	}
+	for _, tt := range tests {
+		if got := relativeTime(now.Add(-tt.ago), now); got != tt.want {
+			t.Fatalf("relativeTime(-%s) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
+
+func TestFormatTimestamp_unparseable(t *testing.T) {
+	now := time.Now()
+	if got := formatTimestamp("yesterday", now, false); got != "yesterday" {
+		t.Fatalf("expected raw value back, got %q", got)
+	}
+	if got := formatTimestamp("", now, false); got != "—" {
+		t.Fatalf("expected placeholder for empty timestamp, got %q", got)
+	}
+	if got := formatTimestamp("2024-05-01T11:58:00.123456Z", time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), false); got != "1m ago" {
+		t.Fatalf("expected fractional seconds to parse, got %q", got)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("abcdef", 4); got != "abc…" {
+		t.Fatalf("got %q", got)
+	}
+	if got := truncate("abc", 4); got != "abc" {
+		t.Fatalf("got %q", got)
+	}
+	if got := truncate("abc", 0); got != "" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestAgoTickInterval(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want time.Duration
+	}{
+		{d: 0, want: time.Second},
+		{d: 1500 * time.Millisecond, want: 500 * time.Millisecond},
+		{d: 90 * time.Second, want: 30 * time.Second},
+		{d: 2*time.Hour + 15*time.Minute, want: 45 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := agoTickInterval(tt.d); got != tt.want {
+			t.Fatalf("agoTickInterval(%s) = %s, want %s", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestResourceExtras(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	r := argocd.Resource{CreatedAt: "2024-04-28T12:00:00Z", Images: []string{"ghcr.io/example/web:1.2", "envoy:1.29"}}
+	if got := resourceExtras(r, now); got != "3d  web:1.2 +1" {
+		t.Fatalf("got %q", got)
+	}
+	if got := resourceExtras(argocd.Resource{Kind: "Service"}, now); got != "" {
+		t.Fatalf("expected nothing for a resource without age or images, got %q", got)
+	}
+}